@@ -6,6 +6,7 @@ import (
 	"log"
 	"log/slog"
 	_ "net/http/pprof"
+	"os"
 	"runtime/debug"
 
 	sloggger "github.com/hectorgimenez/koolo/cmd/koolo/log"
@@ -14,15 +15,42 @@ import (
 	"github.com/hectorgimenez/koolo/internal/event"
 	"github.com/hectorgimenez/koolo/internal/helper"
 	"github.com/hectorgimenez/koolo/internal/helper/winproc"
+	"github.com/hectorgimenez/koolo/internal/journal"
+	"github.com/hectorgimenez/koolo/internal/ledger"
+	"github.com/hectorgimenez/koolo/internal/metrics"
 	"github.com/hectorgimenez/koolo/internal/overseer"
-	"github.com/hectorgimenez/koolo/internal/remote/discord"
-	"github.com/hectorgimenez/koolo/internal/remote/telegram"
+	"github.com/hectorgimenez/koolo/internal/remote"
 	"github.com/hectorgimenez/koolo/internal/server"
 	"github.com/inkeliz/gowebview"
 	"golang.org/x/sync/errgroup"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "mapcache":
+			if err := mapCache(os.Args[2:]); err != nil {
+				log.Fatalf("Error warming up map cache: %s", err.Error())
+			}
+			return
+		case "pickit":
+			if err := pickitCmd(os.Args[2:]); err != nil {
+				log.Fatalf("Error testing pickit rules: %s", err.Error())
+			}
+			return
+		case "mapserver":
+			if err := mapServer(os.Args[2:]); err != nil {
+				log.Fatalf("Error running map server: %s", err.Error())
+			}
+			return
+		case "replay":
+			if err := replayCmd(os.Args[2:]); err != nil {
+				log.Fatalf("Error replaying journal: %s", err.Error())
+			}
+			return
+		}
+	}
+
 	err := config.Load()
 	if err != nil {
 		helper.ShowDialog("Error loading configuration", err.Error())
@@ -36,6 +64,10 @@ func main() {
 	}
 	defer sloggger.FlushLog()
 
+	for _, defErr := range config.RunDefinitionErrors {
+		logger.Warn("Run definition skipped", slog.Any("error", defErr))
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("fatal error detected, Koolo will close with the following error: %v\n Stacktrace: %s", r, debug.Stack())
@@ -55,7 +87,15 @@ func main() {
 	eventListener := event.NewListener(logger)
 	manager := koolo.NewSupervisorManager(logger, eventListener)
 
-	srv, err := server.New(logger, manager)
+	drops, err := ledger.Open(config.Koolo.DropLedger.Directory, config.Koolo.DropLedger.Screenshots)
+	if err != nil {
+		log.Fatalf("Error opening drop ledger: %s", err.Error())
+	}
+	defer drops.Close()
+	// Ledger must not lose any drop, same as the stats
+	eventListener.Register(drops.Handle, event.WithOverflowPolicy(event.Block))
+
+	srv, err := server.New(logger, manager, drops)
 	if err != nil {
 		log.Fatalf("Error starting local server: %s", err.Error())
 	}
@@ -86,32 +126,23 @@ func main() {
 		return nil
 	})
 
-	// Discord Bot initialization
-	if config.Koolo.Discord.Enabled {
-		discordBot, err := discord.NewBot(config.Koolo.Discord.Token, config.Koolo.Discord.ChannelID)
-		if err != nil {
-			logger.Error("Discord could not been initialized", slog.Any("error", err))
-			return
-		}
-
-		eventListener.Register(discordBot.Handle)
-		g.Go(func() error {
-			return discordBot.Start(ctx)
-		})
+	err = remote.StartNotifiers(ctx, g, logger, func(h event.Handler) {
+		eventListener.Register(h)
+	})
+	if err != nil {
+		logger.Error("Notifiers could not been initialized", slog.Any("error", err))
+		return
 	}
 
-	// Telegram Bot initialization
-	if config.Koolo.Telegram.Enabled {
-		telegramBot, err := telegram.NewBot(config.Koolo.Telegram.Token, config.Koolo.Telegram.ChatID, logger)
+	if config.Koolo.Journal.Enabled {
+		recorder, err := journal.NewRecorder(config.Koolo.Journal.Directory, config.Koolo.Journal.MaxSizeMB, config.Koolo.Journal.MaxFiles, config.Koolo.Journal.IncludeScreenshots)
 		if err != nil {
-			logger.Error("Telegram could not been initialized", slog.Any("error", err))
+			logger.Error("Event journal could not been initialized", slog.Any("error", err))
 			return
 		}
+		defer recorder.Close()
 
-		eventListener.Register(telegramBot.Handle)
-		g.Go(func() error {
-			return telegramBot.Start(ctx)
-		})
+		eventListener.Register(recorder.Handle, event.WithQueueSize(1000))
 	}
 
 	g.Go(func() error {
@@ -128,6 +159,8 @@ func main() {
 		eventListener.Register(overseer.Handle)
 	}
 
+	eventListener.Register(metrics.Handle, event.WithOverflowPolicy(event.Block))
+
 	g.Go(func() error {
 		<-ctx.Done()
 		logger.Info("Koolo shutting down...")
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/game/map_client"
+)
+
+// mapCache pre-generates the map data for a list of seeds, so games using them start without waiting for koolo-map:
+//
+//	koolo mapcache -difficulty hell [-seeds 1234,5678] [-file seeds.txt]
+func mapCache(args []string) error {
+	fs := flag.NewFlagSet("mapcache", flag.ExitOnError)
+	difficultyFlag := fs.String("difficulty", string(difficulty.Hell), "difficulty: normal, nightmare or hell")
+	seedList := fs.String("seeds", "", "comma separated list of seeds")
+	file := fs.String("file", "", "file containing one seed per line")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	df, err := map_client.ParseDifficulty(*difficultyFlag)
+	if err != nil {
+		return err
+	}
+
+	seeds := splitSeeds(*seedList)
+	if *file != "" {
+		fromFile, err := readSeeds(*file)
+		if err != nil {
+			return err
+		}
+		seeds = append(seeds, fromFile...)
+	}
+	if len(seeds) == 0 {
+		return errors.New("no seeds provided")
+	}
+
+	if err = config.Load(); err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	cache, err := map_client.NewCache(config.Koolo.MapCache.Directory, config.Koolo.MapCache.MaxSizeMB)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	provider := map_client.NewProvider(config.Koolo.MapProvider.Type, config.Koolo.MapProvider.URL, config.Koolo.MapProvider.Token, config.Koolo.MapProvider.Directory)
+	generated, err := map_client.Prewarm(ctx, provider, cache, seeds, df)
+	maps, size := cache.Size()
+	fmt.Printf("Generated %d maps, cache contains %d maps (%.1f MB)\n", generated, maps, float64(size)/1024/1024)
+
+	return err
+}
+
+func splitSeeds(list string) []string {
+	seeds := make([]string, 0)
+	for _, s := range strings.Split(list, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			seeds = append(seeds, s)
+		}
+	}
+
+	return seeds
+}
+
+func readSeeds(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	seeds := make([]string, 0)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		seeds = append(seeds, splitSeeds(sc.Text())...)
+	}
+
+	return seeds, sc.Err()
+}
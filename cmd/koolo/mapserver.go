@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/game/map_client"
+)
+
+// mapServer serves the map data to other koolo hosts using the remote map provider, maps are generated locally
+// with koolo-map, so Diablo II: LoD 1.13c is only needed on this host. A token is required unless it only listens
+// on a loopback address:
+//
+//	koolo mapserver [-addr :8090] [-token secret]
+func mapServer(args []string) error {
+	fs := flag.NewFlagSet("mapserver", flag.ExitOnError)
+	addr := fs.String("addr", ":8090", "address to listen on")
+	token := fs.String("token", "", "token required to the clients, by default the one from mapProvider configuration")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := config.Load(); err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+	if *token == "" {
+		*token = config.Koolo.MapProvider.Token
+	}
+	if *token == "" && !map_client.IsLoopback(*addr) {
+		return fmt.Errorf("a token is required to listen on %s, set it or listen on a loopback address like 127.0.0.1:8090", *addr)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	// Server always caches, that's the whole point of having a single map host
+	cache, err := map_client.NewCache(config.Koolo.MapCache.Directory, config.Koolo.MapCache.MaxSizeMB)
+	if err != nil {
+		return err
+	}
+	provider := map_client.ExecutableProvider{D2LoDPath: config.Koolo.D2LoDPath}
+	srv := &http.Server{
+		Addr:    *addr,
+		Handler: map_client.NewServer(provider, cache, *token, logger).Handler(),
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	maps, size := cache.Size()
+	logger.Info("Map server listening", slog.String("addr", *addr), slog.Int("cachedMaps", maps), slog.Int64("cacheBytes", size))
+	if err = srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
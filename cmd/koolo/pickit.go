@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/ledger"
+	"github.com/hectorgimenez/koolo/internal/pickit"
+)
+
+// pickitCmd tests items against the pickit rules of a character, items are read from a JSON file or taken from
+// the latest entries of the drop ledger:
+//
+//	koolo pickit test -supervisor mysorc [-items items.json] [-ledger 10] [-v]
+func pickitCmd(args []string) error {
+	if len(args) == 0 || args[0] != "test" {
+		return errors.New("usage: koolo pickit test -supervisor <name> [-items file.json] [-ledger N] [-v]")
+	}
+
+	fs := flag.NewFlagSet("pickit test", flag.ExitOnError)
+	supervisor := fs.String("supervisor", "", "character whose pickit rules will be used")
+	itemsFile := fs.String("items", "", "JSON file with an item or a list of items, as data.Item or drop ledger entries")
+	fromLedger := fs.Int("ledger", 0, "test the latest N items of the drop ledger stashed by the supervisor")
+	verbose := fs.Bool("v", false, "print every rule matching the item type, not only the result")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *supervisor == "" {
+		return errors.New("supervisor is required")
+	}
+	if *itemsFile == "" && *fromLedger <= 0 {
+		return errors.New("items file or ledger is required")
+	}
+
+	if err := config.Load(); err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+	cfg, found := config.Characters[*supervisor]
+	if !found {
+		return fmt.Errorf("character %s not found", *supervisor)
+	}
+	rules, problems := config.ReadPickitRules(*supervisor, cfg)
+	for _, p := range problems {
+		fmt.Printf("%s %s\n", p.Severity, p)
+	}
+
+	items := make([]data.Item, 0)
+	if *itemsFile != "" {
+		raw, err := os.ReadFile(*itemsFile)
+		if err != nil {
+			return err
+		}
+		parsed, err := pickit.ParseItems(raw)
+		if err != nil {
+			return err
+		}
+		items = append(items, parsed...)
+	}
+
+	if *fromLedger > 0 {
+		entries, err := ledger.ReadEntries(config.Koolo.DropLedger.Directory, ledger.Filter{Supervisor: *supervisor})
+		if err != nil {
+			return err
+		}
+		for i, e := range entries {
+			if i >= *fromLedger {
+				break
+			}
+			it, err := pickit.FromLedger(e)
+			if err != nil {
+				return fmt.Errorf("error reading ledger item %s: %w", e.Name, err)
+			}
+			items = append(items, it)
+		}
+	}
+
+	fmt.Printf("%d rules loaded for %s\n", len(rules), *supervisor)
+	for _, it := range items {
+		printPickitReport(pickit.Test(rules, it, pickit.OfflineState(cfg)), *verbose)
+	}
+
+	return nil
+}
+
+func printPickitReport(r pickit.Report, verbose bool) {
+	fmt.Printf("\n%s (%s", r.Item, r.Quality)
+	if r.Ethereal {
+		fmt.Print(", ethereal")
+	}
+	fmt.Println(")")
+
+	fmt.Printf("  pickup: %s\n", decisionText(r.PickedUp, r.PickupReason, r.PickupRule))
+	fmt.Printf("  stash:  %s\n", decisionText(r.Stashed, r.StashReason, r.StashRule))
+
+	if !verbose {
+		return
+	}
+	for _, u := range r.Unchecked {
+		fmt.Printf("  not checked: %s\n", u)
+	}
+	for _, e := range r.Rules {
+		fmt.Printf("  %s:%d [pickup: %s, stash: %s] %s\n    %s\n", e.File, e.Line, e.Pickup, e.Stash, e.Reason, e.Rule)
+	}
+	fmt.Printf("  %d rules for other item types or qualities\n", r.NotMatching)
+}
+
+func decisionText(keep bool, reason, rule string) string {
+	text := "no, " + reason
+	if keep {
+		text = "yes, " + reason
+	}
+	if rule != "" {
+		text += " (" + rule + ")"
+	}
+
+	return text
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/journal"
+	"github.com/hectorgimenez/koolo/internal/overseer"
+	"github.com/hectorgimenez/koolo/internal/remote"
+	"github.com/hectorgimenez/koolo/internal/stats"
+	"golang.org/x/sync/errgroup"
+)
+
+// replayCmd feeds a recorded journal through the stats history and, optionally, the configured notifiers and the
+// Overseer broadcaster. It doesn't need the game running:
+//
+//	koolo replay -supervisor mysorc [-file journal/mysorc.1.jsonl] [-speed 10] [-notifiers] [-overseer] [-print]
+func replayCmd(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	supervisor := fs.String("supervisor", "", "supervisor whose journal will be replayed")
+	file := fs.String("file", "", "journal file to replay, by default all the journal files of the supervisor")
+	speed := fs.Float64("speed", 0, "replay speed relative to the original timing, 0 replays as fast as possible")
+	withNotifiers := fs.Bool("notifiers", false, "send the events to the configured Discord, Telegram and webhook notifiers")
+	withOverseer := fs.Bool("overseer", false, "print the messages the Overseer would broadcast to its websocket")
+	printEvents := fs.Bool("print", true, "print every replayed event")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *supervisor == "" {
+		return errors.New("supervisor is required")
+	}
+
+	if err := config.Load(); err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	files := []string{*file}
+	if *file == "" {
+		files = journal.Files(config.Koolo.Journal.Directory, *supervisor)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no journal found for %s", *supervisor)
+	}
+
+	// Replayed stats are kept apart from the real history
+	statsDir, err := os.MkdirTemp("", "koolo-replay-stats")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(statsDir)
+	history, err := stats.Open(statsDir, *supervisor)
+	if err != nil {
+		return err
+	}
+	defer history.Close()
+
+	handlers := []event.Handler{replayStatsHandler(*supervisor, history)}
+	if *printEvents {
+		handlers = append(handlers, func(_ context.Context, e event.Event) error {
+			fmt.Printf("%s [%s] %T %s\n", e.OccurredAt().Format(time.DateTime), e.Supervisor(), e, e.Message())
+			return nil
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	g, ctx := errgroup.WithContext(ctx)
+	if *withNotifiers {
+		err = remote.StartNotifiers(ctx, g, logger, func(h event.Handler) {
+			handlers = append(handlers, h)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if *withOverseer {
+		broadcast := newBroadcastPrinter(ctx)
+		if _, err = overseer.Setup(broadcast, nil); err != nil {
+			return err
+		}
+		handlers = append(handlers, overseer.Handle)
+	}
+
+	for _, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		replayed, err := journal.Replay(ctx, f, *speed, handlers...)
+		f.Close()
+		if err != nil {
+			logger.Error("Errors found replaying journal", slog.String("file", path), slog.Any("error", err))
+		}
+		logger.Info("Journal replayed", slog.String("file", path), slog.Int("events", replayed))
+	}
+
+	printReplaySummary(history)
+
+	// Give some time to the notifiers to deliver the queued events
+	if *withNotifiers {
+		time.Sleep(5 * time.Second)
+	}
+	// Notifiers are not waited, Telegram bot doesn't stop on context cancellation
+	cancel()
+
+	return nil
+}
+
+// replayStatsHandler persists the events of the supervisor the same way the bot does
+func replayStatsHandler(supervisor string, history *stats.Store) event.Handler {
+	return func(_ context.Context, e event.Event) error {
+		if !strings.EqualFold(e.Supervisor(), supervisor) {
+			return nil
+		}
+
+		record, ok := stats.FromEvent(e)
+		if !ok {
+			return nil
+		}
+
+		return history.Append(record)
+	}
+}
+
+// broadcastPrinter takes the place of the websocket server, the Overseer broadcast messages are printed instead
+type broadcastPrinter struct {
+	messages chan []byte
+}
+
+func newBroadcastPrinter(ctx context.Context) broadcastPrinter {
+	p := broadcastPrinter{messages: make(chan []byte)}
+	go func() {
+		for {
+			select {
+			case msg := <-p.messages:
+				fmt.Printf("overseer: %s\n", msg)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return p
+}
+
+func (p broadcastPrinter) GetOverseerChannel() chan []byte {
+	return p.messages
+}
+
+func printReplaySummary(history *stats.Store) {
+	records := history.Records(time.Time{}, time.Time{})
+	s := stats.Summarize(records, time.Time{}, time.Time{})
+	fmt.Printf("\nGames: %d, Deaths: %d, Chickens: %d, Errors: %d, Drops: %d\n", s.Games, s.Deaths, s.Chickens, s.Errors, s.Drops)
+
+	for _, r := range stats.AnalyzeRuns(records) {
+		fmt.Printf("%s: %d runs, p50 %s, success %.1f%%, chicken %.1f%%, death %.1f%%\n", r.Name, r.Runs, r.P50.Round(time.Second), r.SuccessRate*100, r.ChickenRate*100, r.DeathRate*100)
+	}
+}
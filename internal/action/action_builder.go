@@ -1,6 +1,8 @@
 package action
 
 import (
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
 	"github.com/hectorgimenez/koolo/internal/container"
 	"github.com/hectorgimenez/koolo/internal/health"
 	"github.com/hectorgimenez/koolo/internal/town"
@@ -11,13 +13,27 @@ type Builder struct {
 	bm health.BeltManager
 	ch Character
 	container.Container
+	// Where every item was picked up, it's sent with the item when it's stashed
+	pickedUpIn map[data.UnitID]pickup
+	currentRun string
+}
+
+type pickup struct {
+	area    area.ID
+	runName string
 }
 
 func NewBuilder(container container.Container, sm town.ShopManager, bm health.BeltManager, ch Character) *Builder {
 	return &Builder{
-		sm:        sm,
-		bm:        bm,
-		ch:        ch,
-		Container: container,
+		sm:         sm,
+		bm:         bm,
+		ch:         ch,
+		Container:  container,
+		pickedUpIn: make(map[data.UnitID]pickup),
 	}
 }
+
+// SetCurrentRun sets the run in progress, picked up items are attributed to it
+func (b *Builder) SetCurrentRun(name string) {
+	b.currentRun = name
+}
@@ -59,9 +59,9 @@ func (a *StepChainAction) NextStep(d game.Data, container container.Container) e
 					return errors.Join(err, ErrLogAndContinue)
 				}
 				if a.canBeSkipped {
-					return fmt.Errorf("%w: attempt limit reached on step: %s: %v", ErrCanBeSkipped, reflect.TypeOf(s).Elem().Name(), err)
+					return fmt.Errorf("%w: attempt limit reached on step: %s: %w", ErrCanBeSkipped, reflect.TypeOf(s).Elem().Name(), err)
 				}
-				return fmt.Errorf("%w: attempt limit reached on step: %s: %v", ErrNoRecover, reflect.TypeOf(s).Elem().Name(), err)
+				return fmt.Errorf("%w: attempt limit reached on step: %s: %w", ErrNoRecover, reflect.TypeOf(s).Elem().Name(), err)
 			}
 
 			return nil
@@ -0,0 +1,183 @@
+package action_test
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/container"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/simulator"
+)
+
+// fakeStep is completed after running the given times, every run fails if err is set
+type fakeStep struct {
+	runs          int
+	completeAfter int
+	resets        int
+	err           error
+	lastRun       time.Time
+}
+
+func (s *fakeStep) Status(_ game.Data, _ container.Container) step.Status {
+	if s.runs >= s.completeAfter {
+		return step.StatusCompleted
+	}
+	if s.runs > 0 {
+		return step.StatusInProgress
+	}
+
+	return step.StatusNotStarted
+}
+
+func (s *fakeStep) Run(_ game.Data, _ container.Container) error {
+	s.runs++
+	s.lastRun = time.Now()
+
+	return s.err
+}
+
+func (s *fakeStep) Reset() {
+	s.resets++
+}
+
+func (s *fakeStep) LastRun() time.Time {
+	return s.lastRun
+}
+
+func newContainer() (*simulator.Simulator, container.Container) {
+	sim := simulator.New(&config.CharacterCfg{}, data.Data{})
+
+	return sim, sim.Container("test", slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func steps(s ...step.Step) func(d game.Data) []step.Step {
+	return func(d game.Data) []step.Step {
+		return s
+	}
+}
+
+func TestStepChainActionRunsStepsInOrder(t *testing.T) {
+	sim, c := newContainer()
+	first := &fakeStep{completeAfter: 2}
+	second := &fakeStep{completeAfter: 1}
+	chain := action.NewStepChain(steps(first, second))
+
+	for i := 0; i < 3; i++ {
+		if err := chain.NextStep(sim.GetData(false), c); err != nil {
+			t.Fatalf("iteration %d: unexpected error: %v", i, err)
+		}
+	}
+	if first.runs != 2 || second.runs != 1 {
+		t.Fatalf("steps should run one after the other, got %d and %d runs", first.runs, second.runs)
+	}
+
+	if err := chain.NextStep(sim.GetData(false), c); !errors.Is(err, action.ErrNoMoreSteps) {
+		t.Fatalf("expected ErrNoMoreSteps, got %v", err)
+	}
+	if !chain.IsFinished() {
+		t.Error("chain should be finished")
+	}
+}
+
+func TestStepChainActionErrors(t *testing.T) {
+	stepErr := errors.New("step failed")
+
+	tests := []struct {
+		name     string
+		opts     []action.Option
+		expected error
+		finished bool
+	}{
+		{name: "not recoverable by default", expected: action.ErrNoRecover},
+		{name: "can be skipped", opts: []action.Option{action.CanBeSkipped()}, expected: action.ErrCanBeSkipped},
+		{name: "ignore errors", opts: []action.Option{action.IgnoreErrors()}, expected: action.ErrLogAndContinue, finished: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim, c := newContainer()
+			failing := &fakeStep{completeAfter: 100, err: stepErr}
+			chain := action.NewStepChain(steps(failing), tt.opts...)
+
+			// Errors are retried until the attempt limit is reached
+			for i := 0; i < 2; i++ {
+				if err := chain.NextStep(sim.GetData(false), c); err != nil {
+					t.Fatalf("attempt %d should be retried, got %v", i+1, err)
+				}
+			}
+
+			err := chain.NextStep(sim.GetData(false), c)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+			if tt.expected != action.ErrLogAndContinue && !errors.Is(err, stepErr) {
+				t.Errorf("step error should be wrapped, got %v", err)
+			}
+			if chain.IsFinished() != tt.finished {
+				t.Errorf("expected finished %t, got %t", tt.finished, chain.IsFinished())
+			}
+		})
+	}
+}
+
+func TestStepChainActionResettable(t *testing.T) {
+	sim, c := newContainer()
+	done := &fakeStep{completeAfter: 0}
+	failing := &fakeStep{completeAfter: 100, err: errors.New("step failed")}
+
+	chain := action.NewStepChain(steps(done, failing), action.Resettable())
+	_ = chain.NextStep(sim.GetData(false), c)
+	if done.resets != 1 || failing.resets != 1 {
+		t.Errorf("every step should be reset after an error, got %d and %d resets", done.resets, failing.resets)
+	}
+
+	chain = action.NewStepChain(steps(done, failing))
+	_ = chain.NextStep(sim.GetData(false), c)
+	if done.resets != 1 {
+		t.Error("steps should not be reset when the chain is not resettable")
+	}
+}
+
+func TestStepChainActionRepeatUntilNoSteps(t *testing.T) {
+	sim, c := newContainer()
+	built := 0
+	chain := action.NewStepChain(func(d game.Data) []step.Step {
+		built++
+		if built > 2 {
+			return nil
+		}
+		return []step.Step{&fakeStep{completeAfter: 1}}
+	}, action.RepeatUntilNoSteps())
+
+	var err error
+	for i := 0; i < 10 && err == nil; i++ {
+		err = chain.NextStep(sim.GetData(false), c)
+	}
+	if !errors.Is(err, action.ErrNoMoreSteps) {
+		t.Fatalf("expected ErrNoMoreSteps, got %v", err)
+	}
+	if built != 3 {
+		t.Errorf("builder should be called until no steps are returned, called %d times", built)
+	}
+}
+
+func TestStepChainActionSkip(t *testing.T) {
+	sim, c := newContainer()
+	s := &fakeStep{completeAfter: 100}
+	chain := action.NewStepChain(steps(s))
+	chain.Skip()
+
+	if err := chain.NextStep(sim.GetData(false), c); !errors.Is(err, action.ErrNoMoreSteps) {
+		t.Fatalf("expected ErrNoMoreSteps, got %v", err)
+	}
+	if s.runs != 0 {
+		t.Error("skipped chain should not run its steps")
+	}
+}
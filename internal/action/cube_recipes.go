@@ -9,8 +9,10 @@ import (
 )
 
 type CubeRecipe struct {
-	Name  string
-	Items []string
+	Name string
+	// Output is the item created by the recipe, used to skip it when its quota is reached
+	Output item.Name
+	Items  []string
 }
 
 var (
@@ -18,174 +20,215 @@ var (
 
 		// Perfects
 		{
-			Name:  "Perfect Amethyst",
-			Items: []string{"FlawlessAmethyst", "FlawlessAmethyst", "FlawlessAmethyst"},
+			Name:   "Perfect Amethyst",
+			Output: "PerfectAmethyst",
+			Items:  []string{"FlawlessAmethyst", "FlawlessAmethyst", "FlawlessAmethyst"},
 		},
 		{
-			Name:  "Perfect Diamond",
-			Items: []string{"FlawlessDiamond", "FlawlessDiamond", "FlawlessDiamond"},
+			Name:   "Perfect Diamond",
+			Output: "PerfectDiamond",
+			Items:  []string{"FlawlessDiamond", "FlawlessDiamond", "FlawlessDiamond"},
 		},
 		{
-			Name:  "Perfect Emerald",
-			Items: []string{"FlawlessEmerald", "FlawlessEmerald", "FlawlessEmerald"},
+			Name:   "Perfect Emerald",
+			Output: "PerfectEmerald",
+			Items:  []string{"FlawlessEmerald", "FlawlessEmerald", "FlawlessEmerald"},
 		},
 		{
-			Name:  "Perfect Ruby",
-			Items: []string{"FlawlessRuby", "FlawlessRuby", "FlawlessRuby"},
+			Name:   "Perfect Ruby",
+			Output: "PerfectRuby",
+			Items:  []string{"FlawlessRuby", "FlawlessRuby", "FlawlessRuby"},
 		},
 		{
-			Name:  "Perfect Sapphire",
-			Items: []string{"FlawlessSapphire", "FlawlessSapphire", "FlawlessSapphire"},
+			Name:   "Perfect Sapphire",
+			Output: "PerfectSapphire",
+			Items:  []string{"FlawlessSapphire", "FlawlessSapphire", "FlawlessSapphire"},
 		},
 		{
-			Name:  "Perfect Topaz",
-			Items: []string{"FlawlessTopaz", "FlawlessTopaz", "FlawlessTopaz"},
+			Name:   "Perfect Topaz",
+			Output: "PerfectTopaz",
+			Items:  []string{"FlawlessTopaz", "FlawlessTopaz", "FlawlessTopaz"},
 		},
 		{
-			Name:  "Perfect Skull",
-			Items: []string{"FlawlessSkull", "FlawlessSkull", "FlawlessSkull"},
+			Name:   "Perfect Skull",
+			Output: "PerfectSkull",
+			Items:  []string{"FlawlessSkull", "FlawlessSkull", "FlawlessSkull"},
 		},
 
 		// Token
 		{
-			Name:  "Token of Absolution",
-			Items: []string{"TwistedEssenceOfSuffering", "ChargedEssenceOfHatred", "BurningEssenceOfTerror", "FesteringEssenceOfDestruction"},
+			Name:   "Token of Absolution",
+			Output: "TokenofAbsolution",
+			Items:  []string{"TwistedEssenceOfSuffering", "ChargedEssenceOfHatred", "BurningEssenceOfTerror", "FesteringEssenceOfDestruction"},
 		},
 
 		// Runes
 		{
-			Name:  "Upgrade El",
-			Items: []string{"ElRune", "ElRune", "ElRune"},
+			Name:   "Upgrade El",
+			Output: "EldRune",
+			Items:  []string{"ElRune", "ElRune", "ElRune"},
 		},
 		{
-			Name:  "Upgrade Eld",
-			Items: []string{"EldRune", "EldRune", "EldRune"},
+			Name:   "Upgrade Eld",
+			Output: "TirRune",
+			Items:  []string{"EldRune", "EldRune", "EldRune"},
 		},
 		{
-			Name:  "Upgrade Tir",
-			Items: []string{"TirRune", "TirRune", "TirRune"},
+			Name:   "Upgrade Tir",
+			Output: "NefRune",
+			Items:  []string{"TirRune", "TirRune", "TirRune"},
 		},
 		{
-			Name:  "Upgrade Nef",
-			Items: []string{"NefRune", "NefRune", "NefRune"},
+			Name:   "Upgrade Nef",
+			Output: "EthRune",
+			Items:  []string{"NefRune", "NefRune", "NefRune"},
 		},
 		{
-			Name:  "Upgrade Eth",
-			Items: []string{"EthRune", "EthRune", "EthRune"},
+			Name:   "Upgrade Eth",
+			Output: "IthRune",
+			Items:  []string{"EthRune", "EthRune", "EthRune"},
 		},
 		{
-			Name:  "Upgrade Ith",
-			Items: []string{"IthRune", "IthRune", "IthRune"},
+			Name:   "Upgrade Ith",
+			Output: "TalRune",
+			Items:  []string{"IthRune", "IthRune", "IthRune"},
 		},
 		{
-			Name:  "Upgrade Tal",
-			Items: []string{"TalRune", "TalRune", "TalRune"},
+			Name:   "Upgrade Tal",
+			Output: "RalRune",
+			Items:  []string{"TalRune", "TalRune", "TalRune"},
 		},
 		{
-			Name:  "Upgrade Ral",
-			Items: []string{"RalRune", "RalRune", "RalRune"},
+			Name:   "Upgrade Ral",
+			Output: "OrtRune",
+			Items:  []string{"RalRune", "RalRune", "RalRune"},
 		},
 		{
-			Name:  "Upgrade Ort",
-			Items: []string{"OrtRune", "OrtRune", "OrtRune"},
+			Name:   "Upgrade Ort",
+			Output: "ThulRune",
+			Items:  []string{"OrtRune", "OrtRune", "OrtRune"},
 		},
 		{
-			Name:  "Upgrade Thul",
-			Items: []string{"ThulRune", "ThulRune", "ThulRune", "ChippedTopaz"},
+			Name:   "Upgrade Thul",
+			Output: "AmnRune",
+			Items:  []string{"ThulRune", "ThulRune", "ThulRune", "ChippedTopaz"},
 		},
 		{
-			Name:  "Upgrade Amn",
-			Items: []string{"AmnRune", "AmnRune", "AmnRune", "ChippedAmethyst"},
+			Name:   "Upgrade Amn",
+			Output: "SolRune",
+			Items:  []string{"AmnRune", "AmnRune", "AmnRune", "ChippedAmethyst"},
 		},
 		{
-			Name:  "Upgrade Sol",
-			Items: []string{"SolRune", "SolRune", "SolRune", "ChippedSapphire"},
+			Name:   "Upgrade Sol",
+			Output: "ShaelRune",
+			Items:  []string{"SolRune", "SolRune", "SolRune", "ChippedSapphire"},
 		},
 		{
-			Name:  "Upgrade Shael",
-			Items: []string{"ShaelRune", "ShaelRune", "ShaelRune", "ChippedRuby"},
+			Name:   "Upgrade Shael",
+			Output: "DolRune",
+			Items:  []string{"ShaelRune", "ShaelRune", "ShaelRune", "ChippedRuby"},
 		},
 		{
-			Name:  "Upgrade Dol",
-			Items: []string{"DolRune", "DolRune", "DolRune", "ChippedEmerald"},
+			Name:   "Upgrade Dol",
+			Output: "HelRune",
+			Items:  []string{"DolRune", "DolRune", "DolRune", "ChippedEmerald"},
 		},
 		{
-			Name:  "Upgrade Hel",
-			Items: []string{"HelRune", "HelRune", "HelRune", "ChippedDiamond"},
+			Name:   "Upgrade Hel",
+			Output: "IoRune",
+			Items:  []string{"HelRune", "HelRune", "HelRune", "ChippedDiamond"},
 		},
 		{
-			Name:  "Upgrade Io",
-			Items: []string{"IoRune", "IoRune", "IoRune", "FlawedTopaz"},
+			Name:   "Upgrade Io",
+			Output: "LumRune",
+			Items:  []string{"IoRune", "IoRune", "IoRune", "FlawedTopaz"},
 		},
 		{
-			Name:  "Upgrade Lum",
-			Items: []string{"LumRune", "LumRune", "LumRune", "FlawedAmethyst"},
+			Name:   "Upgrade Lum",
+			Output: "KoRune",
+			Items:  []string{"LumRune", "LumRune", "LumRune", "FlawedAmethyst"},
 		},
 		{
-			Name:  "Upgrade Ko",
-			Items: []string{"KoRune", "KoRune", "KoRune", "FlawedSapphire"},
+			Name:   "Upgrade Ko",
+			Output: "FalRune",
+			Items:  []string{"KoRune", "KoRune", "KoRune", "FlawedSapphire"},
 		},
 		{
-			Name:  "Upgrade Fal",
-			Items: []string{"FalRune", "FalRune", "FalRune", "FlawedRuby"},
+			Name:   "Upgrade Fal",
+			Output: "LemRune",
+			Items:  []string{"FalRune", "FalRune", "FalRune", "FlawedRuby"},
 		},
 		{
-			Name:  "Upgrade Lem",
-			Items: []string{"LemRune", "LemRune", "LemRune", "FlawedEmerald"},
+			Name:   "Upgrade Lem",
+			Output: "PulRune",
+			Items:  []string{"LemRune", "LemRune", "LemRune", "FlawedEmerald"},
 		},
 		{
-			Name:  "Upgrade Pul",
-			Items: []string{"PulRune", "PulRune", "FlawedDiamond"},
+			Name:   "Upgrade Pul",
+			Output: "UmRune",
+			Items:  []string{"PulRune", "PulRune", "FlawedDiamond"},
 		},
 		{
-			Name:  "Upgrade Um",
-			Items: []string{"UmRune", "UmRune", "Topaz"},
+			Name:   "Upgrade Um",
+			Output: "MalRune",
+			Items:  []string{"UmRune", "UmRune", "Topaz"},
 		},
 		{
-			Name:  "Upgrade Mal",
-			Items: []string{"MalRune", "MalRune", "Amethyst"},
+			Name:   "Upgrade Mal",
+			Output: "IstRune",
+			Items:  []string{"MalRune", "MalRune", "Amethyst"},
 		},
 		{
-			Name:  "Upgrade Ist",
-			Items: []string{"IstRune", "IstRune", "Sapphire"},
+			Name:   "Upgrade Ist",
+			Output: "GulRune",
+			Items:  []string{"IstRune", "IstRune", "Sapphire"},
 		},
 		{
-			Name:  "Upgrade Gul",
-			Items: []string{"GulRune", "GulRune", "Ruby"},
+			Name:   "Upgrade Gul",
+			Output: "VexRune",
+			Items:  []string{"GulRune", "GulRune", "Ruby"},
 		},
 		{
-			Name:  "Upgrade Vex",
-			Items: []string{"VexRune", "VexRune", "Emerald"},
+			Name:   "Upgrade Vex",
+			Output: "OhmRune",
+			Items:  []string{"VexRune", "VexRune", "Emerald"},
 		},
 		{
-			Name:  "Upgrade Ohm",
-			Items: []string{"OhmRune", "OhmRune", "Diamond"},
+			Name:   "Upgrade Ohm",
+			Output: "LoRune",
+			Items:  []string{"OhmRune", "OhmRune", "Diamond"},
 		},
 		{
-			Name:  "Upgrade Lo",
-			Items: []string{"LoRune", "LoRune", "FlawlessTopaz"},
+			Name:   "Upgrade Lo",
+			Output: "SurRune",
+			Items:  []string{"LoRune", "LoRune", "FlawlessTopaz"},
 		},
 		{
-			Name:  "Upgrade Sur",
-			Items: []string{"SurRune", "SurRune", "FlawlessAmethyst"},
+			Name:   "Upgrade Sur",
+			Output: "BerRune",
+			Items:  []string{"SurRune", "SurRune", "FlawlessAmethyst"},
 		},
 		{
-			Name:  "Upgrade Ber",
-			Items: []string{"BerRune", "BerRune", "FlawlessSapphire"},
+			Name:   "Upgrade Ber",
+			Output: "JahRune",
+			Items:  []string{"BerRune", "BerRune", "FlawlessSapphire"},
 		},
 		{
-			Name:  "Upgrade Jah",
-			Items: []string{"JahRune", "JahRune", "FlawlessRuby"},
+			Name:   "Upgrade Jah",
+			Output: "ChamRune",
+			Items:  []string{"JahRune", "JahRune", "FlawlessRuby"},
 		},
 		{
-			Name:  "Upgrade Cham",
-			Items: []string{"ChamRune", "ChamRune", "FlawlessEmerald"},
+			Name:   "Upgrade Cham",
+			Output: "ZodRune",
+			Items:  []string{"ChamRune", "ChamRune", "FlawlessEmerald"},
 		},
 
 		// Misc
 		{
-			Name:  "Full Rejuv",
-			Items: []string{"RejuvenationPotion", "RejuvenationPotion", "RejuvenationPotion"},
+			Name:   "Full Rejuv",
+			Output: "FullRejuvenationPotion",
+			Items:  []string{"RejuvenationPotion", "RejuvenationPotion", "RejuvenationPotion"},
 		},
 	}
 )
@@ -205,9 +248,20 @@ func (b *Builder) CubeRecipes() *Chain {
 				continue
 			}
 
+			// Recipes are not transmuted beyond the quota of the item they create
+			remaining := b.quotaRemainingByName(recipe.Output, d)
+			if remaining == 0 {
+				continue
+			}
+
 			continueProcessing := true
 			for continueProcessing {
 				if items, hasItems := b.hasItemsForRecipe(itemsInStash, recipe); hasItems {
+					if remaining > 0 {
+						remaining--
+						continueProcessing = remaining > 0
+					}
+
 					// Add items to the cube and perform the transmutation
 					actions = append(actions, b.CubeAddItems(items...))
 					actions = append(actions, b.CubeTransmute())
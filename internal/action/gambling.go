@@ -2,6 +2,7 @@ package action
 
 import (
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
@@ -13,7 +14,6 @@ import (
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/town"
 	"github.com/hectorgimenez/koolo/internal/ui"
-	"github.com/lxn/win"
 )
 
 func (b *Builder) Gamble() *Chain {
@@ -22,12 +22,12 @@ func (b *Builder) Gamble() *Chain {
 		if d.CharacterCfg.Gambling.Enabled && stashedGold.Value >= 2500000 {
 			b.Logger.Info("Time to gamble! Visiting vendor...")
 
-			openShopStep := step.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_DOWN, win.VK_RETURN)
+			openShopStep := step.KeySequence(game.VKHome, game.VKDown, game.VKDown, game.VKReturn)
 			vendorNPC := town.GetTownByArea(d.PlayerUnit.Area).GamblingNPC()
 
 			// Jamella gamble button is the second one
 			if vendorNPC == npc.Jamella {
-				openShopStep = step.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)
+				openShopStep = step.KeySequence(game.VKHome, game.VKDown, game.VKReturn)
 			}
 
 			// Fix for Anya position
@@ -59,7 +59,7 @@ func (b *Builder) gambleItems() *StepChainAction {
 		if lastStep {
 			if d.OpenMenus.Inventory {
 				return []step.Step{step.SyncStep(func(d game.Data) error {
-					b.HID.PressKey(win.VK_ESCAPE)
+					b.HID.PressKey(game.VKEscape)
 					return nil
 				})}
 			}
@@ -78,7 +78,7 @@ func (b *Builder) gambleItems() *StepChainAction {
 				}
 			}
 
-			if _, result := d.CharacterCfg.Runtime.Rules.EvaluateAll(itemBought); result == nip.RuleResultFullMatch {
+			if rule, result := d.CharacterCfg.Runtime.Rules.EvaluateAll(itemBought); result == nip.RuleResultFullMatch && !b.quotaReached(itemBought, rule, d) {
 				lastStep = true
 				return []step.Step{step.Wait(time.Millisecond * 200)}
 			} else {
@@ -96,9 +96,19 @@ func (b *Builder) gambleItems() *StepChainAction {
 			return []step.Step{step.Wait(time.Millisecond * 200)}
 		}
 
-		for idx, itmName := range d.CharacterCfg.Gambling.Items {
+		// Items with the quota already reached are not bought
+		gambleItems := slices.DeleteFunc(slices.Clone(d.CharacterCfg.Gambling.Items), func(name item.Name) bool {
+			return b.quotaRemainingByName(name, d) == 0
+		})
+		if len(gambleItems) == 0 {
+			b.Logger.Info("Quotas reached for all the gambling items")
+			lastStep = true
+			return []step.Step{step.Wait(time.Millisecond * 200)}
+		}
+
+		for idx, itmName := range gambleItems {
 			// Let's try to get one of each every time
-			if currentIdx == len(d.CharacterCfg.Gambling.Items) {
+			if currentIdx == len(gambleItems) {
 				currentIdx = 0
 			}
 
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/hectorgimenez/koolo/internal/game"
-	"github.com/lxn/win"
 
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/helper"
@@ -29,7 +28,7 @@ func (b *Builder) Heal() *Chain {
 				town.GetTownByArea(d.PlayerUnit.Area).HealNPC(),
 				step.SyncStep(func(d game.Data) error {
 					helper.Sleep(300)
-					b.HID.PressKey(win.VK_ESCAPE)
+					b.HID.PressKey(game.VKEscape)
 					helper.Sleep(100)
 					return nil
 				}),
@@ -13,6 +13,10 @@ Be sure to do appropriate checks to avoid infinite loops or unwanted behavior, s
 
 // NewGameHook is executed when a new game is created. Actions returned here will be executed when a new game is created before the main actions.
 func (b *Builder) NewGameHook() []Action {
+	// Unit IDs are reused across games, items not stashed in the previous game can't be attributed to its runs
+	clear(b.pickedUpIn)
+	b.currentRun = ""
+
 	return []Action{
 		b.SwitchToLegacyMode(),
 	}
@@ -10,7 +10,6 @@ import (
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/helper"
 	"github.com/hectorgimenez/koolo/internal/ui"
-	"github.com/lxn/win"
 )
 
 func (b *Builder) CubeAddItems(items ...data.Item) *Chain {
@@ -112,7 +111,7 @@ func (b *Builder) CubeTransmute() *Chain {
 
 			return []step.Step{
 				step.SyncStepWithCheck(func(d game.Data) error {
-					b.HID.PressKey(win.VK_ESCAPE)
+					b.HID.PressKey(game.VKEscape)
 					helper.Sleep(300)
 					return nil
 				}, func(d game.Data) step.Status {
@@ -10,7 +10,6 @@ import (
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/helper"
-	"github.com/lxn/win"
 )
 
 func (b *Builder) IdentifyAll(skipIdentify bool) *Chain {
@@ -52,7 +51,7 @@ func (b *Builder) IdentifyAll(skipIdentify bool) *Chain {
 						b.identifyItem(idTome, i)
 					}
 
-					b.HID.PressKey(win.VK_ESCAPE)
+					b.HID.PressKey(game.VKEscape)
 
 					return nil
 				}),
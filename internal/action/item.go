@@ -8,33 +8,56 @@ import (
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/helper"
+	"github.com/hectorgimenez/koolo/internal/quota"
 )
 
-func (b *Builder) doesExceedQuantity(i data.Item, rule nip.Rule, d game.Data) bool {
-	stashItems := d.Inventory.ByLocation(item.LocationStash, item.LocationSharedStash)
+// quotaRemaining returns how many more items like the given one the character can keep, -1 if there is no limit.
+// Configured quotas and the NIP maxquantity of the rule are checked, counting the items in the stash, inventory and cube.
+func (b *Builder) quotaRemaining(i data.Item, rule nip.Rule, d game.Data) int {
+	return b.quotaRemainingWith(i, rule, quota.Owned(b.Supervisor, d.Inventory, b.CharacterCfg.Quotas.SharedWith))
+}
 
-	maxQuantity := rule.MaxQuantity()
-	if maxQuantity == 0 {
-		return false
+// quotaRemainingWith is like quotaRemaining counting only the given items
+func (b *Builder) quotaRemainingWith(i data.Item, rule nip.Rule, owned []data.Item) int {
+	quotas := quota.FromConfig(b.CharacterCfg.Quotas.Items, b.CharacterCfg.Runtime.Rules)
+	if rule.MaxQuantity() > 0 {
+		quotas = append(quotas, quota.FromRule(rule))
+	}
+	if len(quotas) == 0 {
+		return -1
 	}
 
-	if maxQuantity == 0 {
-		b.Logger.Debug(fmt.Sprintf("Max quantity for %s item is 0, skipping further logic", i.Name))
-		return false
+	q, remaining := quota.Remaining(quotas, owned, i)
+	if remaining == 0 {
+		b.Logger.Debug(fmt.Sprintf("Quota %s reached for item %s, max is %d", q, i.Name, q.Max))
 	}
 
-	matchedItemsInStash := 0
+	return remaining
+}
 
-	for _, stashItem := range stashItems {
-		res, _ := rule.Evaluate(stashItem)
-		if res == nip.RuleResultFullMatch {
-			matchedItemsInStash += 1
-		}
-	}
+func (b *Builder) quotaReached(i data.Item, rule nip.Rule, d game.Data) bool {
+	return b.quotaRemaining(i, rule, d) == 0
+}
 
-	b.Logger.Debug(fmt.Sprintf("For item %s found %d max quantity from pickit rule, number of items in the stash tabs %d", i.Name, maxQuantity, matchedItemsInStash))
+// quotaRemainingByName checks the quotas for a new identified item of normal quality, like gems and runes created
+// by cube recipes or gambling items before being bought
+func (b *Builder) quotaRemainingByName(name item.Name, d game.Data) int {
+	id := item.GetIDByName(string(name))
+	if id < 0 {
+		return -1
+	}
+	i := data.Item{
+		ID:         id,
+		Name:       item.GetNameByEnum(uint(id)),
+		Quality:    item.QualityNormal,
+		Identified: true,
+	}
+	rule, res := b.CharacterCfg.Runtime.Rules.EvaluateAll(i)
+	if res != nip.RuleResultFullMatch {
+		rule = nip.Rule{}
+	}
 
-	return matchedItemsInStash >= maxQuantity
+	return b.quotaRemaining(i, rule, d)
 }
 
 func (b *Builder) DropMouseItem() *Chain {
@@ -15,6 +15,7 @@ import (
 	"github.com/hectorgimenez/d2go/pkg/data/stat"
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/pather"
+	"github.com/hectorgimenez/koolo/internal/pickit"
 )
 
 func (b *Builder) ItemPickup(waitForDrop bool, maxDistance int) *Chain {
@@ -58,6 +59,7 @@ func (b *Builder) ItemPickup(waitForDrop bool, maxDistance int) *Chain {
 			))
 
 			itemBeingPickedUp = i.UnitID
+			b.pickedUpIn[i.UnitID] = pickup{area: d.PlayerUnit.Area, runName: b.currentRun}
 			return []Action{
 				b.MoveToCoords(i.Position),
 				NewStepChain(func(d game.Data) []step.Step {
@@ -132,61 +134,28 @@ func (b *Builder) getItemsToPickup(d game.Data, maxDistance int) []data.Item {
 }
 
 func (b *Builder) shouldBePickedUp(d game.Data, i data.Item) bool {
-	if i.IsRuneword {
-		return true
+	decision := pickit.ShouldPickUp(d.CharacterCfg.Runtime.Rules, i, b.pickitState(d))
+	if !decision.Keep && i.Name == "Gold" {
+		b.Logger.Debug("Skipping gold pickup", slog.String("reason", decision.Reason))
 	}
 
-	// Skip picking up gold if we can not carry more
-	gold, _ := d.PlayerUnit.FindStat(stat.Gold, 0)
-	if gold.Value >= d.PlayerUnit.MaxGold() && i.Name == "Gold" {
-		b.Logger.Debug("Skipping gold pickup, inventory full")
-		return false
-	}
-
-	// Always pickup WirtsLeg!
-	if i.Name == "WirtsLeg" {
-		return true
-	}
-
-	// Pick up quest items if we're in leveling or questing run
-	specialRuns := slices.Contains(b.CharacterCfg.Game.Runs, "quests") || slices.Contains(b.CharacterCfg.Game.Runs, "leveling")
-	switch i.Name {
-	case "Scrollofinifuss", "LamEsensTome", "HoradricCube", "AmuletoftheViper", "StaffofKings", "HoradricStaff", "AJadeFigurine", "KhalimsEye", "KhalimsBrain", "KhalimsHeart", "KhalimsFlail":
-		if specialRuns {
-			return true
-		}
-	}
-
-	// Book of Skill doesnt work by name, so we find it by ID
-	if i.ID == 552 {
-		return true
-	}
+	return decision.Keep
+}
 
-	// Only during leveling if gold amount is low pickup items to sell as junk
+// pickitState is the character state used by the pickup and stash decisions
+func (b *Builder) pickitState(d game.Data) pickit.State {
 	_, isLevelingChar := b.ch.(LevelingCharacter)
+	gold, _ := d.PlayerUnit.FindStat(stat.Gold, 0)
 
-	// Skip picking up gold, usually early game there are small amounts of gold in many places full of enemies, better
-	// stay away of that
-	if isLevelingChar && d.PlayerUnit.TotalPlayerGold() < 50000 && i.Name != "Gold" {
-		return true
-	}
-
-	minGoldPickupThreshold := b.Container.CharacterCfg.Game.MinGoldPickupThreshold
-	// Pickup all magic or superior items if total gold is low, filter will not pass and items will be sold to vendor
-	if d.PlayerUnit.TotalPlayerGold() < minGoldPickupThreshold && i.Quality >= item.QualityMagic {
-		return true
-	}
-
-	matchedRule, result := d.CharacterCfg.Runtime.Rules.EvaluateAll(i)
-	if result == nip.RuleResultNoMatch {
-		return false
-	}
-
-	if result == nip.RuleResultPartial {
-		return true
+	return pickit.State{
+		Gold:                   gold.Value,
+		MaxGold:                d.PlayerUnit.MaxGold(),
+		TotalGold:              d.PlayerUnit.TotalPlayerGold(),
+		MinGoldPickupThreshold: b.Container.CharacterCfg.Game.MinGoldPickupThreshold,
+		Leveling:               isLevelingChar,
+		QuestRuns:              slices.Contains(b.CharacterCfg.Game.Runs, "quests") || slices.Contains(b.CharacterCfg.Game.Runs, "leveling"),
+		QuotaReached: func(it data.Item, rule nip.Rule) bool {
+			return b.quotaReached(it, rule, d)
+		},
 	}
-
-	exceedQuantity := b.doesExceedQuantity(i, matchedRule, d)
-
-	return !exceedQuantity
 }
@@ -7,7 +7,6 @@ import (
 
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/pather"
-	"github.com/lxn/win"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/area"
@@ -61,7 +60,7 @@ func (b *Builder) EnsureStatPoints() *StepChainAction {
 			if d.OpenMenus.Character {
 				return []step.Step{
 					step.SyncStep(func(_ game.Data) error {
-						b.HID.PressKey(win.VK_ESCAPE)
+						b.HID.PressKey(game.VKEscape)
 						return nil
 					}),
 				}
@@ -115,7 +114,7 @@ func (b *Builder) EnsureSkillPoints() *StepChainAction {
 			if d.OpenMenus.SkillTree {
 				return []step.Step{
 					step.SyncStep(func(_ game.Data) error {
-						b.HID.PressKey(win.VK_ESCAPE)
+						b.HID.PressKey(game.VKEscape)
 						return nil
 					}),
 				}
@@ -370,7 +369,7 @@ func (b *Builder) HireMerc() *Chain {
 				actions = append(actions,
 					b.InteractNPC(
 						town.GetTownByArea(d.PlayerUnit.Area).MercContractorNPC(),
-						step.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN),
+						step.KeySequence(game.VKHome, game.VKDown, game.VKReturn),
 						step.Wait(time.Second*2),
 						step.SyncStep(func(d game.Data) error {
 							b.HID.Click(game.LeftButton, ui.FirstMercFromContractorListX, ui.FirstMercFromContractorListY)
@@ -398,9 +397,9 @@ func (b *Builder) ResetStats() *Chain {
 			}
 			actions = append(actions,
 				b.InteractNPC(npc.Akara,
-					step.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_DOWN, win.VK_RETURN),
+					step.KeySequence(game.VKHome, game.VKDown, game.VKDown, game.VKReturn),
 					step.Wait(time.Second),
-					step.KeySequence(win.VK_HOME, win.VK_RETURN),
+					step.KeySequence(game.VKHome, game.VKReturn),
 				),
 			)
 			if d.PlayerUnit.Area != area.RogueEncampment {
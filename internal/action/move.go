@@ -3,6 +3,7 @@ package action
 import (
 	"fmt"
 	"log/slog"
+	"math"
 	"sort"
 	"time"
 
@@ -124,10 +125,19 @@ func (b *Builder) MoveToCoords(to data.Position, opts ...step.MoveToStepOption)
 	}, opts...)
 }
 
+// Walking characters give up after this number of MoveTo iterations without getting closer to the destination, every
+// walking iteration takes at least a second, so the watchdog has time to apply its recoveries before
+const maxWalkingIterationsWithoutProgress = 20
+
 func (b *Builder) MoveTo(toFunc func(d game.Data) (data.Position, bool), opts ...step.MoveToStepOption) *Chain {
 	pickupBeforeMoving := false
 	openedDoors := make(map[object.Name]data.Position)
 	previousIterationPosition := data.Position{}
+	closestDistance := math.MaxInt
+	iterationsWithoutProgress := 0
+	// Steps are recreated on every iteration, the watchdog is shared to detect when we are stuck across them
+	watchdog := step.NewWatchdog()
+	opts = append([]step.MoveToStepOption{step.WithWatchdog(watchdog)}, opts...)
 
 	return NewChain(func(d game.Data) []Action {
 		to, found := toFunc(d)
@@ -141,10 +151,20 @@ func (b *Builder) MoveTo(toFunc func(d game.Data) (data.Position, bool), opts ..
 			return nil
 		}
 
-		// This prevents we stuck in an infinite loop when we can not get closer to the destination
-		if pather.DistanceFromMe(d, previousIterationPosition) < 5 {
+		// This prevents we stuck in an infinite loop when we can not get closer to the destination. Walking steps are
+		// too short to tell from a single iteration, the watchdog deals with stuck characters first, but walking back
+		// and forth without getting closer is detected here
+		if d.CanTeleport() && pather.DistanceFromMe(d, previousIterationPosition) < 5 {
 			return nil
 		}
+		if !d.CanTeleport() {
+			if distance < closestDistance {
+				closestDistance = distance
+				iterationsWithoutProgress = 0
+			} else if iterationsWithoutProgress++; iterationsWithoutProgress >= maxWalkingIterationsWithoutProgress {
+				return nil
+			}
+		}
 
 		if d.CanTeleport() {
 			previousIterationPosition = d.PlayerUnit.Position
@@ -12,7 +12,6 @@ import (
 	"github.com/hectorgimenez/koolo/internal/helper"
 	"github.com/hectorgimenez/koolo/internal/town"
 	"github.com/hectorgimenez/koolo/internal/ui"
-	"github.com/lxn/win"
 )
 
 func (b *Builder) Repair() *Chain {
@@ -46,13 +45,13 @@ func (b *Builder) Repair() *Chain {
 				}
 
 				keys := make([]byte, 0)
-				keys = append(keys, win.VK_HOME)
+				keys = append(keys, game.VKHome)
 
 				if repairNPC != npc.Halbu {
-					keys = append(keys, win.VK_DOWN)
+					keys = append(keys, game.VKDown)
 				}
 
-				keys = append(keys, win.VK_RETURN)
+				keys = append(keys, game.VKReturn)
 
 				return append(actions, b.InteractNPC(town.GetTownByArea(d.PlayerUnit.Area).RepairNPC(),
 					step.KeySequence(keys...),
@@ -66,7 +65,7 @@ func (b *Builder) Repair() *Chain {
 						helper.Sleep(500)
 						return nil
 					}),
-					step.KeySequence(win.VK_ESCAPE),
+					step.KeySequence(game.VKEscape),
 				))
 			}
 		}
@@ -7,7 +7,6 @@ import (
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/town"
-	"github.com/lxn/win"
 )
 
 func (b *Builder) ReviveMerc() *Chain {
@@ -23,9 +22,9 @@ func (b *Builder) ReviveMerc() *Chain {
 
 			mercNPC := town.GetTownByArea(d.PlayerUnit.Area).MercContractorNPC()
 
-			keySequence := []byte{win.VK_HOME, win.VK_DOWN, win.VK_RETURN, win.VK_ESCAPE}
+			keySequence := []byte{game.VKHome, game.VKDown, game.VKReturn, game.VKEscape}
 			if mercNPC == npc.Tyrael2 {
-				keySequence = []byte{win.VK_END, win.VK_UP, win.VK_RETURN, win.VK_ESCAPE}
+				keySequence = []byte{game.VKEnd, game.VKUp, game.VKReturn, game.VKEscape}
 			}
 
 			return []Action{
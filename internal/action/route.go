@@ -0,0 +1,58 @@
+package action
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/pather"
+)
+
+// Safety net in case some hop doesn't take us where expected and we keep going back and forth
+const maxRouteHops = 30
+
+// TravelTo moves the character to the destination area from wherever it is, using the cheapest combination of level
+// exits, discovered waypoints and town portals. Route is planned again after every hop, so it adapts to hops that
+// don't end where expected. It fails when the destination can not be reached, the same way MoveToArea does.
+func (b *Builder) TravelTo(dst area.ID) *Chain {
+	hops := 0
+
+	return NewChain(func(d game.Data) []Action {
+		if d.PlayerUnit.Area == dst {
+			return nil
+		}
+
+		if hops >= maxRouteHops {
+			return []Action{routeFailed(fmt.Errorf("too many hops trying to reach %s, giving up", dst.Area().Name))}
+		}
+
+		route, found := pather.PlanRoute(b.Reader.GetCachedMapData(false), d, dst)
+		if !found || len(route) == 0 {
+			return []Action{routeFailed(fmt.Errorf("no route found from %s to %s", d.PlayerUnit.Area.Area().Name, dst.Area().Name))}
+		}
+		hops++
+
+		next := route[0]
+		b.Logger.Debug("Travelling to destination", slog.String("area", dst.Area().Name), slog.Any("route", route))
+
+		switch next.Kind {
+		case pather.HopWaypoint:
+			return []Action{b.WayPoint(next.Area)}
+		case pather.HopTownPortal:
+			return []Action{b.ReturnTown()}
+		}
+
+		return []Action{b.MoveToArea(next.Area)}
+	}, RepeatUntilNoSteps())
+}
+
+// routeFailed is a step chain failing with the given error, so TravelTo fails instead of finishing silently
+func routeFailed(err error) Action {
+	return NewStepChain(func(d game.Data) []step.Step {
+		return []step.Step{step.SyncStep(func(_ game.Data) error {
+			return err
+		})}
+	}, Resettable())
+}
@@ -3,7 +3,9 @@ package action
 import (
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/area"
@@ -14,8 +16,10 @@ import (
 	"github.com/hectorgimenez/koolo/internal/event"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/helper"
+	"github.com/hectorgimenez/koolo/internal/pickit"
+	"github.com/hectorgimenez/koolo/internal/quota"
+	"github.com/hectorgimenez/koolo/internal/stash"
 	"github.com/hectorgimenez/koolo/internal/ui"
-	"github.com/lxn/win"
 )
 
 const (
@@ -47,7 +51,7 @@ func (b *Builder) Stash(forceStash bool) *Chain {
 					b.stashGold(d)
 					b.orderInventoryPotions(d)
 					b.stashInventory(d, forceStash)
-					b.HID.PressKey(win.VK_ESCAPE)
+					b.HID.PressKey(game.VKEscape)
 					return nil
 				}),
 			),
@@ -73,7 +77,7 @@ func (b *Builder) orderInventoryPotions(d game.Data) {
 
 func (b *Builder) isStashingRequired(d game.Data, firstRun bool) bool {
 	for _, i := range d.Inventory.ByLocation(item.LocationInventory) {
-		stashIt, _, _ := b.shouldStashIt(d, i, firstRun)
+		stashIt, _, _ := b.shouldStashIt(d, i, firstRun, nil)
 		if stashIt {
 			return true
 		}
@@ -117,63 +121,109 @@ func (b *Builder) stashGold(d game.Data) {
 }
 
 func (b *Builder) stashInventory(d game.Data, firstRun bool) {
-	currentTab := 1
+	type stashRule struct{ rule, file string }
+	toStash := make([]data.Item, 0)
+	rules := make(map[data.UnitID]stashRule)
+	for _, i := range d.Inventory.ByLocation(item.LocationInventory) {
+		stashIt, matchedRule, ruleFile := b.shouldStashIt(d, i, firstRun, toStash)
+		if stashIt {
+			toStash = append(toStash, i)
+			rules[i.UnitID] = stashRule{rule: matchedRule, file: ruleFile}
+		}
+	}
+	if len(toStash) == 0 {
+		return
+	}
+
+	tabs := []int{stash.PersonalTab, 2, 3, stash.LastTab}
 	if b.CharacterCfg.Character.StashToShared {
-		currentTab = 2
+		tabs = tabs[1:]
 	}
-	b.switchTab(currentTab)
 
-	for _, i := range d.Inventory.ByLocation(item.LocationInventory) {
-		stashIt, matchedRule, ruleFile := b.shouldStashIt(d, i, firstRun)
+	st := stash.New(d.Inventory.ByLocation(item.LocationStash, item.LocationSharedStash))
+	placements, unplaced := st.Plan(toStash, tabs, b.CharacterCfg.Stash.TabPreferences)
+	// The model can be wrong (cube contents are read as personal stash items), so the items that don't fit are
+	// tried anyway before giving up
+	for _, i := range unplaced {
+		placements = append(placements, stash.Placement{Item: i, Tab: tabs[len(tabs)-1]})
+	}
 
-		if !stashIt {
+	notStashed := make([]data.Item, 0)
+	currentTab := 0
+	for _, p := range placements {
+		r := rules[p.Item.UnitID]
+		if p.Tab != currentTab {
+			currentTab = p.Tab
+			b.switchTab(currentTab)
+		}
+		if b.stashItemAction(p.Item, r.rule, r.file, firstRun) {
+			b.logStashed(p.Item, firstRun)
 			continue
 		}
-		for currentTab < 5 {
-			if b.stashItemAction(i, matchedRule, ruleFile, firstRun) {
-				r, res := b.CharacterCfg.Runtime.Rules.EvaluateAll(i)
-
-				if res != nip.RuleResultFullMatch && firstRun {
-					b.Logger.Info(
-						fmt.Sprintf("Item %s [%s] stashed because it was found in the inventory during the first run.", i.Desc().Name, i.Quality.ToString()),
-					)
-					break
-				}
-
-				b.Logger.Info(
-					fmt.Sprintf("Item %s [%s] stashed", i.Desc().Name, i.Quality.ToString()),
-					slog.String("nipFile", fmt.Sprintf("%s:%d", r.Filename, r.LineNumber)),
-					slog.String("rawRule", r.RawLine),
-				)
-				break
-			}
-			if currentTab == 5 {
-				// TODO: Stop the bot, stash is full
+
+		// The game doesn't always place the items like the plan, try the rest of the tabs before giving up
+		stashed := false
+		for _, tab := range tabs {
+			if tab == p.Tab {
+				continue
 			}
-			b.Logger.Debug(fmt.Sprintf("Tab %d is full, switching to next one", currentTab))
-			currentTab++
+			b.Logger.Debug(fmt.Sprintf("Tab %d is full, switching to tab %d", currentTab, tab))
+			currentTab = tab
 			b.switchTab(currentTab)
+			if b.stashItemAction(p.Item, r.rule, r.file, firstRun) {
+				b.logStashed(p.Item, firstRun)
+				stashed = true
+				break
+			}
+		}
+		if !stashed {
+			notStashed = append(notStashed, p.Item)
 		}
 	}
-}
 
-func (b *Builder) shouldStashIt(d game.Data, i data.Item, firstRun bool) (bool, string, string) {
-	// Don't stash items from quests during leveling process, it makes things easier to track
-	if _, isLevelingChar := b.ch.(LevelingCharacter); isLevelingChar && i.IsFromQuest() {
-		return false, "", ""
+	if len(notStashed) > 0 {
+		b.stashFull(notStashed)
 	}
+}
 
-	if i.IsRuneword {
-		return true, "runeword", ""
+func (b *Builder) logStashed(i data.Item, firstRun bool) {
+	r, res := b.CharacterCfg.Runtime.Rules.EvaluateAll(i)
+	if res != nip.RuleResultFullMatch && firstRun {
+		b.Logger.Info(
+			fmt.Sprintf("Item %s [%s] stashed because it was found in the inventory during the first run.", i.Desc().Name, i.Quality.ToString()),
+		)
+		return
 	}
 
-	// Don't stash quest items from A2 (for some reason they are not marked as quest items)
-	if i.Name == "StaffOfKings" || i.Name == "AmuletOfTheViper" {
-		return false, "", ""
+	b.Logger.Info(
+		fmt.Sprintf("Item %s [%s] stashed", i.Desc().Name, i.Quality.ToString()),
+		slog.String("nipFile", fmt.Sprintf("%s:%d", r.Filename, r.LineNumber)),
+		slog.String("rawRule", r.RawLine),
+	)
+}
+
+// stashFull reports the items that didn't fit in any tab, the supervisor is paused when the event is received
+func (b *Builder) stashFull(items []data.Item) {
+	names := make([]string, 0, len(items))
+	for _, i := range items {
+		names = append(names, string(i.Name))
 	}
 
-	// Don't stash the Tomes, keys and WirtsLeg
-	if i.Name == item.TomeOfTownPortal || i.Name == item.TomeOfIdentify || i.Name == item.Key || i.Name == "WirtsLeg" {
+	d := b.Reader.GetData(false)
+	freeCells := stash.New(d.Inventory.ByLocation(item.LocationStash, item.LocationSharedStash)).FreeCells()
+	b.Logger.Warn("Stash is full, pausing", slog.Any("items", names), slog.Any("freeCells", freeCells))
+
+	msg := fmt.Sprintf("Stash is full, %d items can't be stashed: %s", len(names), strings.Join(names, ", "))
+	event.Send(event.StashFull(event.WithScreenshot(b.Supervisor, msg, b.Screenshotter.Screenshot()), names, freeCells))
+}
+
+// shouldStashIt decides if an inventory item has to be stashed, accepted are the items of the inventory already
+// accepted in the same pass, they are counted for the quotas like the stashed ones
+func (b *Builder) shouldStashIt(d game.Data, i data.Item, firstRun bool, accepted []data.Item) (bool, string, string) {
+	if decision, found := pickit.StashOverride(i, b.pickitState(d)); found {
+		if decision.Keep {
+			return true, decision.Reason, ""
+		}
 		return false, "", ""
 	}
 
@@ -196,7 +246,14 @@ func (b *Builder) shouldStashIt(d game.Data, i data.Item, firstRun bool) (bool,
 	}
 
 	rule, res := d.CharacterCfg.Runtime.Rules.EvaluateAll(i)
-	if res == nip.RuleResultFullMatch && b.doesExceedQuantity(i, rule, d) {
+	// maxquantity only applies to the items fully matching the rule
+	quotaRule := nip.Rule{}
+	if res == nip.RuleResultFullMatch {
+		quotaRule = rule
+	}
+	// Only the stashed items and the ones accepted before count, the rest of the inventory is waiting to be stashed
+	owned := slices.Concat(quota.Stashed(b.Supervisor, d.Inventory, b.CharacterCfg.Quotas.SharedWith), accepted)
+	if b.quotaRemainingWith(i, quotaRule, owned) == 0 {
 		return false, "", ""
 	}
 
@@ -207,7 +264,7 @@ func (b *Builder) stashItemAction(i data.Item, rule string, ruleFile string, fir
 	screenPos := b.UIManager.GetScreenCoordsForItem(i)
 	b.HID.MovePointer(screenPos.X, screenPos.Y)
 	helper.Sleep(170)
-	screenshot := b.Reader.Screenshot()
+	screenshot := b.Screenshotter.Screenshot()
 	helper.Sleep(150)
 	b.HID.ClickWithModifier(game.LeftButton, screenPos.X, screenPos.Y, game.CtrlKey)
 	helper.Sleep(500)
@@ -219,6 +276,9 @@ func (b *Builder) stashItemAction(i data.Item, rule string, ruleFile string, fir
 		}
 	}
 
+	pickedUp, found := b.pickedUpIn[i.UnitID]
+	delete(b.pickedUpIn, i.UnitID)
+
 	// Never log rejuvs when we stockpile them
 	if b.CharacterCfg.Stash.StockpileRejuvs && i.IsRejuvPotion() {
 		return true
@@ -226,7 +286,11 @@ func (b *Builder) stashItemAction(i data.Item, rule string, ruleFile string, fir
 
 	// Don't log items that we already have in inventory during first run
 	if !firstRun {
-		event.Send(event.ItemStashed(event.WithScreenshot(b.Supervisor, fmt.Sprintf("Item %s [%d] stashed", i.Name, i.Quality), screenshot), data.Drop{Item: i, Rule: rule, RuleFile: ruleFile}))
+		drop := data.Drop{Item: i, Rule: rule, RuleFile: ruleFile}
+		if found {
+			drop.DropLocation = pickedUp.area.Area().Name
+		}
+		event.Send(event.ItemStashed(event.WithScreenshot(b.Supervisor, fmt.Sprintf("Item %s [%d] stashed", i.Name, i.Quality), screenshot), drop, pickedUp.runName))
 	}
 
 	return true
@@ -178,12 +178,7 @@ func (p *AttackStep) ensureEnemyIsInRange(container container.Container, monster
 					}
 
 					for i := moveTo; i > 0; i-- {
-						posTile := path[i].(*pather.Tile)
-						pos := data.Position{
-							X: posTile.X + d.AreaOrigin.X,
-							Y: posTile.Y + d.AreaOrigin.Y,
-						}
-
+						pos := path[i]
 						hasLoS = pather.LineOfSight(d, pos, monster.Position)
 						if hasLoS {
 							path, distance, _ = container.PathFinder.GetPath(d, pos)
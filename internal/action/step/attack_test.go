@@ -0,0 +1,110 @@
+package step_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/container"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/simulator"
+)
+
+func spawnMonster(sim *simulator.Simulator, id data.UnitID, pos data.Position) {
+	sim.Update(func(d *data.Data) {
+		d.Monsters = append(d.Monsters, data.Monster{
+			UnitID:   id,
+			Position: pos,
+			Type:     data.MonsterTypeNone,
+			Stats:    map[stat.ID]int{stat.Life: 100},
+		})
+	})
+}
+
+// runUntilCompleted calls the step like the bot loop does, until it's completed or the iterations are exhausted
+func runUntilCompleted(t *testing.T, sim *simulator.Simulator, c container.Container, s step.Step, iterations int) {
+	t.Helper()
+
+	for i := 0; i < iterations; i++ {
+		d := sim.GetData(false)
+		if s.Status(d, c) == step.StatusCompleted {
+			return
+		}
+		if err := s.Run(d, c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	t.Fatalf("step not completed after %d iterations", iterations)
+}
+
+func TestAttackStepAttacksTheRequestedTimes(t *testing.T) {
+	sim, c := newSimulation(t, false)
+	d := sim.GetData(false)
+	spawnMonster(sim, 1, data.Position{X: d.PlayerUnit.Position.X + 3, Y: d.PlayerUnit.Position.Y})
+
+	attack := step.PrimaryAttack(1, 3, false, step.Distance(1, 10))
+	runUntilCompleted(t, sim, c, attack, 200)
+
+	clicks := simulator.Clicks(sim.Input(), game.LeftButton)
+	if len(clicks) != 3 {
+		t.Fatalf("expected 3 attacks, got %d", len(clicks))
+	}
+
+	// Monster is east of the player, so attacks are aimed down and to the right of the screen center
+	sizeX, sizeY := sim.GameAreaSize()
+	for _, click := range clicks {
+		if click.X <= sizeX/2 || click.Y <= sizeY/2 {
+			t.Errorf("attack not aimed at the monster: %+v", click)
+		}
+	}
+}
+
+func TestAttackStepStopsWhenMonsterDies(t *testing.T) {
+	sim, c := newSimulation(t, false)
+	d := sim.GetData(false)
+	spawnMonster(sim, 1, data.Position{X: d.PlayerUnit.Position.X + 3, Y: d.PlayerUnit.Position.Y})
+
+	// Monster dies with the first hit
+	sim.OnInput(func(d *data.Data, in simulator.Input) {
+		if in.Kind == simulator.InputMouseDown {
+			d.Monsters[0].Stats[stat.Life] = 0
+		}
+	})
+
+	attack := step.PrimaryAttack(1, 10, false, step.Distance(1, 10))
+	runUntilCompleted(t, sim, c, attack, 200)
+
+	if clicks := simulator.Clicks(sim.Input(), game.LeftButton); len(clicks) != 1 {
+		t.Errorf("expected a single attack, got %d", len(clicks))
+	}
+}
+
+func TestAttackStepSecondarySkill(t *testing.T) {
+	sim, c := newSimulation(t, false)
+	d := sim.GetData(false)
+	spawnMonster(sim, 1, data.Position{X: d.PlayerUnit.Position.X, Y: d.PlayerUnit.Position.Y + 5})
+
+	attack := step.SecondaryAttack(d.KeyBindings.Skills[0].SkillID, 1, 2, step.Distance(1, 10))
+	runUntilCompleted(t, sim, c, attack, 200)
+
+	input := sim.Input()
+	if clicks := simulator.Clicks(input, game.RightButton); len(clicks) != 2 {
+		t.Errorf("expected 2 casts, got %d", len(clicks))
+	}
+	if keys := simulator.KeyPresses(input); len(keys) == 0 || keys[0] != teleportKey {
+		t.Errorf("skill should be selected before casting, keys pressed: %v", keys)
+	}
+}
+
+func TestAttackStepCompletesWhenMonsterIsMissing(t *testing.T) {
+	sim, c := newSimulation(t, false)
+
+	attack := step.PrimaryAttack(1, 3, false, step.Distance(1, 10))
+	if status := attack.Status(sim.GetData(false), c); status != step.StatusCompleted {
+		t.Errorf("expected status %s, got %s", step.StatusCompleted, status)
+	}
+}
@@ -0,0 +1,12 @@
+package step
+
+import "time"
+
+// SetWatchdogClock replaces the clock used by the watchdogs until the returned function is called
+func SetWatchdogClock(clock func() time.Time) (restore func()) {
+	now = clock
+
+	return func() {
+		now = time.Now
+	}
+}
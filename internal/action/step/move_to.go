@@ -2,9 +2,14 @@ package step
 
 import (
 	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
 	"time"
 
 	"github.com/hectorgimenez/koolo/internal/container"
+	"github.com/hectorgimenez/koolo/internal/event"
 	"github.com/hectorgimenez/koolo/internal/game"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
@@ -20,6 +25,10 @@ type MoveToStep struct {
 	nearestWalkable bool
 	timeout         time.Duration
 	startedAt       time.Time
+	teleportPath    []data.Position
+	// Planning gives up on unreachable destinations after some work, it's not retried on every run
+	teleportPlanFailed bool
+	watchdog           *Watchdog
 }
 
 type MoveToStepOption func(step *MoveToStep)
@@ -36,6 +45,10 @@ func MoveTo(destination data.Position, opts ...MoveToStepOption) *MoveToStep {
 		o(step)
 	}
 
+	if step.watchdog == nil {
+		step.watchdog = NewWatchdog()
+	}
+
 	return step
 }
 
@@ -45,6 +58,13 @@ func StopAtDistance(distance int) MoveToStepOption {
 	}
 }
 
+// WithWatchdog shares the stuck detection with other steps, useful when the movement is split in many short steps
+func WithWatchdog(w *Watchdog) MoveToStepOption {
+	return func(step *MoveToStep) {
+		step.watchdog = w
+	}
+}
+
 func WithTimeout(timeout time.Duration) MoveToStepOption {
 	return func(step *MoveToStep) {
 		step.timeout = timeout
@@ -69,14 +89,6 @@ func (m *MoveToStep) Status(d game.Data, container container.Container) Status {
 		}
 	}
 
-	if m.isPlayerStuck(d) && d.CanTeleport() {
-		_, distance, found := container.PathFinder.GetPath(d, m.destination)
-		if found && distance > m.stopAtDistance {
-			m.stopAtDistance = distance
-		}
-		m.tryTransitionStatus(StatusCompleted)
-	}
-
 	return m.status
 }
 
@@ -97,6 +109,7 @@ func (m *MoveToStep) Run(d game.Data, container container.Container) error {
 	}
 
 	m.tryTransitionStatus(StatusInProgress)
+	m.watchdog.Observe(d)
 
 	if m.timeout > 0 && time.Since(m.startedAt) > m.timeout {
 		m.tryTransitionStatus(StatusCompleted)
@@ -113,24 +126,15 @@ func (m *MoveToStep) Run(d game.Data, container container.Container) error {
 		return nil
 	}
 
-	stuck := m.isPlayerStuck(d)
-
-	if m.path == nil || !m.cachePath(d) || stuck {
-		if stuck {
-			if len(m.path) == 0 {
-				randomPosX, randomPosY := pather.FindFirstWalkable(d.PlayerUnit.Position, d.AreaOrigin, d.CollisionGrid, 15)
-				screenX, screenY := container.PathFinder.GameCoordsToScreenCords(d.PlayerUnit.Position.X, d.PlayerUnit.Position.Y, randomPosX, randomPosY)
-				m.lastRunPositions = append(m.lastRunPositions, d.PlayerUnit.Position)
-				container.PathFinder.MoveCharacter(d, screenX, screenY)
-				m.lastRun = time.Now()
+	if m.watchdog.Stalled() {
+		return m.recover(d, container)
+	}
 
-				return nil
-			} else {
-				tile := m.path[len(m.path)-1].(*pather.Tile)
-				m.blacklistedPositions = append(m.blacklistedPositions, [2]int{tile.X, tile.Y})
-			}
-		}
+	if d.CanTeleport() && m.teleport(d, container) {
+		return nil
+	}
 
+	if m.path == nil || !m.cachePath(d) {
 		path, _, found := container.PathFinder.GetClosestWalkablePath(d, m.destination, m.blacklistedPositions...)
 		if !found {
 			if pather.DistanceFromMe(d, m.destination) < m.stopAtDistance+5 {
@@ -148,16 +152,145 @@ func (m *MoveToStep) Run(d game.Data, container container.Container) error {
 	if len(m.path) == 0 {
 		return nil
 	}
-	m.lastRunPositions = append(m.lastRunPositions, d.PlayerUnit.Position)
+	m.watchdog.Clicked()
 	container.PathFinder.MoveThroughPath(d, m.path, calculateMaxDistance(d, walkDuration))
 
 	return nil
 }
 
+// teleport moves to the next hop of the teleport path, replanning it when needed. It returns false when there is no
+// teleport path available and the regular path should be used instead.
+func (m *MoveToStep) teleport(d game.Data, container container.Container) bool {
+	if m.previousArea != d.PlayerUnit.Area {
+		m.teleportPath = nil
+		m.teleportPlanFailed = false
+	}
+
+	m.skipReachedHops(d)
+	// Teleport may land a bit off, replan if next hop is not reachable anymore
+	if len(m.teleportPath) > 0 && pather.DistanceFromMe(d, m.teleportPath[0]) > pather.TeleportRange {
+		m.teleportPath = nil
+	}
+
+	if len(m.teleportPath) == 0 {
+		if m.teleportPlanFailed {
+			return false
+		}
+		hops, found := container.PathFinder.GetTeleportPath(d, m.destination, m.blacklistedPositions...)
+		if !found {
+			m.teleportPlanFailed = true
+			return false
+		}
+		m.teleportPath = hops
+		m.skipReachedHops(d)
+		if len(m.teleportPath) == 0 {
+			return false
+		}
+	}
+
+	next := m.teleportPath[0]
+	screenX, screenY := container.PathFinder.GameCoordsToScreenCords(d.PlayerUnit.Position.X, d.PlayerUnit.Position.Y, next.X, next.Y)
+	m.watchdog.Clicked()
+	container.PathFinder.MoveCharacter(d, screenX, screenY)
+	m.lastRun = time.Now()
+	m.previousArea = d.PlayerUnit.Area
+
+	return true
+}
+
+// recover applies the next recovery strategy when the character is stuck, giving up with a StuckError when none of
+// them worked
+func (m *MoveToStep) recover(d game.Data, container container.Container) error {
+	m.lastRun = time.Now()
+	stuckErr := StuckError{Area: d.PlayerUnit.Area, Position: d.PlayerUnit.Position}
+
+	// Already reported, just keep failing until the action gives up
+	if m.watchdog.Aborted() {
+		stuckErr.Reason = m.watchdog.Reason()
+		return stuckErr
+	}
+
+	reason := m.watchdog.Classify(d, m.destination)
+	stuckErr.Reason = reason
+	recovery := m.watchdog.NextRecovery(d, reason)
+	container.Logger.Warn("Character stuck, trying to recover",
+		slog.String("reason", string(reason)),
+		slog.String("recovery", recovery),
+		slog.Int("attempt", m.watchdog.Attempt()),
+	)
+
+	if recovery == RecoveryAbort {
+		event.Send(event.Stuck(event.WithScreenshot(container.Supervisor, stuckErr.Error(), container.Screenshotter.Screenshot()), reason, recovery, m.watchdog.Attempt(), d.PlayerUnit.Area, d.PlayerUnit.Position))
+
+		// Close enough, same as reaching the destination
+		if reason != event.StuckWrongArea && pather.DistanceFromMe(d, m.destination) <= m.stopAtDistance*2 {
+			m.watchdog.Reset()
+			m.tryTransitionStatus(StatusCompleted)
+			return nil
+		}
+
+		return stuckErr
+	}
+
+	event.Send(event.Stuck(event.Text(container.Supervisor, fmt.Sprintf("Character stuck (%s), trying %s", reason, recovery)), reason, recovery, m.watchdog.Attempt(), d.PlayerUnit.Area, d.PlayerUnit.Position))
+
+	switch recovery {
+	case RecoveryOpenDoor:
+		if door, found := closestDoor(d); found {
+			screenX, screenY := container.PathFinder.GameCoordsToScreenCords(d.PlayerUnit.Position.X, d.PlayerUnit.Position.Y, door.Position.X, door.Position.Y)
+			container.HID.Click(game.LeftButton, screenX, screenY)
+		}
+	case RecoveryRepath:
+		// Blacklist the next tiles of the path or the next teleport landing, they are probably the ones blocking us
+		for i := len(m.path) - 2; i >= 0 && i >= len(m.path)-4; i-- {
+			m.blacklistedPositions = append(m.blacklistedPositions, [2]int{m.path[i].X, m.path[i].Y})
+		}
+		if len(m.teleportPath) > 0 {
+			m.blacklistedPositions = append(m.blacklistedPositions, [2]int{m.teleportPath[0].X, m.teleportPath[0].Y})
+		}
+		m.path = nil
+		m.teleportPath = nil
+		m.teleportPlanFailed = false
+	case RecoverySideStep:
+		sideStepX, sideStepY := sideStep(d)
+		screenX, screenY := container.PathFinder.GameCoordsToScreenCords(d.PlayerUnit.Position.X, d.PlayerUnit.Position.Y, sideStepX, sideStepY)
+		container.PathFinder.MoveCharacter(d, screenX, screenY)
+		m.path = nil
+		m.teleportPath = nil
+	}
+
+	return nil
+}
+
+// sideStep returns a random walkable position close to the player, falling back to the first walkable one
+func sideStep(d game.Data) (int, int) {
+	for range 10 {
+		angle := rand.Float64() * 2 * math.Pi
+		distance := 4 + rand.Float64()*6
+		pos := data.Position{
+			X: d.PlayerUnit.Position.X + int(math.Cos(angle)*distance),
+			Y: d.PlayerUnit.Position.Y + int(math.Sin(angle)*distance),
+		}
+		if pather.IsWalkable(pos, d.AreaOrigin, d.CollisionGrid) {
+			return pos.X, pos.Y
+		}
+	}
+
+	return pather.FindFirstWalkable(d.PlayerUnit.Position, d.AreaOrigin, d.CollisionGrid, 15)
+}
+
+func (m *MoveToStep) skipReachedHops(d game.Data) {
+	for len(m.teleportPath) > 0 && pather.DistanceFromMe(d, m.teleportPath[0]) <= 3 {
+		m.teleportPath = m.teleportPath[1:]
+	}
+}
+
 func (m *MoveToStep) Reset() {
 	m.status = StatusNotStarted
 	m.lastRun = time.Time{}
 	m.startedAt = time.Time{}
+	m.teleportPath = nil
+	m.teleportPlanFailed = false
 }
 
 func calculateMaxDistance(d game.Data, duration time.Duration) int {
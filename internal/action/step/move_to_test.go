@@ -0,0 +1,91 @@
+package step_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/simulator"
+)
+
+func TestMoveToStepWalksTowardsDestination(t *testing.T) {
+	sim, c := newSimulation(t, false)
+	dest := data.Position{X: levelOrigin.X + 80, Y: levelOrigin.Y + 50}
+
+	m := step.MoveTo(dest)
+	if err := m.Run(sim.GetData(false), c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	input := sim.Input()
+	if !slices.Contains(simulator.KeyPresses(input), forceMoveKey) {
+		t.Fatalf("force move was not pressed, input: %v", input)
+	}
+	if clicks := simulator.Clicks(input, game.LeftButton); len(clicks) > 0 {
+		t.Errorf("walking character should use force move instead of clicking, got %d clicks", len(clicks))
+	}
+
+	// Destination is east in game coordinates, that is down and to the right of the screen center
+	pointer := input[0]
+	sizeX, sizeY := sim.GameAreaSize()
+	if pointer.Kind != simulator.InputMove || pointer.X <= sizeX/2 || pointer.Y <= sizeY/2 {
+		t.Errorf("pointer should move towards the destination, got %+v", pointer)
+	}
+
+	if status := m.Status(sim.GetData(false), c); status != step.StatusInProgress {
+		t.Errorf("expected status %s, got %s", step.StatusInProgress, status)
+	}
+
+	movePlayer(sim, dest)
+	if status := m.Status(sim.GetData(false), c); status != step.StatusCompleted {
+		t.Errorf("expected status %s after reaching the destination, got %s", step.StatusCompleted, status)
+	}
+}
+
+func TestMoveToStepTeleports(t *testing.T) {
+	sim, c := newSimulation(t, true)
+	dest := data.Position{X: levelOrigin.X + 90, Y: levelOrigin.Y + 90}
+
+	m := step.MoveTo(dest)
+	if err := m.Run(sim.GetData(false), c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	input := sim.Input()
+	if keys := simulator.KeyPresses(input); !slices.Contains(keys, teleportKey) {
+		t.Errorf("teleport skill was not selected, keys pressed: %v", keys)
+	}
+	if clicks := simulator.Clicks(input, game.RightButton); len(clicks) != 1 {
+		t.Errorf("expected a single teleport cast, got %d", len(clicks))
+	}
+	if keys := simulator.KeyPresses(input); slices.Contains(keys, forceMoveKey) {
+		t.Errorf("teleporting character should not walk")
+	}
+}
+
+func TestMoveToStepCompletesWhenAlreadyThere(t *testing.T) {
+	sim, c := newSimulation(t, false)
+	d := sim.GetData(false)
+
+	m := step.MoveTo(data.Position{X: d.PlayerUnit.Position.X + 2, Y: d.PlayerUnit.Position.Y})
+	if status := m.Status(d, c); status != step.StatusCompleted {
+		t.Errorf("expected status %s, got %s", step.StatusCompleted, status)
+	}
+}
+
+func TestMoveToStepTimeout(t *testing.T) {
+	sim, c := newSimulation(t, false)
+	dest := data.Position{X: levelOrigin.X + 90, Y: levelOrigin.Y + 50}
+
+	m := step.MoveTo(dest, step.WithTimeout(1))
+	_ = m.Run(sim.GetData(false), c)
+	// Timeout is checked on the next run, the character never moved
+	if err := m.Run(sim.GetData(false), c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status := m.Status(sim.GetData(false), c); status != step.StatusCompleted {
+		t.Errorf("expected status %s after the timeout, got %s", step.StatusCompleted, status)
+	}
+}
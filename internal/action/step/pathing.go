@@ -1,7 +1,6 @@
 package step
 
 import (
-	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/area"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/pather"
@@ -11,7 +10,6 @@ type pathingStep struct {
 	basicStep
 	consecutivePathNotFound int
 	path                    pather.Pather
-	lastRunPositions        []data.Position
 	blacklistedPositions    [][2]int
 	previousArea            area.ID
 }
@@ -33,18 +31,7 @@ func (s *pathingStep) cachePath(d game.Data) bool {
 	}
 
 	for k, pos := range s.path {
-		tile := pos.(*pather.Tile)
-		expandedGrid := 0
-		if len(tile.W.World) == 3000 {
-			expandedGrid = 1500
-		}
-
-		destination := data.Position{
-			X: tile.X + (d.AreaOrigin.X - expandedGrid),
-			Y: tile.Y + (d.AreaOrigin.Y - expandedGrid),
-		}
-
-		distance := pather.DistanceFromMe(d, destination)
+		distance := pather.DistanceFromMe(d, pos)
 		if distance < nearestDistance {
 			nearestDistance = distance
 			nearestKey = k
@@ -60,23 +47,3 @@ func (s *pathingStep) cachePath(d game.Data) bool {
 
 	return false
 }
-
-func (s *pathingStep) isPlayerStuck(d game.Data) bool {
-	if s.lastRun.IsZero() {
-		return false
-	}
-
-	if len(s.lastRunPositions) > 3 {
-		s.lastRunPositions = s.lastRunPositions[1:]
-	} else {
-		return false
-	}
-
-	for _, pos := range s.lastRunPositions {
-		if pather.DistanceFromMe(d, pos) > 5 {
-			return false
-		}
-	}
-
-	return true
-}
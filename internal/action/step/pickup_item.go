@@ -82,7 +82,8 @@ func (p *PickupItemStep) Run(d game.Data, container container.Container) error {
 			objectY := i.Position.Y - 1
 			mX, mY := container.PathFinder.GameCoordsToScreenCords(d.PlayerUnit.Position.X, d.PlayerUnit.Position.Y, objectX, objectY)
 
-			if i.IsHovered {
+			// Item can be hovered by the movement before the pointer was placed by this step, there is nothing to click yet
+			if i.IsHovered && p.mouseOverAttempts > 0 {
 				container.HID.Click(game.LeftButton, p.currentMouseCoords.X, p.currentMouseCoords.Y)
 				if p.waitingForInteraction.IsZero() {
 					p.waitingForInteraction = time.Now()
@@ -0,0 +1,62 @@
+package step_test
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/container"
+	"github.com/hectorgimenez/koolo/internal/simulator"
+)
+
+const (
+	forceMoveKey = 'L'
+	teleportKey  = 'F'
+)
+
+var levelOrigin = data.Position{X: 1000, Y: 1000}
+
+// newSimulation places the character in the middle of an open 100x100 level, with force move and teleport bound
+func newSimulation(t *testing.T, teleport bool) (*simulator.Simulator, container.Container) {
+	t.Helper()
+
+	if config.Koolo == nil {
+		config.Koolo = &config.KooloCfg{}
+	}
+
+	cfg := &config.CharacterCfg{}
+	cfg.Character.UseTeleport = teleport
+
+	grid := make([][]bool, 100)
+	for y := range grid {
+		grid[y] = make([]bool, 100)
+		for x := range grid[y] {
+			grid[y][x] = true
+		}
+	}
+
+	d := data.Data{
+		AreaOrigin:    levelOrigin,
+		CollisionGrid: grid,
+		PlayerUnit: data.PlayerUnit{
+			Area:     area.BloodMoor,
+			Position: data.Position{X: levelOrigin.X + 50, Y: levelOrigin.Y + 50},
+		},
+	}
+	d.KeyBindings.ForceMove = data.KeyBinding{Key1: [2]byte{forceMoveKey, 0}}
+	d.KeyBindings.Skills[0] = data.SkillBinding{SkillID: skill.Teleport, KeyBinding: data.KeyBinding{Key1: [2]byte{teleportKey, 0}}}
+
+	sim := simulator.New(cfg, d)
+
+	return sim, sim.Container("test", slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func movePlayer(sim *simulator.Simulator, pos data.Position) {
+	sim.Update(func(d *data.Data) {
+		d.PlayerUnit.Position = pos
+	})
+}
@@ -0,0 +1,191 @@
+package step
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/pather"
+)
+
+const (
+	// Character is stuck when the position doesn't change for this time, despite the movement clicks
+	stuckTimeout   = 3 * time.Second
+	stuckMinClicks = 3
+	// Small position changes (pushed by monsters, teleport landing a bit off) are not considered movement
+	stuckTolerance = 2
+	// Moving this far away from the position where we got stuck means the recovery worked
+	unstuckDistance    = 10
+	maxStuckRecoveries = 5
+)
+
+// now is replaced by the tests, detecting a stuck character takes several seconds otherwise
+var now = time.Now
+
+// Recovery strategies applied when the character is stuck
+const (
+	RecoverySideStep = "side step"
+	RecoveryRepath   = "re-path"
+	RecoveryOpenDoor = "open door"
+	RecoveryAbort    = "abort"
+)
+
+// StuckError is returned when the character is stuck and none of the recoveries worked
+type StuckError struct {
+	Reason   event.StuckReason
+	Area     area.ID
+	Position data.Position
+}
+
+func (e StuckError) Error() string {
+	return fmt.Sprintf("character stuck at %d,%d in %s, reason: %s", e.Position.X, e.Position.Y, e.Area.Area().Name, e.Reason)
+}
+
+// Watchdog tracks the player position while moving and detects when it's not changing despite the clicks. The same
+// watchdog can be shared by consecutive steps moving to the same destination, like the ones created by MoveTo action.
+type Watchdog struct {
+	startArea  area.ID
+	position   data.Position
+	movedAt    time.Time
+	clicks     int
+	stuckAt    data.Position
+	recoveries int
+	reason     event.StuckReason
+	aborted    bool
+}
+
+func NewWatchdog() *Watchdog {
+	return &Watchdog{}
+}
+
+// Observe updates the position history, it should be called on every step run
+func (w *Watchdog) Observe(d game.Data) {
+	if w.movedAt.IsZero() {
+		w.startArea = d.PlayerUnit.Area
+	}
+
+	if w.movedAt.IsZero() || pather.DistanceFromPoint(w.position, d.PlayerUnit.Position) > stuckTolerance {
+		w.position = d.PlayerUnit.Position
+		w.movedAt = now()
+		w.clicks = 0
+	}
+
+	if w.recoveries > 0 && !w.aborted && pather.DistanceFromPoint(w.stuckAt, d.PlayerUnit.Position) > unstuckDistance {
+		w.recoveries = 0
+	}
+}
+
+// Clicked registers a movement click (or teleport cast)
+func (w *Watchdog) Clicked() {
+	w.clicks++
+}
+
+// Stalled returns true when the character didn't move during the last seconds, despite the clicks
+func (w *Watchdog) Stalled() bool {
+	return w.aborted || w.clicks >= stuckMinClicks && now().Sub(w.movedAt) > stuckTimeout
+}
+
+// Attempt returns the number of recoveries tried since the character got stuck
+func (w *Watchdog) Attempt() int {
+	return w.recoveries
+}
+
+// Classify guesses why the character is not moving
+func (w *Watchdog) Classify(d game.Data, destination data.Position) event.StuckReason {
+	if d.PlayerUnit.Area != w.startArea && !insideCurrentArea(d, destination) {
+		return event.StuckWrongArea
+	}
+
+	if _, found := closestDoor(d); found {
+		return event.StuckBlockedByDoor
+	}
+
+	for _, m := range d.Monsters.Enemies() {
+		if m.Stats[stat.Life] > 0 && pather.DistanceFromMe(d, m.Position) <= 3 {
+			return event.StuckBodyBlocked
+		}
+	}
+
+	if d.CanTeleport() {
+		return event.StuckTeleportFailed
+	}
+
+	return event.StuckTerrain
+}
+
+// NextRecovery returns the recovery strategy for the reason, strategies are alternated on every attempt until
+// giving up. Stall detection starts again, so the recovery has some time to work.
+func (w *Watchdog) NextRecovery(d game.Data, reason event.StuckReason) string {
+	if w.recoveries == 0 {
+		w.stuckAt = d.PlayerUnit.Position
+	}
+	w.recoveries++
+	w.reason = reason
+	w.clicks = 0
+	w.movedAt = now()
+
+	if reason == event.StuckWrongArea || w.recoveries > maxStuckRecoveries {
+		w.aborted = true
+		return RecoveryAbort
+	}
+
+	switch reason {
+	case event.StuckBlockedByDoor:
+		if w.recoveries%2 == 1 {
+			return RecoveryOpenDoor
+		}
+	case event.StuckBodyBlocked:
+		return RecoverySideStep
+	case event.StuckTeleportFailed:
+		if w.recoveries%2 == 1 {
+			return RecoverySideStep
+		}
+		return RecoveryRepath
+	case event.StuckTerrain:
+		if w.recoveries%2 == 1 {
+			return RecoveryRepath
+		}
+	}
+
+	return RecoverySideStep
+}
+
+// Reset forgets the position history and the recoveries, like a new watchdog
+func (w *Watchdog) Reset() {
+	*w = Watchdog{}
+}
+
+// Reason returns the reason of the last time the character got stuck
+func (w *Watchdog) Reason() event.StuckReason {
+	return w.reason
+}
+
+// Aborted returns true if the watchdog already gave up
+func (w *Watchdog) Aborted() bool {
+	return w.aborted
+}
+
+func closestDoor(d game.Data) (data.Object, bool) {
+	door := data.Object{}
+	doorDistance := 6
+	found := false
+	for _, o := range d.Objects {
+		if o.IsDoor() && o.Selectable {
+			if distance := pather.DistanceFromMe(d, o.Position); distance <= doorDistance {
+				door, doorDistance, found = o, distance, true
+			}
+		}
+	}
+
+	return door, found
+}
+
+func insideCurrentArea(d game.Data, p data.Position) bool {
+	x, y := p.X-d.AreaOrigin.X, p.Y-d.AreaOrigin.Y
+
+	return x >= 0 && y >= 0 && y < len(d.CollisionGrid) && x < len(d.CollisionGrid[y])
+}
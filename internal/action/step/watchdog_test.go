@@ -0,0 +1,260 @@
+package step_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/simulator"
+)
+
+// fakeClock starts at the current time and only moves forward when advanced
+func fakeClock(t *testing.T) func(time.Duration) {
+	t.Helper()
+
+	current := time.Now()
+	t.Cleanup(step.SetWatchdogClock(func() time.Time { return current }))
+
+	return func(d time.Duration) {
+		current = current.Add(d)
+	}
+}
+
+func TestWatchdogStalled(t *testing.T) {
+	advance := fakeClock(t)
+	sim, _ := newSimulation(t, false)
+	d := sim.GetData(false)
+
+	w := step.NewWatchdog()
+	w.Observe(d)
+	w.Clicked()
+	w.Clicked()
+	advance(5 * time.Second)
+	if w.Stalled() {
+		t.Error("stalled with less clicks than required")
+	}
+
+	w.Clicked()
+	if !w.Stalled() {
+		t.Error("not stalled after clicking for seconds without moving")
+	}
+
+	// Being pushed a bit is not moving
+	movePlayer(sim, data.Position{X: d.PlayerUnit.Position.X + 1, Y: d.PlayerUnit.Position.Y + 1})
+	w.Observe(sim.GetData(false))
+	if !w.Stalled() {
+		t.Error("small position changes should not reset the stall detection")
+	}
+
+	movePlayer(sim, data.Position{X: d.PlayerUnit.Position.X + 5, Y: d.PlayerUnit.Position.Y})
+	w.Observe(sim.GetData(false))
+	if w.Stalled() {
+		t.Error("still stalled after moving")
+	}
+}
+
+func TestWatchdogClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		teleport bool
+		setup    func(d *data.Data)
+		want     event.StuckReason
+	}{
+		{
+			name: "terrain",
+			want: event.StuckTerrain,
+		},
+		{
+			name:     "teleport failed",
+			teleport: true,
+			want:     event.StuckTeleportFailed,
+		},
+		{
+			name: "closed door",
+			setup: func(d *data.Data) {
+				d.Objects = append(d.Objects, data.Object{Name: object.IronGrateDoorLeft, Selectable: true, Position: data.Position{X: d.PlayerUnit.Position.X + 3, Y: d.PlayerUnit.Position.Y}})
+			},
+			want: event.StuckBlockedByDoor,
+		},
+		{
+			name: "opened door",
+			setup: func(d *data.Data) {
+				d.Objects = append(d.Objects, data.Object{Name: object.IronGrateDoorLeft, Position: data.Position{X: d.PlayerUnit.Position.X + 3, Y: d.PlayerUnit.Position.Y}})
+			},
+			want: event.StuckTerrain,
+		},
+		{
+			name: "monster next to the player",
+			setup: func(d *data.Data) {
+				d.Monsters = append(d.Monsters, data.Monster{UnitID: 1, Type: data.MonsterTypeNone, Position: data.Position{X: d.PlayerUnit.Position.X + 2, Y: d.PlayerUnit.Position.Y}, Stats: map[stat.ID]int{stat.Life: 100}})
+			},
+			want: event.StuckBodyBlocked,
+		},
+		{
+			name: "wrong area",
+			setup: func(d *data.Data) {
+				d.PlayerUnit.Area = area.ColdPlains
+				d.AreaOrigin = data.Position{X: 5000, Y: 5000}
+			},
+			want: event.StuckWrongArea,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sim, _ := newSimulation(t, tc.teleport)
+			w := step.NewWatchdog()
+			w.Observe(sim.GetData(false))
+			if tc.setup != nil {
+				sim.Update(tc.setup)
+			}
+
+			destination := data.Position{X: levelOrigin.X + 90, Y: levelOrigin.Y + 90}
+			if got := w.Classify(sim.GetData(false), destination); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWatchdogNextRecovery(t *testing.T) {
+	fakeClock(t)
+
+	tests := []struct {
+		reason event.StuckReason
+		want   []string
+	}{
+		{
+			reason: event.StuckTerrain,
+			want:   []string{step.RecoveryRepath, step.RecoverySideStep, step.RecoveryRepath, step.RecoverySideStep, step.RecoveryRepath, step.RecoveryAbort},
+		},
+		{
+			reason: event.StuckBlockedByDoor,
+			want:   []string{step.RecoveryOpenDoor, step.RecoverySideStep, step.RecoveryOpenDoor, step.RecoverySideStep, step.RecoveryOpenDoor, step.RecoveryAbort},
+		},
+		{
+			reason: event.StuckTeleportFailed,
+			want:   []string{step.RecoverySideStep, step.RecoveryRepath, step.RecoverySideStep, step.RecoveryRepath, step.RecoverySideStep, step.RecoveryAbort},
+		},
+		{
+			reason: event.StuckBodyBlocked,
+			want:   []string{step.RecoverySideStep, step.RecoverySideStep, step.RecoverySideStep, step.RecoverySideStep, step.RecoverySideStep, step.RecoveryAbort},
+		},
+		{
+			reason: event.StuckWrongArea,
+			want:   []string{step.RecoveryAbort},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.reason), func(t *testing.T) {
+			sim, _ := newSimulation(t, false)
+			d := sim.GetData(false)
+			w := step.NewWatchdog()
+			w.Observe(d)
+
+			for i, want := range tc.want {
+				if got := w.NextRecovery(d, tc.reason); got != want {
+					t.Errorf("attempt %d: expected %s, got %s", i+1, want, got)
+				}
+			}
+			if !w.Aborted() || !w.Stalled() || w.Reason() != tc.reason {
+				t.Errorf("watchdog should stay aborted with reason %s, got aborted: %t, reason: %s", tc.reason, w.Aborted(), w.Reason())
+			}
+		})
+	}
+}
+
+func TestWatchdogRecoveriesAreForgottenAfterMovingAway(t *testing.T) {
+	fakeClock(t)
+	sim, _ := newSimulation(t, false)
+	d := sim.GetData(false)
+
+	w := step.NewWatchdog()
+	w.Observe(d)
+	w.NextRecovery(d, event.StuckTerrain)
+	w.NextRecovery(d, event.StuckTerrain)
+
+	movePlayer(sim, data.Position{X: d.PlayerUnit.Position.X + 20, Y: d.PlayerUnit.Position.Y})
+	w.Observe(sim.GetData(false))
+	if w.Attempt() != 0 {
+		t.Errorf("recoveries should be reset after moving away, got %d", w.Attempt())
+	}
+	if got := w.NextRecovery(sim.GetData(false), event.StuckTerrain); got != step.RecoveryRepath {
+		t.Errorf("expected the first recovery again, got %s", got)
+	}
+}
+
+func TestMoveToStepReportsStuckCharacter(t *testing.T) {
+	advance := fakeClock(t)
+	// Simulator doesn't react to the teleport casts, the character never moves
+	sim, c := newSimulation(t, true)
+	d := sim.GetData(false)
+
+	var mu sync.Mutex
+	stuckEvents := make([]event.StuckEvent, 0)
+	event.Subscribe(c.EventListener, func(_ context.Context, e event.StuckEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		stuckEvents = append(stuckEvents, e)
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.EventListener.Listen(ctx)
+
+	// Previous recoveries didn't work, only the last one is left before giving up
+	w := step.NewWatchdog()
+	w.Observe(d)
+	for i := 0; i < 4; i++ {
+		w.NextRecovery(d, event.StuckTeleportFailed)
+	}
+
+	m := step.MoveTo(data.Position{X: levelOrigin.X + 90, Y: levelOrigin.Y + 90}, step.WithWatchdog(w))
+	var err error
+	for i := 0; i < 200 && err == nil; i++ {
+		err = m.Run(sim.GetData(false), c)
+		advance(time.Second)
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	var stuckErr step.StuckError
+	if !errors.As(err, &stuckErr) {
+		t.Fatalf("expected a StuckError, got %v", err)
+	}
+	if stuckErr.Reason != event.StuckTeleportFailed || stuckErr.Position != d.PlayerUnit.Position {
+		t.Errorf("unexpected error %+v", stuckErr)
+	}
+
+	// Events are delivered asynchronously
+	var got []event.StuckEvent
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		mu.Lock()
+		got = append(got[:0], stuckEvents...)
+		mu.Unlock()
+		if len(got) >= 2 {
+			break
+		}
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 stuck events, got %d", len(got))
+	}
+	if got[0].Recovery != step.RecoverySideStep || got[0].Attempt != 5 {
+		t.Errorf("expected a side step on the 5th attempt, got %s on attempt %d", got[0].Recovery, got[0].Attempt)
+	}
+	if got[1].Recovery != step.RecoveryAbort || got[1].Attempt != 6 || got[1].Reason != event.StuckTeleportFailed {
+		t.Errorf("expected an abort on the 6th attempt, got %s on attempt %d", got[1].Recovery, got[1].Attempt)
+	}
+	if clicks := simulator.Clicks(sim.Input(), game.RightButton); len(clicks) < 3 {
+		t.Errorf("expected several teleport casts before giving up, got %d", len(clicks))
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/hectorgimenez/koolo/internal/game"
-	"github.com/lxn/win"
 
 	"github.com/hectorgimenez/d2go/pkg/data/item"
 	"github.com/hectorgimenez/d2go/pkg/data/npc"
@@ -21,12 +20,12 @@ func (b *Builder) VendorRefill(forceRefill, sellJunk bool) *Chain {
 
 		b.Logger.Info("Visiting vendor...", slog.Bool("forceRefill", forceRefill))
 
-		openShopStep := step.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)
+		openShopStep := step.KeySequence(game.VKHome, game.VKDown, game.VKReturn)
 		vendorNPC := town.GetTownByArea(d.PlayerUnit.Area).RefillNPC()
 
 		// Jamella trade button is the first one
 		if vendorNPC == npc.Jamella {
-			openShopStep = step.KeySequence(win.VK_HOME, win.VK_RETURN)
+			openShopStep = step.KeySequence(game.VKHome, game.VKReturn)
 		}
 
 		if vendorNPC == npc.Drognan {
@@ -51,18 +50,18 @@ func (b *Builder) VendorRefill(forceRefill, sellJunk bool) *Chain {
 				return nil
 			}),
 			step.Wait(time.Second),
-			step.KeySequence(win.VK_ESCAPE),
+			step.KeySequence(game.VKEscape),
 		)}
 	})
 }
 
 func (b *Builder) BuyAtVendor(vendor npc.ID, items ...VendorItemRequest) *Chain {
 	return NewChain(func(d game.Data) []Action {
-		openShopStep := step.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)
+		openShopStep := step.KeySequence(game.VKHome, game.VKDown, game.VKReturn)
 
 		// Jamella trade button is the first one
 		if vendor == npc.Jamella {
-			openShopStep = step.KeySequence(win.VK_HOME, win.VK_RETURN)
+			openShopStep = step.KeySequence(game.VKHome, game.VKReturn)
 		}
 
 		return []Action{b.InteractNPC(vendor,
@@ -82,7 +81,7 @@ func (b *Builder) BuyAtVendor(vendor npc.ID, items ...VendorItemRequest) *Chain
 				return nil
 			}),
 			step.Wait(time.Second),
-			step.KeySequence(win.VK_ESCAPE),
+			step.KeySequence(game.VKEscape),
 		)}
 	})
 }
@@ -6,7 +6,6 @@ import (
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/helper"
-	"github.com/lxn/win"
 )
 
 func (b *Builder) DiscoverWaypoint() *Chain {
@@ -21,7 +20,7 @@ func (b *Builder) DiscoverWaypoint() *Chain {
 					step.SyncStep(func(d game.Data) error {
 						b.Logger.Info("Waypoint discovered", slog.String("area", d.PlayerUnit.Area.Area().Name))
 						helper.Sleep(500)
-						b.HID.PressKey(win.VK_ESCAPE)
+						b.HID.PressKey(game.VKEscape)
 						return nil
 					}),
 				)}
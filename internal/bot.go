@@ -14,9 +14,12 @@ import (
 	"github.com/hectorgimenez/koolo/internal/config"
 	"github.com/hectorgimenez/koolo/internal/container"
 	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/health"
 	"github.com/hectorgimenez/koolo/internal/helper"
+	"github.com/hectorgimenez/koolo/internal/metrics"
 	"github.com/hectorgimenez/koolo/internal/run"
+	"github.com/hectorgimenez/koolo/internal/snapshot"
 )
 
 // Bot will be in charge of running the run loop: create games, traveling, killing bosses, repairing, picking...
@@ -29,6 +32,7 @@ type Bot struct {
 	pauseRequested  bool
 	resumeRequested bool
 	supervisorName  string
+	snapshots       *snapshot.Recorder
 }
 
 func NewBot(
@@ -52,22 +56,29 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) (err error
 	companionTPRequested := false
 	companionLeftGame := false
 	if b.c.CharacterCfg.Companion.Enabled && b.c.CharacterCfg.Companion.Leader {
-		b.c.EventListener.Register(func(ctx context.Context, e event.Event) error {
-			switch evt := e.(type) {
-			case event.CompanionRequestedTPEvent:
-				if time.Since(companionTPRequestedAt) > time.Second*5 {
-					companionTPRequestedAt = time.Now()
-					companionTPRequested = true
-				}
-			case event.GameFinishedEvent:
-				cmp := config.Characters[evt.Supervisor()].Companion
-				if cmp.Enabled && !cmp.Leader {
-					companionLeftGame = true
-				}
+		tpRequestedID := event.Subscribe(b.c.EventListener, func(_ context.Context, _ event.CompanionRequestedTPEvent) error {
+			if time.Since(companionTPRequestedAt) > time.Second*5 {
+				companionTPRequestedAt = time.Now()
+				companionTPRequested = true
 			}
 
 			return nil
 		})
+		defer b.c.EventListener.Unregister(tpRequestedID)
+
+		gameFinishedID := event.Subscribe(b.c.EventListener, func(_ context.Context, e event.GameFinishedEvent) error {
+			cmp := config.Characters[e.Supervisor()].Companion
+			if cmp.Enabled && !cmp.Leader {
+				companionLeftGame = true
+			}
+
+			return nil
+		})
+		defer b.c.EventListener.Unregister(gameFinishedID)
+	}
+
+	if b.snapshots != nil {
+		defer b.snapshots.Finish()
 	}
 
 	gameStartedAt := time.Now()
@@ -77,13 +88,11 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) (err error
 
 	for k, r := range runs {
 
-		if config.Koolo.Discord.EnableNewRunMessages {
-			event.Send(event.RunStarted(event.Text(b.supervisorName, "Starting run"), r.Name()))
-		} else {
-			event.Send(event.RunStarted(event.Text(b.supervisorName, ""), r.Name()))
-		}
+		event.Send(event.RunStarted(event.Text(b.supervisorName, "Starting run"), r.Name()))
+		b.ab.SetCurrentRun(r.Name())
 		runStart := time.Now()
 		b.logger.Info(fmt.Sprintf("Running: %s", r.Name()))
+		b.startSnapshot(r.Name())
 
 		actions = slices.Concat(actions,
 			b.ab.PreRunHook(firstRun),
@@ -100,140 +109,150 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) (err error
 			case <-ctx.Done():
 				return context.Canceled
 			default:
-				if b.resumeRequested {
-					b.paused = false
-					b.resumeRequested = false
-					b.pauseRequested = false
-					b.logger.Info("Resuming...")
-					b.c.Injector.Load()
-					event.Send(event.GamePaused(event.Text(b.supervisorName, "Game resumed"), false))
-				}
-				if b.pauseRequested {
-					b.paused = true
-					b.resumeRequested = false
-					b.pauseRequested = false
-					b.logger.Info("Pausing...")
-					b.c.Injector.RestoreMemory()
-					event.Send(event.GamePaused(event.Text(b.supervisorName, "Game paused"), true))
-					b.paused = true
-				}
-
-				if b.paused {
-					time.Sleep(time.Second)
-					continue
-				}
-
 				// Throttle loop a bit, don't need to waste CPU
 				if time.Since(loopTime) < time.Millisecond*10 {
 					time.Sleep(time.Millisecond*10 - time.Since(loopTime))
 				}
 
-				d := b.c.Reader.GetData(false)
+				// Every iteration is observed, including the ones waiting for loading screens or paused
+				err := func() error {
+					iterationStart := time.Now()
+					defer func() {
+						metrics.ObserveGameLoop(b.supervisorName, time.Since(iterationStart))
+					}()
 
-				// Skip running stuff if loading screen is present
-				if d.OpenMenus.LoadingScreen {
-					if loadingScreensDetected == 15 {
-						b.logger.Debug("Loading screen detected, waiting until loading screen is gone")
+					if b.resumeRequested {
+						b.paused = false
+						b.resumeRequested = false
+						b.pauseRequested = false
+						b.logger.Info("Resuming...")
+						b.c.Injector.Load()
+						event.Send(event.GamePaused(event.Text(b.supervisorName, "Game resumed"), false))
+					}
+					if b.pauseRequested {
+						b.paused = true
+						b.resumeRequested = false
+						b.pauseRequested = false
+						b.logger.Info("Pausing...")
+						b.c.Injector.RestoreMemory()
+						event.Send(event.GamePaused(event.Text(b.supervisorName, "Game paused"), true))
+						b.paused = true
 					}
-					loadingScreensDetected++
-					continue
-				}
 
-				if loadingScreensDetected >= 15 {
-					b.logger.Debug("Load completed, continuing execution")
-				}
-				loadingScreensDetected = 0
+					if b.paused {
+						time.Sleep(time.Second)
+						return nil
+					}
 
-				// By this point we're ingame
+					d := b.c.Reader.GetData(false)
 
-				// Check if we have all keybindings
-				missingBindings := b.ab.CheckKeyBindings(d)
-				if len(missingBindings) > 0 {
-					var str = "Missing skill bindings for skills:"
-					for _, id := range missingBindings {
-						str += "\n" + skill.SkillNames[id]
+					// Skip running stuff if loading screen is present
+					if d.OpenMenus.LoadingScreen {
+						if loadingScreensDetected == 15 {
+							b.logger.Debug("Loading screen detected, waiting until loading screen is gone")
+						}
+						loadingScreensDetected++
+						return nil
 					}
-					str += "\nPlease bind the skills to a key. Pausing bot..."
 
-					// Display the message box
-					helper.ShowDialog(b.supervisorName+" skill bindings missing", str)
+					if loadingScreensDetected >= 15 {
+						b.logger.Debug("Load completed, continuing execution")
+					}
+					loadingScreensDetected = 0
 
-					// Pause the bot
-					b.pauseRequested = true
-					continue
-				}
+					// By this point we're ingame
+					b.recordSnapshot(d)
 
-				if err := b.hm.HandleHealthAndMana(d); err != nil {
-					return err
-				}
+					// Check if we have all keybindings
+					missingBindings := b.ab.CheckKeyBindings(d)
+					if len(missingBindings) > 0 {
+						var str = "Missing skill bindings for skills:"
+						for _, id := range missingBindings {
+							str += "\n" + skill.SkillNames[id]
+						}
+						str += "\nPlease bind the skills to a key. Pausing bot..."
 
-				// Check if game length is exceeded, only if it's not a leveling run
-				if r.Name() != string(config.LevelingRun) {
-					if err := b.maxGameLengthExceeded(gameStartedAt); err != nil {
-						return err
+						// Display the message box
+						helper.ShowDialog(b.supervisorName+" skill bindings missing", str)
+
+						// Pause the bot
+						b.pauseRequested = true
+						return nil
 					}
-				}
-				// Some hacky stuff for companion mode, ideally should be encapsulated everything together in a different place
-				if d.CharacterCfg.Companion.Enabled {
-					if companionTPRequested && r.Name() == string(config.LevelingRun) {
-						companionTPRequested = false
-						actions = append([]action.Action{b.ab.OpenTPIfLeader()}, actions...)
+
+					if err := b.hm.HandleHealthAndMana(d); err != nil {
+						return err
 					}
-					if companionLeftGame {
-						event.Send(event.RunFinished(event.WithScreenshot(b.supervisorName, "Companion left game", b.c.Reader.Screenshot()), r.Name(), event.FinishedError))
-						return errors.New("companion left game")
+
+					// Check if game length is exceeded, only if it's not a leveling run
+					if r.Name() != string(config.LevelingRun) {
+						if err := b.maxGameLengthExceeded(gameStartedAt); err != nil {
+							return err
+						}
 					}
-					_, leaderFound := d.Roster.FindByName(d.CharacterCfg.Companion.LeaderName)
-					if !leaderFound {
-						event.Send(event.RunFinished(event.WithScreenshot(b.supervisorName, "Leader left game", b.c.Reader.Screenshot()), r.Name(), event.FinishedError))
-						return errors.New("leader left game")
+					// Some hacky stuff for companion mode, ideally should be encapsulated everything together in a different place
+					if d.CharacterCfg.Companion.Enabled {
+						if companionTPRequested && r.Name() == string(config.LevelingRun) {
+							companionTPRequested = false
+							actions = append([]action.Action{b.ab.OpenTPIfLeader()}, actions...)
+						}
+						if companionLeftGame {
+							event.Send(event.RunFinished(event.WithScreenshot(b.supervisorName, "Companion left game", b.c.Screenshotter.Screenshot()), r.Name(), event.FinishedError))
+							return errors.New("companion left game")
+						}
+						_, leaderFound := d.Roster.FindByName(d.CharacterCfg.Companion.LeaderName)
+						if !leaderFound {
+							event.Send(event.RunFinished(event.WithScreenshot(b.supervisorName, "Leader left game", b.c.Screenshotter.Screenshot()), r.Name(), event.FinishedError))
+							return errors.New("leader left game")
+						}
 					}
-				}
 
-				if len(eachLoopActions) == 0 || (reflect.ValueOf(eachLoopActions[len(eachLoopActions)-1]).IsNil() || eachLoopActions[len(eachLoopActions)-1].IsFinished()) {
-					eachLoopActions = b.ab.EachLoopHook(d)
-					if len(eachLoopActions) > 0 {
-						actions = append(eachLoopActions, actions...)
+					if len(eachLoopActions) == 0 || (reflect.ValueOf(eachLoopActions[len(eachLoopActions)-1]).IsNil() || eachLoopActions[len(eachLoopActions)-1].IsFinished()) {
+						eachLoopActions = b.ab.EachLoopHook(d)
+						if len(eachLoopActions) > 0 {
+							actions = append(eachLoopActions, actions...)
+						}
 					}
-				}
 
-				for k, act := range actions {
-					// Ensure we're not trying to access a nil action
-					if act == nil {
-						continue
-					}
-					err := act.NextStep(d, b.c)
-					loopTime = time.Now()
-					if errors.Is(err, action.ErrNoMoreSteps) {
-						if len(actions)-1 == k {
-							b.logger.Info(fmt.Sprintf("Run %s finished, length: %0.2fs", r.Name(), time.Since(runStart).Seconds()))
-							if config.Koolo.Discord.EnableRunFinishMessages {
+					for k, act := range actions {
+						// Ensure we're not trying to access a nil action
+						if act == nil {
+							continue
+						}
+						err := act.NextStep(d, b.c)
+						loopTime = time.Now()
+						if errors.Is(err, action.ErrNoMoreSteps) {
+							if len(actions)-1 == k {
+								b.logger.Info(fmt.Sprintf("Run %s finished, length: %0.2fs", r.Name(), time.Since(runStart).Seconds()))
 								event.Send(event.RunFinished(event.Text(b.supervisorName, "Finished run"), r.Name(), event.FinishedOK))
-							} else {
-								event.Send(event.RunFinished(event.Text(b.supervisorName, ""), r.Name(), event.FinishedOK))
+								running = false
 							}
-							running = false
+							continue
+						}
+						if errors.Is(err, action.ErrWillBeRetried) {
+							b.logger.Warn("error occurred, will be retried", slog.Any("error", err))
+							break
+						}
+						if errors.Is(err, action.ErrCanBeSkipped) {
+							event.Send(event.RunActionSkipped(event.WithScreenshot(b.supervisorName, err.Error(), b.c.Screenshotter.Screenshot()), r.Name()))
+							b.logger.Warn("error occurred on action that can be skipped, game will continue", slog.Any("error", err))
+							act.Skip()
+							break
+						}
+						if errors.Is(err, action.ErrLogAndContinue) {
+							b.logger.Warn(err.Error())
+							break
+						}
+						if err != nil {
+							return err
 						}
-						continue
-					}
-					if errors.Is(err, action.ErrWillBeRetried) {
-						b.logger.Warn("error occurred, will be retried", slog.Any("error", err))
-						break
-					}
-					if errors.Is(err, action.ErrCanBeSkipped) {
-						event.Send(event.RunFinished(event.WithScreenshot(b.supervisorName, err.Error(), b.c.Reader.Screenshot()), r.Name(), event.FinishedError))
-						b.logger.Warn("error occurred on action that can be skipped, game will continue", slog.Any("error", err))
-						act.Skip()
-						break
-					}
-					if errors.Is(err, action.ErrLogAndContinue) {
-						b.logger.Warn(err.Error())
 						break
 					}
-					if err != nil {
-						return err
-					}
-					break
+
+					return nil
+				}()
+				if err != nil {
+					return err
 				}
 			}
 		}
@@ -242,6 +261,42 @@ func (b *Bot) Run(ctx context.Context, firstRun bool, runs []run.Run) (err error
 	return nil
 }
 
+// RecordSnapshots enables recording the game data of every run, nil disables it
+func (b *Bot) RecordSnapshots(recorder *snapshot.Recorder) {
+	b.snapshots = recorder
+}
+
+func (b *Bot) startSnapshot(runName string) {
+	if b.snapshots == nil {
+		return
+	}
+
+	gameAreaSizeX, gameAreaSizeY := b.c.Reader.GameAreaSize()
+	err := b.snapshots.Start(snapshot.Header{
+		Supervisor:    b.supervisorName,
+		Run:           runName,
+		StartedAt:     time.Now(),
+		MapSeed:       b.c.Reader.MapSeed(),
+		Difficulty:    b.c.CharacterCfg.Game.Difficulty,
+		GameAreaSizeX: gameAreaSizeX,
+		GameAreaSizeY: gameAreaSizeY,
+		MapData:       b.c.Reader.GetCachedMapData(false),
+	})
+	if err != nil {
+		b.logger.Warn("Error starting snapshot recording", slog.Any("error", err))
+	}
+}
+
+func (b *Bot) recordSnapshot(d game.Data) {
+	if b.snapshots == nil {
+		return
+	}
+
+	if err := b.snapshots.Record(d.Data); err != nil {
+		b.logger.Warn("Error recording snapshot, recording stopped until next run", slog.Any("error", err))
+	}
+}
+
 func (b *Bot) maxGameLengthExceeded(startedAt time.Time) error {
 	// Check if config or Characters map is nil
 	if config.Characters == nil {
@@ -278,3 +333,10 @@ func (b *Bot) TogglePause() {
 		b.pauseRequested = true
 	}
 }
+
+// Pause stops the bot until it's resumed, it does nothing if it's already paused
+func (b *Bot) Pause() {
+	if !b.paused {
+		b.pauseRequested = true
+	}
+}
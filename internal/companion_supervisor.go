@@ -10,6 +10,7 @@ import (
 	"github.com/hectorgimenez/koolo/internal/action"
 
 	"github.com/hectorgimenez/koolo/internal/container"
+	"github.com/hectorgimenez/koolo/internal/game"
 
 	"github.com/hectorgimenez/koolo/internal/config"
 	"github.com/hectorgimenez/koolo/internal/event"
@@ -21,8 +22,8 @@ type CompanionSupervisor struct {
 	*baseSupervisor
 }
 
-func NewCompanionSupervisor(name string, bot *Bot, runFactory *run.Factory, statsHandler *StatsHandler, c container.Container, pid uint32, hwnd uintptr) (*CompanionSupervisor, error) {
-	bs, err := newBaseSupervisor(bot, runFactory, name, statsHandler, c)
+func NewCompanionSupervisor(name string, bot *Bot, runFactory *run.Factory, statsHandler *StatsHandler, c container.Container, gr *game.MemoryReader, pid uint32, hwnd uintptr) (*CompanionSupervisor, error) {
+	bs, err := newBaseSupervisor(bot, runFactory, name, statsHandler, c, gr)
 	if err != nil {
 		return nil, err
 	}
@@ -54,6 +55,12 @@ func (s *CompanionSupervisor) Start() error {
 		case <-ctx.Done():
 			return nil
 		default:
+			if nextStart, reason, onBreak := s.session.Break(config.Characters[s.name], time.Now()); onBreak {
+				if err = s.takeBreak(ctx, nextStart, reason); err != nil {
+					return err
+				}
+				continue
+			}
 			if s.c.CharacterCfg.Companion.Leader {
 				time.Sleep(time.Second * 5)
 				gameName, err := s.c.Manager.CreateOnlineGame(gameCounter)
@@ -63,12 +70,9 @@ func (s *CompanionSupervisor) Start() error {
 					continue
 				}
 
-				if config.Koolo.Discord.EnableGameCreatedMessages {
-					event.Send(event.GameCreated(event.Text(s.name, "New game created: %s"), gameName, config.Characters[s.name].Companion.GamePassword))
-				} else {
-					event.Send(event.GameCreated(event.Text(s.name, ""), gameName, config.Characters[s.name].Companion.GamePassword))
-				}
-				err = s.startBot(ctx, s.runFactory.BuildRuns(), firstRun)
+				runs := s.scheduler.Schedule(config.Characters[s.name], s.runFactory.BuildRuns())
+				event.Send(event.GameCreated(event.Text(s.name, "New game created: "+gameName), gameName, config.Characters[s.name].Companion.GamePassword))
+				err = s.startBot(ctx, runs, firstRun)
 				if err != nil {
 					return err
 				}
@@ -106,7 +110,7 @@ func (s *CompanionSupervisor) startBot(ctx context.Context, runs []run.Run, firs
 			return nil
 		}
 		errorMsg := fmt.Sprintf("Game finished with errors, reason: %s. Game total time: %0.2fs", err.Error(), time.Since(gameStart).Seconds())
-		event.Send(event.GameFinished(event.WithScreenshot(s.name, errorMsg, s.c.Reader.Screenshot()), event.FinishedError))
+		event.Send(event.GameFinished(event.WithScreenshot(s.name, errorMsg, s.c.Screenshotter.Screenshot()), event.FinishedError))
 		s.c.Logger.Warn(errorMsg, slog.String("supervisor", s.name))
 	}
 	if exitErr := s.c.Manager.ExitGame(); exitErr != nil {
@@ -9,6 +9,7 @@ import (
 
 	"os"
 	"strings"
+	"time"
 
 	"github.com/hectorgimenez/d2go/pkg/data/area"
 	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
@@ -32,6 +33,7 @@ type KooloCfg struct {
 		Log         bool `yaml:"log"`
 		Screenshots bool `yaml:"screenshots"`
 		RenderMap   bool `yaml:"renderMap"`
+		Snapshots   bool `yaml:"snapshots"`
 	} `yaml:"debug"`
 	FirstRun              bool   `yaml:"firstRun"`
 	UseCustomSettings     bool   `yaml:"useCustomSettings"`
@@ -40,23 +42,76 @@ type KooloCfg struct {
 	D2LoDPath             string `yaml:"D2LoDPath"`
 	D2RPath               string `yaml:"D2RPath"`
 	Discord               struct {
-		Enabled                      bool   `yaml:"enabled"`
-		EnableGameCreatedMessages    bool   `yaml:"enableGameCreatedMessages"`
-		EnableNewRunMessages         bool   `yaml:"enableNewRunMessages"`
-		EnableRunFinishMessages      bool   `yaml:"enableRunFinishMessages"`
-		EnableDiscordChickenMessages bool   `yaml:"enableDiscordChickenMessages"`
-		ChannelID                    string `yaml:"channelId"`
-		Token                        string `yaml:"token"`
+		Enabled                      bool            `yaml:"enabled"`
+		EnableGameCreatedMessages    bool            `yaml:"enableGameCreatedMessages"`
+		EnableNewRunMessages         bool            `yaml:"enableNewRunMessages"`
+		EnableRunFinishMessages      bool            `yaml:"enableRunFinishMessages"`
+		EnableDiscordChickenMessages bool            `yaml:"enableDiscordChickenMessages"`
+		ChannelID                    string          `yaml:"channelId"`
+		Token                        string          `yaml:"token"`
+		Routing                      NotifierRouting `yaml:"routing"`
 	} `yaml:"discord"`
 	Telegram struct {
-		Enabled bool   `yaml:"enabled"`
-		ChatID  int64  `yaml:"chatId"`
-		Token   string `yaml:"token"`
+		Enabled bool            `yaml:"enabled"`
+		ChatID  int64           `yaml:"chatId"`
+		Token   string          `yaml:"token"`
+		Routing NotifierRouting `yaml:"routing"`
 	}
 	Overseer struct {
 		Enabled bool   `yaml:"enabled"`
 		AppURL  string `yaml:"appUrl"`
 	} `yaml:"overseer"`
+	Journal struct {
+		Enabled            bool   `yaml:"enabled"`
+		Directory          string `yaml:"directory"`
+		MaxSizeMB          int    `yaml:"maxSizeMB"`
+		MaxFiles           int    `yaml:"maxFiles"`
+		IncludeScreenshots bool   `yaml:"includeScreenshots"`
+	} `yaml:"journal"`
+	DropLedger struct {
+		Directory   string `yaml:"directory"`
+		Screenshots bool   `yaml:"screenshots"`
+	} `yaml:"dropLedger"`
+	MapCache struct {
+		Enabled   bool   `yaml:"enabled"`
+		Directory string `yaml:"directory"`
+		MaxSizeMB int    `yaml:"maxSizeMB"`
+	} `yaml:"mapCache"`
+	MapProvider struct {
+		Type      string `yaml:"type"`
+		URL       string `yaml:"url"`
+		Token     string `yaml:"token"`
+		Directory string `yaml:"directory"`
+	} `yaml:"mapProvider"`
+	Webhook struct {
+		Enabled           bool            `yaml:"enabled"`
+		URLs              []string        `yaml:"urls"`
+		Events            []string        `yaml:"events"`
+		Secret            string          `yaml:"secret"`
+		IncludeScreenshot bool            `yaml:"includeScreenshot"`
+		MaxRetries        int             `yaml:"maxRetries"`
+		Routing           NotifierRouting `yaml:"routing"`
+	} `yaml:"webhook"`
+}
+
+// NotifierRouting decides which events are sent to a remote notifier, an event is sent if it matches any of
+// the rules. Rate limit and dedup are applied after matching, zero values disable them.
+type NotifierRouting struct {
+	Rules     []RoutingRule `yaml:"rules"`
+	RateLimit struct {
+		MaxEvents int           `yaml:"maxEvents"`
+		Window    time.Duration `yaml:"window"`
+	} `yaml:"rateLimit"`
+	DedupWindow time.Duration `yaml:"dedupWindow"`
+}
+
+// RoutingRule matches when all the non-empty conditions match, values inside a condition are OR'ed
+type RoutingRule struct {
+	Events        []string `yaml:"events"`
+	Supervisors   []string `yaml:"supervisors"`
+	Reasons       []string `yaml:"reasons"`
+	ItemQualities []string `yaml:"itemQualities"`
+	RuleFiles     []string `yaml:"ruleFiles"`
 }
 
 type CharacterCfg struct {
@@ -72,6 +127,19 @@ type CharacterCfg struct {
 	ClassicMode     bool   `yaml:"classicMode"`
 	CloseMiniPanel  bool   `yaml:"closeMiniPanel"`
 
+	Session struct {
+		Enabled bool `yaml:"enabled"`
+		// ActiveHours are the time windows the character plays, outside of them it takes a break
+		ActiveHours []TimeWindow `yaml:"activeHours"`
+		// SessionLength is the play time before taking a break of BreakDuration, 0 plays without breaks
+		SessionLength  time.Duration `yaml:"sessionLength"`
+		BreakDuration  time.Duration `yaml:"breakDuration"`
+		MaxGamesPerDay int           `yaml:"maxGamesPerDay"`
+		MaxHoursPerDay float64       `yaml:"maxHoursPerDay"`
+		// CloseGame closes D2R during the breaks, it will be started again for the next session
+		CloseGame bool `yaml:"closeGame"`
+	} `yaml:"session"`
+
 	Health struct {
 		HealingPotionAt     int `yaml:"healingPotionAt"`
 		ManaPotionAt        int `yaml:"manaPotionAt"`
@@ -98,7 +166,12 @@ type CharacterCfg struct {
 		Difficulty             difficulty.Difficulty `yaml:"difficulty"`
 		RandomizeRuns          bool                  `yaml:"randomizeRuns"`
 		Runs                   []Run                 `yaml:"runs"`
-		Pindleskin             struct {
+		Scheduler              struct {
+			// RunsPerGame limits the runs executed every game, picked by weight. 0 executes all the eligible runs
+			RunsPerGame int                 `yaml:"runsPerGame"`
+			Runs        map[Run]RunSchedule `yaml:"runs"`
+		} `yaml:"scheduler"`
+		Pindleskin struct {
 			SkipOnImmunities []stat.Resist `yaml:"skipOnImmunities"`
 		} `yaml:"pindleskin"`
 		Cows struct {
@@ -187,6 +260,12 @@ type CharacterCfg struct {
 		GameNameTemplate string `yaml:"gameNameTemplate"`
 		GamePassword     string `yaml:"gamePassword"`
 	} `yaml:"companion"`
+	Quotas struct {
+		// SharedWith are the supervisors using the same shared stash, the items they keep in their personal stash,
+		// inventory and cube (last time they were seen in game) count for the quotas too
+		SharedWith []string    `yaml:"sharedWith"`
+		Items      []ItemQuota `yaml:"items"`
+	} `yaml:"quotas"`
 	Gambling struct {
 		Enabled bool        `yaml:"enabled"`
 		Items   []item.Name `yaml:"items"`
@@ -203,17 +282,80 @@ type CharacterCfg struct {
 	} `yaml:"backtotown"`
 	Stash struct {
 		StockpileRejuvs bool `yaml:"stockpileRejuvs"`
+		// TabPreferences are the tabs tried first for each item category (runes, gems, charms, jewels, runewords,
+		// uniques, sets and other), the rest of the tabs are used when they are full
+		TabPreferences map[string][]int `yaml:"tabPreferences"`
 	} `yaml:"stash"`
+	Pathing struct {
+		Danger struct {
+			Enabled     bool          `yaml:"enabled"`
+			Elites      DangerLayer   `yaml:"elites"`
+			Immunities  DangerLayer   `yaml:"immunities"`
+			Resists     []stat.Resist `yaml:"resists"`
+			BaalWaves   DangerLayer   `yaml:"baalWaves"`
+			DuranceFire DangerLayer   `yaml:"duranceFire"`
+		} `yaml:"danger"`
+	} `yaml:"pathing"`
 	Overseer struct {
 		Tmp             bool   `yaml:"tmp"`
 		ApiSupervisorId string `yaml:"apiSupervisorId"`
 	} `yaml:"overseer"`
 	Runtime struct {
-		Rules nip.Rules   `yaml:"-" json:"-"`
-		Drops []data.Item `yaml:"-" json:"-"`
+		Rules          nip.Rules      `yaml:"-" json:"-"`
+		PickitProblems PickitProblems `yaml:"-" json:"-"`
+		Drops          []data.Item    `yaml:"-" json:"-"`
 	} `yaml:"-" json:"-"`
 }
 
+// DangerLayer sets the extra walking cost added to every tile in the given radius of a danger source, 0 cost disables it
+type DangerLayer struct {
+	Radius int     `yaml:"radius"`
+	Cost   float64 `yaml:"cost"`
+}
+
+// RunSchedule restricts when a run is executed, zero values don't apply any restriction
+type RunSchedule struct {
+	// Weight is the relative chance of the run being picked first, 1 by default
+	Weight float64 `yaml:"weight"`
+	// EveryGames executes the run once every N games
+	EveryGames int `yaml:"everyGames"`
+	// The run is skipped during the cooldown after failing MaxFailures consecutive times (chicken, death or error)
+	MaxFailures     int           `yaml:"maxFailures"`
+	FailureCooldown time.Duration `yaml:"failureCooldown"`
+	MaxPerHour      int           `yaml:"maxPerHour"`
+	// Windows are the wall-clock time ranges the run is allowed, like 22:00-06:00
+	Windows []TimeWindow `yaml:"windows"`
+}
+
+// ItemQuota limits how many items the character keeps, the items must match all the fields set. Rule is a NIP file,
+// optionally with the line number, like unique.nip or unique.nip:12. Max 0 means the items are never kept.
+type ItemQuota struct {
+	Name    item.Name `yaml:"name"`
+	Type    string    `yaml:"type"`
+	Quality string    `yaml:"quality"`
+	Rule    string    `yaml:"rule"`
+	Max     int       `yaml:"max"`
+}
+
+func (q ItemQuota) validate() error {
+	switch {
+	case q.Name == "" && q.Type == "" && q.Quality == "" && q.Rule == "":
+		return errors.New("quota needs a name, type, quality or rule")
+	case q.Max < 0:
+		return fmt.Errorf("quota max can't be negative: %d", q.Max)
+	case q.Name != "" && item.GetIDByName(string(q.Name)) < 0:
+		return fmt.Errorf("unknown item name %s", q.Name)
+	}
+	if _, found := nip.TypeAliases[strings.ToLower(q.Type)]; q.Type != "" && !found {
+		return fmt.Errorf("unknown item type %s", q.Type)
+	}
+	if _, found := NIPQuality(q.Quality); q.Quality != "" && !found {
+		return fmt.Errorf("unknown item quality %s", q.Quality)
+	}
+
+	return nil
+}
+
 type BeltColumns [4]string
 
 func (bm BeltColumns) Total(potionType data.PotionType) int {
@@ -255,8 +397,13 @@ func Load() error {
 		return fmt.Errorf("error reading config: %w", err)
 	}
 
+	if err = loadRunDefinitions(); err != nil {
+		return err
+	}
+
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		// Run definitions are not a character
+		if !entry.IsDir() || entry.Name() == "runs" {
 			continue
 		}
 
@@ -271,27 +418,38 @@ func Load() error {
 			return fmt.Errorf("error reading %s character config: %w", entry.Name(), err)
 		}
 
-		rules, err := nip.ReadDir("config/" + entry.Name() + "/pickit/")
-		if err != nil {
-			return err
-		}
-
-		if len(charCfg.Game.Runs) > 0 && charCfg.Game.Runs[0] == "leveling" {
-			levelingRules, err := nip.ReadDir("config/" + entry.Name() + "/pickit_leveling/")
-			if err != nil {
-				return err
+		// A broken pickit or quota doesn't stop the rest of the characters from loading, it's flagged and can't be started
+		charCfg.Runtime.Rules, charCfg.Runtime.PickitProblems = ReadPickitRules(entry.Name(), &charCfg)
+		for i, q := range charCfg.Quotas.Items {
+			if qErr := q.validate(); qErr != nil {
+				charCfg.Runtime.PickitProblems = append(charCfg.Runtime.PickitProblems, PickitProblem{
+					Severity: PickitError,
+					File:     "config/" + entry.Name() + "/config.yaml",
+					Message:  fmt.Sprintf("quota %d: %s", i+1, qErr),
+				})
 			}
-			rules = append(rules, levelingRules...)
 		}
 
-		charCfg.Runtime.Rules = rules
-
 		Characters[entry.Name()] = &charCfg
 	}
 
 	return nil
 }
 
+// ReadPickitRules reads the NIP rules of the character pickit directory, leveling characters use pickit_leveling
+// rules as well. Rules with errors are skipped and reported along with the warnings.
+func ReadPickitRules(name string, cfg *CharacterCfg) (nip.Rules, PickitProblems) {
+	rules, problems := LintPickitDir("config/" + name + "/pickit/")
+
+	if len(cfg.Game.Runs) > 0 && cfg.Game.Runs[0] == "leveling" {
+		levelingRules, levelingProblems := LintPickitDir("config/" + name + "/pickit_leveling/")
+		rules = append(rules, levelingRules...)
+		problems = append(problems, levelingProblems...)
+	}
+
+	return rules, problems
+}
+
 func CreateFromTemplate(name string) error {
 	if name == "" {
 		return errors.New("name cannot be empty")
@@ -314,9 +472,11 @@ func ValidateAndSaveConfig(config KooloCfg) error {
 	config.D2LoDPath = strings.ReplaceAll(strings.ToLower(config.D2LoDPath), "game.exe", "")
 	config.D2RPath = strings.ReplaceAll(strings.ToLower(config.D2RPath), "d2r.exe", "")
 
-	// Validate paths
-	if _, err := os.Stat(config.D2LoDPath + "/d2data.mpq"); os.IsNotExist(err) {
-		return errors.New("D2LoDPath is not valid")
+	// Validate paths, LoD is only needed to generate the maps with the local provider (the default one)
+	if config.MapProvider.Type == "" || config.MapProvider.Type == "local" {
+		if _, err := os.Stat(config.D2LoDPath + "/d2data.mpq"); os.IsNotExist(err) {
+			return errors.New("D2LoDPath is not valid")
+		}
 	}
 
 	if _, err := os.Stat(config.D2RPath + "/d2r.exe"); os.IsNotExist(err) {
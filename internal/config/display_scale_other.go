@@ -0,0 +1,8 @@
+//go:build !windows
+
+package config
+
+// GetCurrentDisplayScale has no display to read outside Windows, the game always runs on Windows
+func GetCurrentDisplayScale() float64 {
+	return 1
+}
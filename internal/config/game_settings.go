@@ -1,7 +1,6 @@
 package config
 
 import (
-	"github.com/lxn/win"
 	cp "github.com/otiai10/copy"
 	"os"
 )
@@ -20,11 +19,3 @@ func ReplaceGameSettings() error {
 
 	return cp.Copy("config/Settings.json", settingsFilePath)
 }
-
-func GetCurrentDisplayScale() float64 {
-	hDC := win.GetDC(0)
-	defer win.ReleaseDC(0, hDC)
-	dpiX := win.GetDeviceCaps(hDC, win.LOGPIXELSX)
-
-	return float64(dpiX) / 96.0
-}
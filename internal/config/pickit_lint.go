@@ -0,0 +1,345 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+)
+
+const (
+	PickitError   = "error"
+	PickitWarning = "warning"
+)
+
+// PickitProblem is an issue found in a NIP rule. Rules with errors are not loaded, warnings are only reported.
+type PickitProblem struct {
+	Severity string `json:"severity"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+}
+
+func (p PickitProblem) String() string {
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	}
+
+	return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Message)
+}
+
+type PickitProblems []PickitProblem
+
+func (p PickitProblems) Errors() int {
+	errs := 0
+	for _, problem := range p {
+		if problem.Severity == PickitError {
+			errs++
+		}
+	}
+
+	return errs
+}
+
+var (
+	nipFixedPropRegexp   = regexp.MustCompile(`\[(type|quality|class|name|flag|color)]\s*(<=|<|>|>=|!=|==)\s*([a-zA-Z0-9]+)`)
+	nipStatRegexp        = regexp.MustCompile(`\[(.*?)]`)
+	nipMaxQuantityRegexp = regexp.MustCompile(`\[maxquantity]\s*(<=|<|>|>=|!=|==)\s*([0-9]+)`)
+
+	nipQualities = map[string]item.Quality{
+		"lowquality": item.QualityLowQuality,
+		"normal":     item.QualityNormal,
+		"superior":   item.QualitySuperior,
+		"magic":      item.QualityMagic,
+		"set":        item.QualitySet,
+		"rare":       item.QualityRare,
+		"unique":     item.QualityUnique,
+		"crafted":    item.QualityCrafted,
+	}
+	nipClasses = []string{"normal", "exceptional", "elite"}
+
+	// Qualities an item type can drop with, types not listed here can have any quality
+	typeQualities = map[string][]item.Quality{
+		item.TypeRing:        {item.QualityMagic, item.QualitySet, item.QualityRare, item.QualityUnique, item.QualityCrafted},
+		item.TypeAmulet:      {item.QualityMagic, item.QualitySet, item.QualityRare, item.QualityUnique, item.QualityCrafted},
+		item.TypeJewel:       {item.QualityMagic, item.QualityRare, item.QualityUnique, item.QualityCrafted},
+		item.TypeSmallCharm:  {item.QualityMagic, item.QualityUnique},
+		item.TypeMediumCharm: {item.QualityMagic, item.QualityUnique},
+		item.TypeLargeCharm:  {item.QualityMagic, item.QualityUnique},
+	}
+	normalOnlyTypes = []string{"rune", "gold", "potion", "healingpotion", "manapotion", "rejuvpotion", "staminapotion",
+		"antidotepotion", "thawingpotion", "elixir", "scroll", "key", "book", "quest", "herb", "playerbodypart",
+		"bodypart", "gem", "chippedgem", "flawedgem", "standardgem", "flawlessgem", "perfectgem", "amethyst", "diamond",
+		"emerald", "ruby", "sapphire", "topaz", "skull"}
+
+	// Types of the items that exist in the game, rules with other types (like "charm" or "weapon") never match
+	usedTypes = func() map[string]bool {
+		types := make(map[string]bool)
+		for _, desc := range item.Desc {
+			types[desc.Type] = true
+		}
+
+		return types
+	}()
+)
+
+func init() {
+	for _, alias := range normalOnlyTypes {
+		if code, found := nip.TypeAliases[alias]; found {
+			typeQualities[code] = []item.Quality{item.QualityNormal}
+		}
+	}
+}
+
+// NIPQuality returns the quality by its name in NIP rules, case-insensitive
+func NIPQuality(name string) (item.Quality, bool) {
+	q, found := nipQualities[strings.ToLower(name)]
+	return q, found
+}
+
+// LintPickitDir reads all the NIP files in the directory like nip.ReadDir, but instead of stopping on the first
+// error every problem is reported, and the rules without errors are returned
+func LintPickitDir(path string) (nip.Rules, PickitProblems) {
+	files, err := os.ReadDir(path)
+	if err != nil {
+		return nil, PickitProblems{{Severity: PickitError, File: path, Message: err.Error()}}
+	}
+
+	l := pickitLinter{seen: make(map[string]nip.Rule), unconditional: make(map[string]nip.Rule)}
+	rules := make(nip.Rules, 0)
+	for _, file := range files {
+		if !strings.HasSuffix(strings.ToLower(file.Name()), ".nip") || file.IsDir() {
+			continue
+		}
+
+		rules = append(rules, l.lintFile(path+file.Name())...)
+	}
+
+	return rules, l.problems
+}
+
+type pickitLinter struct {
+	problems PickitProblems
+	// Previous rules by their normalized text, and by their first part for the ones without stats
+	seen          map[string]nip.Rule
+	unconditional map[string]nip.Rule
+}
+
+func (l *pickitLinter) lintFile(path string) nip.Rules {
+	f, err := os.Open(path)
+	if err != nil {
+		l.problems = append(l.problems, PickitProblem{Severity: PickitError, File: path, Message: err.Error()})
+		return nil
+	}
+	defer f.Close()
+
+	// Same item used by nip to test the rules at load time
+	dummyItem := data.Item{ID: 516, Name: "healingpotion", Quality: item.QualityNormal}
+
+	rules := make(nip.Rules, 0)
+	sc := bufio.NewScanner(f)
+	lineNumber := 0
+	for sc.Scan() {
+		lineNumber++
+		line := sc.Text()
+		rule, err := nip.NewRule(line, path, lineNumber)
+		if errors.Is(err, nip.ErrEmptyRule) {
+			continue
+		}
+		if err == nil {
+			_, err = rule.Evaluate(dummyItem)
+		}
+		if err != nil {
+			l.report(PickitError, path, lineNumber, line, err.Error())
+			continue
+		}
+
+		if l.lintRule(rule) {
+			rules = append(rules, rule)
+		}
+	}
+	if err = sc.Err(); err != nil {
+		l.problems = append(l.problems, PickitProblem{Severity: PickitError, File: path, Message: err.Error()})
+	}
+
+	return rules
+}
+
+// lintRule checks a rule that nip was able to parse, returns false if it has errors and can't be used
+func (l *pickitLinter) lintRule(rule nip.Rule) bool {
+	report := func(severity, msg string, args ...any) {
+		l.report(severity, rule.Filename, rule.LineNumber, rule.RawLine, fmt.Sprintf(msg, args...))
+	}
+
+	line := sanitizeNIPLine(rule.RawLine)
+	maxQuantities := nipMaxQuantityRegexp.FindAllStringSubmatch(line, -1)
+	for _, mq := range maxQuantities {
+		if mq[1] != "==" {
+			report(PickitWarning, "maxquantity only supports ==, %s is evaluated as ==", mq[1])
+		}
+		if mq[2] == "0" {
+			report(PickitWarning, "maxquantity 0 means no limit")
+		}
+	}
+	stages := strings.Split(nipMaxQuantityRegexp.ReplaceAllString(line, ""), "#")
+	if len(maxQuantities) > 0 && strings.Count(line, "#") < 2 {
+		report(PickitWarning, "maxquantity should be in the third part of the rule, after the second #")
+	}
+
+	valid := true
+	props := nipFixedPropRegexp.FindAllStringSubmatch(stages[0], -1)
+	for _, p := range props {
+		switch p[1] {
+		case "name":
+			if item.GetIDByName(p[3]) < 0 {
+				report(PickitError, "unknown item name %s", p[3])
+				valid = false
+			}
+		case "type":
+			code, found := nip.TypeAliases[p[3]]
+			if !found {
+				report(PickitError, "unknown item type %s", p[3])
+				valid = false
+			} else if !usedTypes[code] {
+				report(PickitWarning, "no item has type %s, the rule will never match", p[3])
+			}
+		case "quality":
+			if _, found := nipQualities[p[3]]; !found {
+				report(PickitError, "unknown item quality %s", p[3])
+				valid = false
+			}
+		case "class":
+			if !slices.Contains(nipClasses, p[3]) {
+				report(PickitError, "unknown item class %s", p[3])
+				valid = false
+			}
+		}
+	}
+
+	if len(stages) > 1 {
+		for _, s := range nipStatRegexp.FindAllStringSubmatch(stages[1], -1) {
+			if _, found := nip.StatAliases[s[1]]; !found {
+				report(PickitError, "unknown stat %s", s[1])
+				valid = false
+			}
+		}
+	}
+
+	if !valid {
+		return false
+	}
+
+	if msg, impossible := impossibleQuality(stages[0], props); impossible {
+		report(PickitWarning, "%s", msg)
+	}
+
+	// A previous rule matching the same items without stats is always evaluated first
+	stage1 := strings.TrimSpace(stages[0])
+	hasStats := len(stages) > 1 && strings.TrimSpace(stages[1]) != ""
+	normalized := strings.Join(strings.Fields(line), " ")
+	if prev, found := l.seen[normalized]; found {
+		report(PickitWarning, "duplicate of %s:%d", prev.Filename, prev.LineNumber)
+	} else if prev, found := l.unconditional[stage1]; found {
+		report(PickitWarning, "shadowed by %s:%d, which matches the same items without checking stats", prev.Filename, prev.LineNumber)
+	} else {
+		l.seen[normalized] = rule
+		if !hasStats && len(maxQuantities) == 0 {
+			l.unconditional[stage1] = rule
+		}
+	}
+
+	return true
+}
+
+// impossibleQuality detects rules asking for a quality the item type can't have, like magic runes or normal rings.
+// Only rules joined with && are checked, any || or negation can widen what the rule matches.
+func impossibleQuality(stage1 string, props [][]string) (string, bool) {
+	if strings.Contains(stage1, "||") || strings.Contains(stage1, "!(") {
+		return "", false
+	}
+
+	types := make([]string, 0)
+	allowed := make([]item.Quality, 0)
+	for q := item.QualityLowQuality; q <= item.QualityCrafted; q++ {
+		allowed = append(allowed, q)
+	}
+
+	for _, p := range props {
+		switch p[1] {
+		case "name":
+			if p[2] == "==" {
+				types = append(types, item.Desc[item.GetIDByName(p[3])].Type)
+			}
+		case "type":
+			if p[2] == "==" {
+				types = append(types, nip.TypeAliases[p[3]])
+			}
+		case "quality":
+			value := nipQualities[p[3]]
+			allowed = slices.DeleteFunc(allowed, func(q item.Quality) bool {
+				return !compareNIP(int(q), p[2], int(value))
+			})
+		}
+	}
+
+	for _, t := range types {
+		possible, found := typeQualities[t]
+		if !found {
+			continue
+		}
+		if !slices.ContainsFunc(possible, func(q item.Quality) bool { return slices.Contains(allowed, q) }) {
+			return fmt.Sprintf("%s items can't have the quality required by the rule, the rule will never match", item.ItemTypes[t].Name), true
+		}
+	}
+
+	return "", false
+}
+
+func compareNIP(a int, op string, b int) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+
+	return true
+}
+
+func (l *pickitLinter) report(severity, file string, line int, rule, msg string) {
+	l.problems = append(l.problems, PickitProblem{
+		Severity: severity,
+		File:     file,
+		Line:     line,
+		Rule:     strings.TrimSpace(rule),
+		Message:  msg,
+	})
+}
+
+// sanitizeNIPLine normalizes the rule the same way nip does before parsing it
+func sanitizeNIPLine(rawLine string) string {
+	line := strings.TrimSpace(strings.Split(rawLine, "//")[0])
+	line = strings.Join(strings.Fields(line), " ")
+	line = strings.ReplaceAll(line, "'", "")
+	line = strings.ReplaceAll(line, "=>", ">=")
+	line = strings.ReplaceAll(line, "=<", "<=")
+	line = strings.TrimSpace(strings.Trim(line, "&&"))
+
+	return strings.ToLower(line)
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLintPickitDir(t *testing.T) {
+	type problem struct {
+		severity string
+		line     int
+		message  string
+	}
+
+	tests := []struct {
+		name     string
+		rules    string
+		loaded   int
+		problems []problem
+	}{
+		{
+			name:   "valid rules",
+			rules:  "[name] == shako && [quality] == unique\n// comment\n\n[type] == ring && [quality] == unique # [dexterity] == 20\n",
+			loaded: 2,
+		},
+		{
+			name:     "unknown name",
+			rules:    "[name] == notanitem && [quality] == unique",
+			problems: []problem{{PickitError, 1, "unknown item name notanitem"}},
+		},
+		{
+			name:     "unknown type",
+			rules:    "[type] == notatype",
+			problems: []problem{{PickitError, 1, "unknown item type notatype"}},
+		},
+		{
+			name:     "type without items",
+			rules:    "[type] == charm && [quality] == unique",
+			loaded:   1,
+			problems: []problem{{PickitWarning, 1, "no item has type charm"}},
+		},
+		{
+			name:     "unknown quality",
+			rules:    "[type] == ring && [quality] == legendary",
+			problems: []problem{{PickitError, 1, "unknown item quality legendary"}},
+		},
+		{
+			name:     "unknown class",
+			rules:    "[type] == armor && [class] == ultra",
+			problems: []problem{{PickitError, 1, "unknown item class ultra"}},
+		},
+		{
+			name:     "unknown stat",
+			rules:    "[type] == ring && [quality] == rare # [notastat] >= 10",
+			problems: []problem{{PickitError, 1, "unknown stat notastat"}},
+		},
+		{
+			name:   "maxquantity misuse",
+			rules:  "[name] == berrune # [maxquantity] == 2\n[name] == jahrune # # [maxquantity] == 0\n[name] == ohmrune # # [maxquantity] < 3",
+			loaded: 3,
+			problems: []problem{
+				{PickitWarning, 1, "maxquantity should be in the third part"},
+				{PickitWarning, 2, "maxquantity 0 means no limit"},
+				{PickitWarning, 3, "maxquantity only supports ==, < is evaluated as =="},
+			},
+		},
+		{
+			name:   "maxquantity used right",
+			rules:  "[name] == vexrune # # [maxquantity] == 2",
+			loaded: 1,
+		},
+		{
+			name:     "duplicate rule",
+			rules:    "[type] == ring && [quality] == unique\n[type]  ==  ring && [quality] == unique // same rule",
+			loaded:   2,
+			problems: []problem{{PickitWarning, 2, "duplicate of"}},
+		},
+		{
+			name:     "shadowed rule",
+			rules:    "[type] == ring && [quality] == rare\n[type] == ring && [quality] == rare # [fcr] == 10",
+			loaded:   2,
+			problems: []problem{{PickitWarning, 2, "shadowed by"}},
+		},
+		{
+			name:   "rule with stats doesn't shadow",
+			rules:  "[type] == ring && [quality] == rare # [fcr] == 10\n[type] == ring && [quality] == rare # [dexterity] >= 10",
+			loaded: 2,
+		},
+		{
+			name:   "rule with maxquantity doesn't shadow",
+			rules:  "[name] == berrune # # [maxquantity] == 2\n[name] == berrune",
+			loaded: 2,
+		},
+		{
+			name:     "impossible quality by type",
+			rules:    "[type] == ring && [quality] == normal",
+			loaded:   1,
+			problems: []problem{{PickitWarning, 1, "can't have the quality required"}},
+		},
+		{
+			name:     "impossible quality by name",
+			rules:    "[name] == berrune && [quality] >= magic",
+			loaded:   1,
+			problems: []problem{{PickitWarning, 1, "can't have the quality required"}},
+		},
+		{
+			name:   "possible quality range",
+			rules:  "[type] == smallcharm && [quality] >= magic",
+			loaded: 1,
+		},
+		{
+			name:   "or is not checked for impossible quality",
+			rules:  "[type] == ring && ([quality] == normal || [quality] == unique)",
+			loaded: 1,
+		},
+		{
+			name:   "negation is not checked for impossible quality",
+			rules:  "[type] == ring && !([quality] <= superior)",
+			loaded: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir() + string(filepath.Separator)
+			if err := os.WriteFile(filepath.Join(dir, "rules.nip"), []byte(tc.rules), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			rules, problems := LintPickitDir(dir)
+			if len(rules) != tc.loaded {
+				t.Errorf("expected %d rules loaded, got %d", tc.loaded, len(rules))
+			}
+			if len(problems) != len(tc.problems) {
+				t.Fatalf("expected %d problems, got %v", len(tc.problems), problems)
+			}
+			for i, want := range tc.problems {
+				got := problems[i]
+				if got.Severity != want.severity || got.Line != want.line || !strings.Contains(got.Message, want.message) {
+					t.Errorf("expected %s on line %d containing %q, got %s on line %d: %s", want.severity, want.line, want.message, got.Severity, got.Line, got.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestLintPickitDirChecksDuplicatesAcrossFiles(t *testing.T) {
+	dir := t.TempDir() + string(filepath.Separator)
+	files := map[string]string{
+		"a.nip":     "[name] == vexrune",
+		"b.nip":     "[name] == vexrune # [fcr] >= 10",
+		"other.txt": "[name] == notanitem",
+	}
+	for name, rules := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(rules), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rules, problems := LintPickitDir(dir)
+	if len(rules) != 2 {
+		t.Errorf("expected the rules of the nip files, got %d", len(rules))
+	}
+	if len(problems) != 1 || problems[0].File != dir+"b.nip" || !strings.Contains(problems[0].Message, "shadowed by "+dir+"a.nip:1") {
+		t.Errorf("expected b.nip shadowed by a.nip, got %v", problems)
+	}
+}
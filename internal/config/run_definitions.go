@@ -0,0 +1,230 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"gopkg.in/yaml.v3"
+)
+
+//go:generate go run run_names_gen.go
+
+const runDefinitionsDir = "config/runs"
+
+// RunDefinitions are the runs declared in config/runs/*.yaml, they are available as any other run
+var RunDefinitions = make(map[Run]RunDefinition)
+
+// RunDefinitionErrors are the errors of the run definitions that could not be loaded, they are skipped so the rest of
+// the config can still be used
+var RunDefinitionErrors []error
+
+// RunDefinition is a run made of a sequence of primitive steps, executed in order
+type RunDefinition struct {
+	Name        Run       `yaml:"name"`
+	Description string    `yaml:"description"`
+	Steps       []RunStep `yaml:"steps"`
+}
+
+// RunStep holds a single primitive, only one of the fields can be set
+type RunStep struct {
+	Waypoint     *RunArea         `yaml:"waypoint"`
+	MoveToArea   *RunArea         `yaml:"moveToArea"`
+	TravelTo     *RunArea         `yaml:"travelTo"`
+	MoveToObject *RunObject       `yaml:"moveToObject"`
+	MoveToNPC    *RunNPC          `yaml:"moveToNPC"`
+	MoveToCoords *data.Position   `yaml:"moveToCoords"`
+	ClearArea    *ClearAreaStep   `yaml:"clearArea"`
+	KillUnique   *KillUniqueStep  `yaml:"killUnique"`
+	OpenChests   *OpenChestsStep  `yaml:"openChests"`
+	PickupItems  *PickupItemsStep `yaml:"pickupItems"`
+}
+
+type ClearAreaStep struct {
+	// Radius around the player, 0 clears the whole level
+	Radius     int  `yaml:"radius"`
+	OnlyElites bool `yaml:"onlyElites"`
+	OpenChests bool `yaml:"openChests"`
+}
+
+type KillUniqueStep struct {
+	NPC RunNPC `yaml:"npc"`
+	// Unique, SuperUnique or Champion, SuperUnique by default
+	Type             data.MonsterType `yaml:"type"`
+	SkipOnImmunities []stat.Resist    `yaml:"skipOnImmunities"`
+}
+
+type OpenChestsStep struct {
+	Radius int `yaml:"radius"`
+}
+
+type PickupItemsStep struct {
+	Radius int `yaml:"radius"`
+}
+
+// RunArea is an area that can be written in the run definition by ID or by name, like "Frozen River"
+type RunArea area.ID
+
+func (a *RunArea) UnmarshalYAML(value *yaml.Node) error {
+	if id, err := strconv.Atoi(value.Value); err == nil {
+		*a = RunArea(id)
+		return nil
+	}
+
+	for id, ar := range area.Areas {
+		if strings.EqualFold(ar.Name, value.Value) {
+			*a = RunArea(id)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown area: %s", value.Value)
+}
+
+func (a RunArea) ID() area.ID {
+	return area.ID(a)
+}
+
+// RunObject is an object that can be written in the run definition by ID or by name, like "HellForge"
+type RunObject object.Name
+
+func (o *RunObject) UnmarshalYAML(value *yaml.Node) error {
+	id, err := unmarshalRunName(value, objectNames)
+	if err != nil {
+		return fmt.Errorf("unknown object: %s", value.Value)
+	}
+	*o = RunObject(id)
+
+	return nil
+}
+
+func (o RunObject) Name() object.Name {
+	return object.Name(o)
+}
+
+// RunNPC is a NPC or monster that can be written in the run definition by ID or by name, like "Hephasto"
+type RunNPC npc.ID
+
+func (n *RunNPC) UnmarshalYAML(value *yaml.Node) error {
+	id, err := unmarshalRunName(value, npcNames)
+	if err != nil {
+		return fmt.Errorf("unknown npc: %s", value.Value)
+	}
+	*n = RunNPC(id)
+
+	return nil
+}
+
+func (n RunNPC) ID() npc.ID {
+	return npc.ID(n)
+}
+
+// unmarshalRunName returns the ID written in the node or the one matching the name, case, spaces and underscores
+// are ignored so "Hell Forge" matches HellForge
+func unmarshalRunName[T ~int](value *yaml.Node, names map[string]T) (T, error) {
+	if id, err := strconv.Atoi(value.Value); err == nil {
+		return T(id), nil
+	}
+
+	name := strings.ToLower(strings.NewReplacer(" ", "", "_", "").Replace(value.Value))
+	if id, found := names[name]; found {
+		return id, nil
+	}
+
+	return 0, fmt.Errorf("unknown name: %s", value.Value)
+}
+
+// primitives returns the number of primitives set in the step
+func (s RunStep) primitives() int {
+	set := 0
+	for _, isSet := range []bool{
+		s.Waypoint != nil, s.MoveToArea != nil, s.TravelTo != nil, s.MoveToObject != nil, s.MoveToNPC != nil,
+		s.MoveToCoords != nil, s.ClearArea != nil, s.KillUnique != nil, s.OpenChests != nil, s.PickupItems != nil,
+	} {
+		if isSet {
+			set++
+		}
+	}
+
+	return set
+}
+
+func (d RunDefinition) validate() error {
+	if d.Name == "" {
+		return errors.New("name is required")
+	}
+	if _, found := AvailableRuns[d.Name]; found {
+		if _, defined := RunDefinitions[d.Name]; !defined {
+			return fmt.Errorf("%s is a built-in run", d.Name)
+		}
+	}
+	if len(d.Steps) == 0 {
+		return errors.New("at least one step is required")
+	}
+
+	for i, s := range d.Steps {
+		if s.primitives() != 1 {
+			return fmt.Errorf("step %d: exactly one primitive is required, found %d", i+1, s.primitives())
+		}
+		if s.KillUnique != nil && s.KillUnique.NPC == 0 {
+			return fmt.Errorf("step %d: npc is required to kill a unique", i+1)
+		}
+	}
+
+	return nil
+}
+
+// loadRunDefinitions reads all the run definitions, replacing the ones loaded before. Broken definitions are skipped
+// and kept in RunDefinitionErrors.
+func loadRunDefinitions() error {
+	for name := range RunDefinitions {
+		delete(AvailableRuns, name)
+	}
+	RunDefinitions = make(map[Run]RunDefinition)
+	RunDefinitionErrors = nil
+
+	files, err := filepath.Glob(filepath.Join(runDefinitionsDir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		def, err := readRunDefinition(file)
+		if err != nil {
+			RunDefinitionErrors = append(RunDefinitionErrors, fmt.Errorf("error reading run definition %s: %w", file, err))
+			continue
+		}
+		if _, found := RunDefinitions[def.Name]; found {
+			RunDefinitionErrors = append(RunDefinitionErrors, fmt.Errorf("error reading run definition %s: run %s is already defined", file, def.Name))
+			continue
+		}
+
+		RunDefinitions[def.Name] = def
+		AvailableRuns[def.Name] = nil
+	}
+
+	return nil
+}
+
+func readRunDefinition(file string) (RunDefinition, error) {
+	r, err := os.Open(file)
+	if err != nil {
+		return RunDefinition{}, err
+	}
+	defer r.Close()
+
+	def := RunDefinition{}
+	if err = yaml.NewDecoder(r).Decode(&def); err != nil {
+		return RunDefinition{}, err
+	}
+
+	return def, def.validate()
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRunStepNames(t *testing.T) {
+	var steps []RunStep
+	err := yaml.Unmarshal([]byte(`
+- waypoint: River of Flame
+- moveToObject: Hell Forge
+- moveToNPC: 254
+- killUnique:
+    npc: hephasto
+`), &steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if steps[0].Waypoint.ID() != area.RiverOfFlame {
+		t.Errorf("expected River of Flame, got %d", steps[0].Waypoint.ID())
+	}
+	if steps[1].MoveToObject.Name() != object.HellForge {
+		t.Errorf("expected Hell Forge, got %d", steps[1].MoveToObject.Name())
+	}
+	if steps[2].MoveToNPC.ID() != npc.ID(254) {
+		t.Errorf("expected NPC 254, got %d", steps[2].MoveToNPC.ID())
+	}
+	if steps[3].KillUnique.NPC.ID() != npc.Hephasto {
+		t.Errorf("expected Hephasto, got %d", steps[3].KillUnique.NPC.ID())
+	}
+
+	if err = yaml.Unmarshal([]byte(`moveToNPC: Nobody`), &RunStep{}); err == nil {
+		t.Error("expected an error for an unknown NPC")
+	}
+}
+
+func TestLoadRunDefinitionsSkipsBrokenFiles(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err = os.MkdirAll(filepath.Join(dir, runDefinitionsDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	files := map[string]string{
+		"valid.yaml":   "name: valid\nsteps:\n  - waypoint: 107\n",
+		"broken.yaml":  "name: broken\nsteps: [\n",
+		"unknown.yaml": "name: unknown\nsteps:\n  - moveToObject: Nothing\n",
+	}
+	for name, content := range files {
+		if err = os.WriteFile(filepath.Join(runDefinitionsDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = loadRunDefinitions(); err != nil {
+		t.Fatalf("broken definitions should not fail loading, got %v", err)
+	}
+	if _, found := RunDefinitions["valid"]; !found || len(RunDefinitions) != 1 {
+		t.Errorf("expected only the valid definition, got %v", RunDefinitions)
+	}
+	if len(RunDefinitionErrors) != 2 {
+		t.Errorf("expected 2 errors, got %v", RunDefinitionErrors)
+	}
+}
@@ -0,0 +1,1320 @@
+// Code generated by run_names_gen.go; DO NOT EDIT.
+
+package config
+
+import (
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+)
+
+var npcNames = map[string]npc.ID{
+	"skeleton":                 npc.Skeleton,
+	"returned":                 npc.Returned,
+	"bonewarrior":              npc.BoneWarrior,
+	"burningdead":              npc.BurningDead,
+	"horror":                   npc.Horror,
+	"zombie":                   npc.Zombie,
+	"hungrydead":               npc.HungryDead,
+	"ghoul":                    npc.Ghoul,
+	"drownedcarcass":           npc.DrownedCarcass,
+	"plaguebearer":             npc.PlagueBearer,
+	"afflicted":                npc.Afflicted,
+	"tainted":                  npc.Tainted,
+	"misshapen":                npc.Misshapen,
+	"disfigured":               npc.Disfigured,
+	"damned":                   npc.Damned,
+	"foulcrow":                 npc.FoulCrow,
+	"bloodhawk":                npc.BloodHawk,
+	"blackraptor":              npc.BlackRaptor,
+	"cloudstalker":             npc.CloudStalker,
+	"fallen":                   npc.Fallen,
+	"carver":                   npc.Carver,
+	"devilkin":                 npc.Devilkin,
+	"darkone":                  npc.DarkOne,
+	"warpedfallen":             npc.WarpedFallen,
+	"brute":                    npc.Brute,
+	"yeti":                     npc.Yeti,
+	"crusher":                  npc.Crusher,
+	"wailingbeast":             npc.WailingBeast,
+	"gargantuanbeast":          npc.GargantuanBeast,
+	"sandraer":                 npc.SandRaer,
+	"marauder":                 npc.Marauder,
+	"invader":                  npc.Invader,
+	"infel":                    npc.Infel,
+	"assailant":                npc.Assailant,
+	"gorgon":                   npc.Gorgon,
+	"gorgon2":                  npc.Gorgon2,
+	"gorgon3":                  npc.Gorgon3,
+	"gorgon4":                  npc.Gorgon4,
+	"ghost":                    npc.Ghost,
+	"wraith":                   npc.Wraith,
+	"specter":                  npc.Specter,
+	"apparition":               npc.Apparition,
+	"darkshape":                npc.DarkShape,
+	"darkhunter":               npc.DarkHunter,
+	"vilehunter":               npc.VileHunter,
+	"darkstalker":              npc.DarkStalker,
+	"blackrogue":               npc.BlackRogue,
+	"fleshhunter":              npc.FleshHunter,
+	"dunebeast":                npc.DuneBeast,
+	"rockdweller":              npc.RockDweller,
+	"junglehunter":             npc.JungleHunter,
+	"doomape":                  npc.DoomApe,
+	"templeguard":              npc.TempleGuard,
+	"moonclan":                 npc.MoonClan,
+	"nightclan":                npc.NightClan,
+	"bloodclan":                npc.BloodClan,
+	"hellclan":                 npc.HellClan,
+	"deathclan":                npc.DeathClan,
+	"fallenshaman":             npc.FallenShaman,
+	"carvershaman":             npc.CarverShaman,
+	"devilkinshaman":           npc.DevilkinShaman,
+	"darkshaman":               npc.DarkShaman,
+	"warpedshaman":             npc.WarpedShaman,
+	"quillrat":                 npc.QuillRat,
+	"spikefiend":               npc.SpikeFiend,
+	"thornbeast":               npc.ThornBeast,
+	"razorspine":               npc.RazorSpine,
+	"jungleurchin":             npc.JungleUrchin,
+	"sandmaggot":               npc.SandMaggot,
+	"rockworm":                 npc.RockWorm,
+	"devourer":                 npc.Devourer,
+	"giantlamprey":             npc.GiantLamprey,
+	"worldkiller":              npc.WorldKiller,
+	"tombviper":                npc.TombViper,
+	"clawviper":                npc.ClawViper,
+	"salamander":               npc.Salamander,
+	"pitviper":                 npc.PitViper,
+	"serpentmagus":             npc.SerpentMagus,
+	"sandleaper":               npc.SandLeaper,
+	"caveleaper":               npc.CaveLeaper,
+	"tombcreeper":              npc.TombCreeper,
+	"treelurker":               npc.TreeLurker,
+	"razorpitdemon":            npc.RazorPitDemon,
+	"huntress":                 npc.Huntress,
+	"sabercat":                 npc.SaberCat,
+	"nighttiger":               npc.NightTiger,
+	"hellcat":                  npc.HellCat,
+	"itchies":                  npc.Itchies,
+	"blacklocusts":             npc.BlackLocusts,
+	"plaguebugs":               npc.PlagueBugs,
+	"hellswarm":                npc.HellSwarm,
+	"dungsoldier":              npc.DungSoldier,
+	"sandwarrior":              npc.SandWarrior,
+	"scarab":                   npc.Scarab,
+	"steelweevil":              npc.SteelWeevil,
+	"albinoroach":              npc.AlbinoRoach,
+	"driedcorpse":              npc.DriedCorpse,
+	"decayed":                  npc.Decayed,
+	"embalmed":                 npc.Embalmed,
+	"preserveddead":            npc.PreservedDead,
+	"cadaver":                  npc.Cadaver,
+	"hollowone":                npc.HollowOne,
+	"guardian":                 npc.Guardian,
+	"unraveler":                npc.Unraveler,
+	"horadrimancient":          npc.HoradrimAncient,
+	"baalsubjectmummy":         npc.BaalSubjectMummy,
+	"chaoshorde":               npc.ChaosHorde,
+	"chaoshorde2":              npc.ChaosHorde2,
+	"chaoshorde3":              npc.ChaosHorde3,
+	"chaoshorde4":              npc.ChaosHorde4,
+	"carrionbird":              npc.CarrionBird,
+	"undeadscavenger":          npc.UndeadScavenger,
+	"hellbuzzard":              npc.HellBuzzard,
+	"wingednightmare":          npc.WingedNightmare,
+	"sucker":                   npc.Sucker,
+	"feeder":                   npc.Feeder,
+	"bloodhook":                npc.BloodHook,
+	"bloodwing":                npc.BloodWing,
+	"gloam":                    npc.Gloam,
+	"swampghost":               npc.SwampGhost,
+	"burningsoul":              npc.BurningSoul,
+	"blacksoul":                npc.BlackSoul,
+	"arach":                    npc.Arach,
+	"sandfisher":               npc.SandFisher,
+	"poisonspinner":            npc.PoisonSpinner,
+	"flamesper":                npc.FlameSper,
+	"spermagus":                npc.SperMagus,
+	"thornedhulk":              npc.ThornedHulk,
+	"bramblehulk":              npc.BrambleHulk,
+	"thrasher":                 npc.Thrasher,
+	"spikefist":                npc.Spikefist,
+	"ghoullord":                npc.GhoulLord,
+	"nightlord":                npc.NightLord,
+	"darklord":                 npc.DarkLord,
+	"bloodlord":                npc.BloodLord,
+	"banished":                 npc.Banished,
+	"desertwing":               npc.DesertWing,
+	"fiend":                    npc.Fiend,
+	"gloombat":                 npc.Gloombat,
+	"blooddiver":               npc.BloodDiver,
+	"darkfamiliar":             npc.DarkFamiliar,
+	"ratman":                   npc.RatMan,
+	"fetish":                   npc.Fetish,
+	"flayer":                   npc.Flayer,
+	"soulkiller":               npc.SoulKiller,
+	"stygiandoll":              npc.StygianDoll,
+	"deckardcain":              npc.DeckardCain,
+	"gheed":                    npc.Gheed,
+	"akara":                    npc.Akara,
+	"chicken":                  npc.Chicken,
+	"kashya":                   npc.Kashya,
+	"rat":                      npc.Rat,
+	"rogue":                    npc.Rogue,
+	"hellmeteor":               npc.HellMeteor,
+	"charsi":                   npc.Charsi,
+	"warriv":                   npc.Warriv,
+	"andariel":                 npc.Andariel,
+	"bird":                     npc.Bird,
+	"bird2":                    npc.Bird2,
+	"bat":                      npc.Bat,
+	"darkranger":               npc.DarkRanger,
+	"vilearcher":               npc.VileArcher,
+	"darkarcher":               npc.DarkArcher,
+	"blackarcher":              npc.BlackArcher,
+	"flesharcher":              npc.FleshArcher,
+	"darkspearwoman":           npc.DarkSpearwoman,
+	"vilelancer":               npc.VileLancer,
+	"darklancer":               npc.DarkLancer,
+	"blacklancer":              npc.BlackLancer,
+	"fleshlancer":              npc.FleshLancer,
+	"skeletonarcher":           npc.SkeletonArcher,
+	"returnedarcher":           npc.ReturnedArcher,
+	"bonearcher":               npc.BoneArcher,
+	"burningdeadarcher":        npc.BurningDeadArcher,
+	"horrorarcher":             npc.HorrorArcher,
+	"warriv2":                  npc.Warriv2,
+	"atma":                     npc.Atma,
+	"drognan":                  npc.Drognan,
+	"fara":                     npc.Fara,
+	"cow":                      npc.Cow,
+	"sandmaggotyoung":          npc.SandMaggotYoung,
+	"rockwormyoung":            npc.RockWormYoung,
+	"devoureryoung":            npc.DevourerYoung,
+	"giantlampreyyoung":        npc.GiantLampreyYoung,
+	"worldkilleryoung":         npc.WorldKillerYoung,
+	"camel":                    npc.Camel,
+	"blunderbore":              npc.Blunderbore,
+	"gorbelly":                 npc.Gorbelly,
+	"mauler":                   npc.Mauler,
+	"urdar":                    npc.Urdar,
+	"sandmaggotegg":            npc.SandMaggotEgg,
+	"rockwormegg":              npc.RockWormEgg,
+	"devoureregg":              npc.DevourerEgg,
+	"giantlampreyegg":          npc.GiantLampreyEgg,
+	"worldkilleregg":           npc.WorldKillerEgg,
+	"act2male":                 npc.Act2Male,
+	"act2female":               npc.Act2Female,
+	"act2child":                npc.Act2Child,
+	"greiz":                    npc.Greiz,
+	"elzix":                    npc.Elzix,
+	"geglash":                  npc.Geglash,
+	"jerhyn":                   npc.Jerhyn,
+	"lysander":                 npc.Lysander,
+	"act2guard":                npc.Act2Guard,
+	"act2vendor":               npc.Act2Vendor,
+	"act2vendor2":              npc.Act2Vendor2,
+	"foulcrownest":             npc.FoulCrowNest,
+	"bloodhawknest":            npc.BloodHawkNest,
+	"blackvulturenest":         npc.BlackVultureNest,
+	"cloudstalkernest":         npc.CloudStalkerNest,
+	"meshif":                   npc.Meshif,
+	"duriel":                   npc.Duriel,
+	"undeadratman":             npc.UndeadRatMan,
+	"undeadfetish":             npc.UndeadFetish,
+	"undeadflayer":             npc.UndeadFlayer,
+	"undeadsoulkiller":         npc.UndeadSoulKiller,
+	"undeadstygiandoll":        npc.UndeadStygianDoll,
+	"darkguard":                npc.DarkGuard,
+	"darkguard2":               npc.DarkGuard2,
+	"darkguard3":               npc.DarkGuard3,
+	"darkguard4":               npc.DarkGuard4,
+	"darkguard5":               npc.DarkGuard5,
+	"bloodmage":                npc.BloodMage,
+	"bloodmage2":               npc.BloodMage2,
+	"bloodmage3":               npc.BloodMage3,
+	"bloodmage4":               npc.BloodMage4,
+	"bloodmage5":               npc.BloodMage5,
+	"maggot":                   npc.Maggot,
+	"mummygenerator":           npc.MummyGenerator,
+	"radament":                 npc.Radament,
+	"firebeast":                npc.FireBeast,
+	"iceglobe":                 npc.IceGlobe,
+	"lightningbeast":           npc.LightningBeast,
+	"poisonorb":                npc.PoisonOrb,
+	"flyingscimitar":           npc.FlyingScimitar,
+	"zakarumite":               npc.Zakarumite,
+	"faithful":                 npc.Faithful,
+	"zealot":                   npc.Zealot,
+	"sexton":                   npc.Sexton,
+	"cantor":                   npc.Cantor,
+	"heirophant":               npc.Heirophant,
+	"heirophant2":              npc.Heirophant2,
+	"mephisto":                 npc.Mephisto,
+	"diablo":                   npc.Diablo,
+	"deckardcain2":             npc.DeckardCain2,
+	"deckardcain3":             npc.DeckardCain3,
+	"deckardcain4":             npc.DeckardCain4,
+	"swampdweller":             npc.SwampDweller,
+	"bogcreature":              npc.BogCreature,
+	"slimeprince":              npc.SlimePrince,
+	"summoner":                 npc.Summoner,
+	"tyrael":                   npc.Tyrael,
+	"asheara":                  npc.Asheara,
+	"hratli":                   npc.Hratli,
+	"alkor":                    npc.Alkor,
+	"ormus":                    npc.Ormus,
+	"izual":                    npc.Izual,
+	"halbu":                    npc.Halbu,
+	"waterwatcherlimb":         npc.WaterWatcherLimb,
+	"riverstalkerlimb":         npc.RiverStalkerLimb,
+	"stygianwatcherlimb":       npc.StygianWatcherLimb,
+	"waterwatcherhead":         npc.WaterWatcherHead,
+	"riverstalkerhead":         npc.RiverStalkerHead,
+	"stygianwatcherhead":       npc.StygianWatcherHead,
+	"meshif2":                  npc.Meshif2,
+	"deckardcain5":             npc.DeckardCain5,
+	"navi":                     npc.Navi,
+	"bloodraven":               npc.BloodRaven,
+	"bug":                      npc.Bug,
+	"scorpion":                 npc.Scorpion,
+	"roguescout":               npc.RogueScout,
+	"rogue2":                   npc.Rogue2,
+	"rogue3":                   npc.Rogue3,
+	"gargoyletrap":             npc.GargoyleTrap,
+	"returnedmage":             npc.ReturnedMage,
+	"bonemage":                 npc.BoneMage,
+	"burningdeadmage":          npc.BurningDeadMage,
+	"horrormage":               npc.HorrorMage,
+	"ratmanshaman":             npc.RatManShaman,
+	"fetishshaman":             npc.FetishShaman,
+	"flayershaman":             npc.FlayerShaman,
+	"soulkillershaman":         npc.SoulKillerShaman,
+	"stygiandollshaman":        npc.StygianDollShaman,
+	"larva":                    npc.Larva,
+	"sandmaggotqueen":          npc.SandMaggotQueen,
+	"rockwormqueen":            npc.RockWormQueen,
+	"devourerqueen":            npc.DevourerQueen,
+	"giantlampreyqueen":        npc.GiantLampreyQueen,
+	"worldkillerqueen":         npc.WorldKillerQueen,
+	"claygolem":                npc.ClayGolem,
+	"bloodgolem":               npc.BloodGolem,
+	"irongolem":                npc.IronGolem,
+	"firegolem":                npc.FireGolem,
+	"familiar":                 npc.Familiar,
+	"act3male":                 npc.Act3Male,
+	"nightmarauder":            npc.NightMarauder,
+	"act3female":               npc.Act3Female,
+	"natalya":                  npc.Natalya,
+	"fleshspawner":             npc.FleshSpawner,
+	"stygianhag":               npc.StygianHag,
+	"grotesque":                npc.Grotesque,
+	"fleshbeast":               npc.FleshBeast,
+	"stygiandog":               npc.StygianDog,
+	"grotesquewyrm":            npc.GrotesqueWyrm,
+	"groper":                   npc.Groper,
+	"strangler":                npc.Strangler,
+	"stormcaster":              npc.StormCaster,
+	"corpulent":                npc.Corpulent,
+	"corpsespitter":            npc.CorpseSpitter,
+	"mawfiend":                 npc.MawFiend,
+	"doomknight":               npc.DoomKnight,
+	"abyssknight":              npc.AbyssKnight,
+	"oblivionknight":           npc.OblivionKnight,
+	"quillbear":                npc.QuillBear,
+	"spikegiant":               npc.SpikeGiant,
+	"thornbrute":               npc.ThornBrute,
+	"razorbeast":               npc.RazorBeast,
+	"gianturchin":              npc.GiantUrchin,
+	"snake":                    npc.Snake,
+	"parrot":                   npc.Parrot,
+	"fish":                     npc.Fish,
+	"evilhole":                 npc.EvilHole,
+	"evilhole2":                npc.EvilHole2,
+	"evilhole3":                npc.EvilHole3,
+	"evilhole4":                npc.EvilHole4,
+	"evilhole5":                npc.EvilHole5,
+	"firebolttrap":             npc.FireboltTrap,
+	"horzmissiletrap":          npc.HorzMissileTrap,
+	"vertmissiletrap":          npc.VertMissileTrap,
+	"poisoncloudtrap":          npc.PoisonCloudTrap,
+	"lightningtrap":            npc.LightningTrap,
+	"kaelan":                   npc.Kaelan,
+	"invisospawner":            npc.InvisoSpawner,
+	"diabloclone":              npc.DiabloClone,
+	"suckernest":               npc.SuckerNest,
+	"feedernest":               npc.FeederNest,
+	"bloodhooknest":            npc.BloodHookNest,
+	"bloodwingnest":            npc.BloodWingNest,
+	"guard":                    npc.Guard,
+	"minisper":                 npc.MiniSper,
+	"boneprison":               npc.BonePrison,
+	"boneprison2":              npc.BonePrison2,
+	"boneprison3":              npc.BonePrison3,
+	"boneprison4":              npc.BonePrison4,
+	"bonewall":                 npc.BoneWall,
+	"councilmember":            npc.CouncilMember,
+	"councilmember2":           npc.CouncilMember2,
+	"councilmember3":           npc.CouncilMember3,
+	"turret":                   npc.Turret,
+	"turret2":                  npc.Turret2,
+	"turret3":                  npc.Turret3,
+	"hydra":                    npc.Hydra,
+	"hydra2":                   npc.Hydra2,
+	"hydra3":                   npc.Hydra3,
+	"meleetrap":                npc.MeleeTrap,
+	"seventombs":               npc.SevenTombs,
+	"decoy":                    npc.Decoy,
+	"valkyrie":                 npc.Valkyrie,
+	"act2guard3":               npc.Act2Guard3,
+	"ironwolf":                 npc.IronWolf,
+	"balrog":                   npc.Balrog,
+	"pitlord":                  npc.PitLord,
+	"venomlord":                npc.VenomLord,
+	"necroskeleton":            npc.NecroSkeleton,
+	"necromage":                npc.NecroMage,
+	"griswold":                 npc.Griswold,
+	"compellingorbnpc":         npc.CompellingOrbNpc,
+	"tyrael2":                  npc.Tyrael2,
+	"darkwanderer":             npc.DarkWanderer,
+	"novatrap":                 npc.NovaTrap,
+	"spiritmummy":              npc.SpiritMummy,
+	"lightningspire":           npc.LightningSpire,
+	"firetower":                npc.FireTower,
+	"slinger":                  npc.Slinger,
+	"spearcat":                 npc.SpearCat,
+	"nightslinger":             npc.NightSlinger,
+	"hellslinger":              npc.HellSlinger,
+	"act2guard4":               npc.Act2Guard4,
+	"act2guard5":               npc.Act2Guard5,
+	"returnedmage2":            npc.ReturnedMage2,
+	"bonemage2":                npc.BoneMage2,
+	"baalcoldmage":             npc.BaalColdMage,
+	"horrormage2":              npc.HorrorMage2,
+	"returnedmage3":            npc.ReturnedMage3,
+	"bonemage3":                npc.BoneMage3,
+	"burningdeadmage2":         npc.BurningDeadMage2,
+	"horrormage3":              npc.HorrorMage3,
+	"returnedmage4":            npc.ReturnedMage4,
+	"bonemage4":                npc.BoneMage4,
+	"burningdeadmage3":         npc.BurningDeadMage3,
+	"horrormage4":              npc.HorrorMage4,
+	"hellbovine":               npc.HellBovine,
+	"window":                   npc.Window,
+	"window2":                  npc.Window2,
+	"spearcat2":                npc.SpearCat2,
+	"nightslinger2":            npc.NightSlinger2,
+	"ratman2":                  npc.RatMan2,
+	"fetish2":                  npc.Fetish2,
+	"flayer2":                  npc.Flayer2,
+	"soulkiller2":              npc.SoulKiller2,
+	"stygiandoll2":             npc.StygianDoll2,
+	"mephistospirit":           npc.MephistoSpirit,
+	"thesmith":                 npc.TheSmith,
+	"trappedsoul":              npc.TrappedSoul,
+	"trappedsoul2":             npc.TrappedSoul2,
+	"jamella":                  npc.Jamella,
+	"izual2":                   npc.Izual2,
+	"ratman3":                  npc.RatMan3,
+	"malachai":                 npc.Malachai,
+	"hephasto":                 npc.Hephasto,
+	"wakeofdestruction":        npc.WakeOfDestruction,
+	"chargedboltsentry":        npc.ChargedBoltSentry,
+	"lightningsentry":          npc.LightningSentry,
+	"bladecreeper":             npc.BladeCreeper,
+	"invisiblepet":             npc.InvisiblePet,
+	"infernosentry":            npc.InfernoSentry,
+	"deathsentry":              npc.DeathSentry,
+	"shadowwarrior":            npc.ShadowWarrior,
+	"shadowmaster":             npc.ShadowMaster,
+	"druhawk":                  npc.DruHawk,
+	"druspiritwolf":            npc.DruSpiritWolf,
+	"drufenris":                npc.DruFenris,
+	"spiritofbarbs":            npc.SpiritOfBarbs,
+	"heartofwolverine":         npc.HeartOfWolverine,
+	"oaksage":                  npc.OakSage,
+	"druplaguepoppy":           npc.DruPlaguePoppy,
+	"drucycleoflife":           npc.DruCycleOfLife,
+	"vinecreature":             npc.VineCreature,
+	"drubear":                  npc.DruBear,
+	"eagle":                    npc.Eagle,
+	"wolf":                     npc.Wolf,
+	"bear":                     npc.Bear,
+	"barricadedoor":            npc.BarricadeDoor,
+	"barricadedoor2":           npc.BarricadeDoor2,
+	"prisondoor":               npc.PrisonDoor,
+	"barricadetower":           npc.BarricadeTower,
+	"rotwalker":                npc.RotWalker,
+	"reanimatedhorde":          npc.ReanimatedHorde,
+	"prowlingdead":             npc.ProwlingDead,
+	"unholycorpse":             npc.UnholyCorpse,
+	"defiledwarrior":           npc.DefiledWarrior,
+	"siegebeast":               npc.SiegeBeast,
+	"crushbiest":               npc.CrushBiest,
+	"bloodbringer":             npc.BloodBringer,
+	"gorebearer":               npc.GoreBearer,
+	"deamonsteed":              npc.DeamonSteed,
+	"snowyeti":                 npc.SnowYeti,
+	"snowyeti2":                npc.SnowYeti2,
+	"snowyeti3":                npc.SnowYeti3,
+	"snowyeti4":                npc.SnowYeti4,
+	"wolfrer":                  npc.WolfRer,
+	"wolfrer2":                 npc.WolfRer2,
+	"wolfrer3":                 npc.WolfRer3,
+	"minionexp":                npc.MinionExp,
+	"slayerexp":                npc.SlayerExp,
+	"iceboar":                  npc.IceBoar,
+	"fireboar":                 npc.FireBoar,
+	"hellspawn":                npc.HellSpawn,
+	"icespawn":                 npc.IceSpawn,
+	"greaterhellspawn":         npc.GreaterHellSpawn,
+	"greatericespawn":          npc.GreaterIceSpawn,
+	"fanaticminion":            npc.FanaticMinion,
+	"berserkslayer":            npc.BerserkSlayer,
+	"consumediceboar":          npc.ConsumedIceBoar,
+	"consumedfireboar":         npc.ConsumedFireBoar,
+	"frenziedhellspawn":        npc.FrenziedHellSpawn,
+	"frenziedicespawn":         npc.FrenziedIceSpawn,
+	"insanehellspawn":          npc.InsaneHellSpawn,
+	"insaneicespawn":           npc.InsaneIceSpawn,
+	"succubusexp":              npc.SuccubusExp,
+	"viletemptress":            npc.VileTemptress,
+	"stygianharlot":            npc.StygianHarlot,
+	"helltemptress":            npc.HellTemptress,
+	"bloodtemptress":           npc.BloodTemptress,
+	"dominus":                  npc.Dominus,
+	"vilewitch":                npc.VileWitch,
+	"stygianfury":              npc.StygianFury,
+	"bloodwitch":               npc.BloodWitch,
+	"hellwitch":                npc.HellWitch,
+	"overseer":                 npc.OverSeer,
+	"lasher":                   npc.Lasher,
+	"overlord":                 npc.OverLord,
+	"bloodboss":                npc.BloodBoss,
+	"hellwhip":                 npc.HellWhip,
+	"minionspawner":            npc.MinionSpawner,
+	"minionslayerspawner":      npc.MinionSlayerSpawner,
+	"minionboarspawner":        npc.MinionBoarSpawner,
+	"minionboarspawner2":       npc.MinionBoarSpawner2,
+	"minionspawnspawner":       npc.MinionSpawnSpawner,
+	"minionboarspawner3":       npc.MinionBoarSpawner3,
+	"minionboarspawner4":       npc.MinionBoarSpawner4,
+	"minionspawnspawner2":      npc.MinionSpawnSpawner2,
+	"imp":                      npc.Imp,
+	"imp2":                     npc.Imp2,
+	"imp3":                     npc.Imp3,
+	"imp4":                     npc.Imp4,
+	"imp5":                     npc.Imp5,
+	"catapults":                npc.CatapultS,
+	"catapulte":                npc.CatapultE,
+	"catapultsiege":            npc.CatapultSiege,
+	"catapultw":                npc.CatapultW,
+	"frozenhorror":             npc.FrozenHorror,
+	"frozenhorror2":            npc.FrozenHorror2,
+	"frozenhorror3":            npc.FrozenHorror3,
+	"frozenhorror4":            npc.FrozenHorror4,
+	"frozenhorror5":            npc.FrozenHorror5,
+	"bloodlord2":               npc.BloodLord2,
+	"bloodlord3":               npc.BloodLord3,
+	"bloodlord4":               npc.BloodLord4,
+	"bloodlord5":               npc.BloodLord5,
+	"bloodlord6":               npc.BloodLord6,
+	"larzuk":                   npc.Larzuk,
+	"drehya":                   npc.Drehya,
+	"malah":                    npc.Malah,
+	"nihlathaktown":            npc.NihlathakTown,
+	"qualkehk":                 npc.QualKehk,
+	"catapultspotters":         npc.CatapultSpotterS,
+	"catapultspottere":         npc.CatapultSpotterE,
+	"catapultspottersiegename": npc.CatapultSpotterSiegeName,
+	"catapultspotterw":         npc.CatapultSpotterW,
+	"deckardcain6":             npc.DeckardCain6,
+	"tyrael3":                  npc.Tyrael3,
+	"act5combatant":            npc.Act5Combatant,
+	"act5combatant2":           npc.Act5Combatant2,
+	"barricadewallright":       npc.BarricadeWallRight,
+	"barricadewallleft":        npc.BarricadeWallLeft,
+	"nihlathak":                npc.Nihlathak,
+	"drehya2":                  npc.Drehya2,
+	"evilhut":                  npc.EvilHut,
+	"deathmauler":              npc.DeathMauler,
+	"deathmauler2":             npc.DeathMauler2,
+	"deathmauler3":             npc.DeathMauler3,
+	"deathmauler4":             npc.DeathMauler4,
+	"deathmauler5":             npc.DeathMauler5,
+	"pow":                      npc.POW,
+	"act5townguard":            npc.Act5Townguard,
+	"act5townguard2":           npc.Act5Townguard2,
+	"ancientstatue":            npc.AncientStatue,
+	"ancientstatuenpc2":        npc.AncientStatueNpc2,
+	"ancientstatuenpc3":        npc.AncientStatueNpc3,
+	"ancientbarbarian":         npc.AncientBarbarian,
+	"ancientbarbarian2":        npc.AncientBarbarian2,
+	"ancientbarbarian3":        npc.AncientBarbarian3,
+	"baalthrone":               npc.BaalThrone,
+	"baalcrab":                 npc.BaalCrab,
+	"baaltaunt":                npc.BaalTaunt,
+	"putrdefiler":              npc.PutrDefiler,
+	"putrdefiler2":             npc.PutrDefiler2,
+	"putrdefiler3":             npc.PutrDefiler3,
+	"putrdefiler4":             npc.PutrDefiler4,
+	"putrdefiler5":             npc.PutrDefiler5,
+	"painworm":                 npc.PainWorm,
+	"painworm2":                npc.PainWorm2,
+	"painworm3":                npc.PainWorm3,
+	"painworm4":                npc.PainWorm4,
+	"painworm5":                npc.PainWorm5,
+	"bunny":                    npc.Bunny,
+	"councilmemberball":        npc.CouncilMemberBall,
+	"venomlord2":               npc.VenomLord2,
+	"baalcrabtostairs":         npc.BaalCrabToStairs,
+	"act5hireling1hand":        npc.Act5Hireling1Hand,
+	"act5hireling2hand":        npc.Act5Hireling2Hand,
+	"baaltentacle":             npc.BaalTentacle,
+	"baaltentacle2":            npc.BaalTentacle2,
+	"baaltentacle3":            npc.BaalTentacle3,
+	"baaltentacle4":            npc.BaalTentacle4,
+	"baaltentacle5":            npc.BaalTentacle5,
+	"injuredbarbarian":         npc.InjuredBarbarian,
+	"injuredbarbarian2":        npc.InjuredBarbarian2,
+	"injuredbarbarian3":        npc.InjuredBarbarian3,
+	"baalcrabclone":            npc.BaalCrabClone,
+	"baalsminion":              npc.BaalsMinion,
+	"baalsminion2":             npc.BaalsMinion2,
+	"baalsminion3":             npc.BaalsMinion3,
+	"worldstoneeffect":         npc.WorldstoneEffect,
+	"burningdeadarcher2":       npc.BurningDeadArcher2,
+	"bonearcher2":              npc.BoneArcher2,
+	"burningdeadarcher3":       npc.BurningDeadArcher3,
+	"returnedarcher2":          npc.ReturnedArcher2,
+	"horrorarcher2":            npc.HorrorArcher2,
+	"afflicted2":               npc.Afflicted2,
+	"tainted2":                 npc.Tainted2,
+	"misshapen2":               npc.Misshapen2,
+	"disfigured2":              npc.Disfigured2,
+	"damned2":                  npc.Damned2,
+	"moonclan2":                npc.MoonClan2,
+	"nightclan2":               npc.NightClan2,
+	"hellclan2":                npc.HellClan2,
+	"bloodclan2":               npc.BloodClan2,
+	"deathclan2":               npc.DeathClan2,
+	"foulcrow2":                npc.FoulCrow2,
+	"bloodhawk2":               npc.BloodHawk2,
+	"blackraptor2":             npc.BlackRaptor2,
+	"cloudstalker2":            npc.CloudStalker2,
+	"clawviper2":               npc.ClawViper2,
+	"pitviper2":                npc.PitViper2,
+	"salamander2":              npc.Salamander2,
+	"tombviper2":               npc.TombViper2,
+	"serpentmagus2":            npc.SerpentMagus2,
+	"marauder2":                npc.Marauder2,
+	"infel2":                   npc.Infel2,
+	"sandraer2":                npc.SandRaer2,
+	"invader2":                 npc.Invader2,
+	"assailant2":               npc.Assailant2,
+	"deathmauler6":             npc.DeathMauler6,
+	"quillrat2":                npc.QuillRat2,
+	"spikefiend2":              npc.SpikeFiend2,
+	"razorspine2":              npc.RazorSpine2,
+	"carrionbird2":             npc.CarrionBird2,
+	"thornedhulk2":             npc.ThornedHulk2,
+	"slinger2":                 npc.Slinger2,
+	"slinger3":                 npc.Slinger3,
+	"slinger4":                 npc.Slinger4,
+	"vilearcher2":              npc.VileArcher2,
+	"darkarcher2":              npc.DarkArcher2,
+	"vilelancer2":              npc.VileLancer2,
+	"darklancer2":              npc.DarkLancer2,
+	"blacklancer2":             npc.BlackLancer2,
+	"blunderbore2":             npc.Blunderbore2,
+	"mauler2":                  npc.Mauler2,
+	"returnedmage5":            npc.ReturnedMage5,
+	"burningdeadmage4":         npc.BurningDeadMage4,
+	"returnedmage6":            npc.ReturnedMage6,
+	"horrormage5":              npc.HorrorMage5,
+	"bonemage5":                npc.BoneMage5,
+	"horrormage6":              npc.HorrorMage6,
+	"horrormage7":              npc.HorrorMage7,
+	"huntress2":                npc.Huntress2,
+	"sabercat2":                npc.SaberCat2,
+	"caveleaper2":              npc.CaveLeaper2,
+	"tombcreeper2":             npc.TombCreeper2,
+	"ghost2":                   npc.Ghost2,
+	"wraith2":                  npc.Wraith2,
+	"specter2":                 npc.Specter2,
+	"succubusexp2":             npc.SuccubusExp2,
+	"helltemptress2":           npc.HellTemptress2,
+	"dominus2":                 npc.Dominus2,
+	"hellwitch2":               npc.HellWitch2,
+	"vilewitch2":               npc.VileWitch2,
+	"gloam2":                   npc.Gloam2,
+	"blacksoul2":               npc.BlackSoul2,
+	"burningsoul2":             npc.BurningSoul2,
+	"carver2":                  npc.Carver2,
+	"devilkin2":                npc.Devilkin2,
+	"darkone2":                 npc.DarkOne2,
+	"carvershaman2":            npc.CarverShaman2,
+	"devilkinshaman2":          npc.DevilkinShaman2,
+	"darkshaman2":              npc.DarkShaman2,
+	"bonewarrior2":             npc.BoneWarrior2,
+	"returned2":                npc.Returned2,
+	"gloombat2":                npc.Gloombat2,
+	"fiend2":                   npc.Fiend2,
+	"bloodlord7":               npc.BloodLord7,
+	"bloodlord8":               npc.BloodLord8,
+	"scarab2":                  npc.Scarab2,
+	"steelweevil2":             npc.SteelWeevil2,
+	"flayer3":                  npc.Flayer3,
+	"stygiandoll3":             npc.StygianDoll3,
+	"soulkiller3":              npc.SoulKiller3,
+	"flayer4":                  npc.Flayer4,
+	"stygiandoll4":             npc.StygianDoll4,
+	"soulkiller4":              npc.SoulKiller4,
+	"flayershaman2":            npc.FlayerShaman2,
+	"stygiandollshaman2":       npc.StygianDollShaman2,
+	"soulkillershaman2":        npc.SoulKillerShaman2,
+	"templeguard2":             npc.TempleGuard2,
+	"templeguard3":             npc.TempleGuard3,
+	"guardian2":                npc.Guardian2,
+	"unraveler2":               npc.Unraveler2,
+	"horadrimancient2":         npc.HoradrimAncient2,
+	"horadrimancient3":         npc.HoradrimAncient3,
+	"zealot2":                  npc.Zealot2,
+	"zealot3":                  npc.Zealot3,
+	"heirophant3":              npc.Heirophant3,
+	"heirophant4":              npc.Heirophant4,
+	"grotesque2":               npc.Grotesque2,
+	"fleshspawner2":            npc.FleshSpawner2,
+	"grotesquewyrm2":           npc.GrotesqueWyrm2,
+	"fleshbeast2":              npc.FleshBeast2,
+	"worldkiller2":             npc.WorldKiller2,
+	"worldkilleryoung2":        npc.WorldKillerYoung2,
+	"worldkilleregg2":          npc.WorldKillerEgg2,
+	"slayerexp2":               npc.SlayerExp2,
+	"hellspawn2":               npc.HellSpawn2,
+	"greaterhellspawn2":        npc.GreaterHellSpawn2,
+	"arach2":                   npc.Arach2,
+	"balrog2":                  npc.Balrog2,
+	"pitlord2":                 npc.PitLord2,
+	"imp6":                     npc.Imp6,
+	"imp7":                     npc.Imp7,
+	"undeadstygiandoll2":       npc.UndeadStygianDoll2,
+	"undeadsoulkiller2":        npc.UndeadSoulKiller2,
+	"strangler2":               npc.Strangler2,
+	"stormcaster2":             npc.StormCaster2,
+	"mawfiend2":                npc.MawFiend2,
+	"bloodlord9":               npc.BloodLord9,
+	"ghoullord2":               npc.GhoulLord2,
+	"darklord2":                npc.DarkLord2,
+	"unholycorpse2":            npc.UnholyCorpse2,
+	"doomknight2":              npc.DoomKnight2,
+	"doomknight3":              npc.DoomKnight3,
+	"oblivionknight2":          npc.OblivionKnight2,
+	"oblivionknight3":          npc.OblivionKnight3,
+	"cadaver2":                 npc.Cadaver2,
+	"ubermephisto":             npc.UberMephisto,
+	"uberdiablo":               npc.UberDiablo,
+	"uberizual":                npc.UberIzual,
+	"lilith":                   npc.Lilith,
+	"uberduriel":               npc.UberDuriel,
+	"uberbaal":                 npc.UberBaal,
+	"evilhut2":                 npc.EvilHut2,
+	"demonhole":                npc.DemonHole,
+	"pitlord3":                 npc.PitLord3,
+	"oblivionknight4":          npc.OblivionKnight4,
+	"imp8":                     npc.Imp8,
+	"hellswarm2":               npc.HellSwarm2,
+	"worldkiller3":             npc.WorldKiller3,
+	"arach3":                   npc.Arach3,
+	"steelweevil3":             npc.SteelWeevil3,
+	"helltemptress3":           npc.HellTemptress3,
+	"vilewitch3":               npc.VileWitch3,
+	"fleshhunter2":             npc.FleshHunter2,
+	"darkarcher3":              npc.DarkArcher3,
+	"blacklancer3":             npc.BlackLancer3,
+	"hellwhip2":                npc.HellWhip2,
+	"returned3":                npc.Returned3,
+	"horrorarcher3":            npc.HorrorArcher3,
+	"burningdeadmage5":         npc.BurningDeadMage5,
+	"horrormage8":              npc.HorrorMage8,
+	"bonemage6":                npc.BoneMage6,
+	"horrormage9":              npc.HorrorMage9,
+	"darklord3":                npc.DarkLord3,
+	"specter3":                 npc.Specter3,
+	"burningsoul3":             npc.BurningSoul3,
+}
+
+var objectNames = map[string]object.Name{
+	"notapplicable":                        object.NotApplicable,
+	"testdata1":                            object.TestData1,
+	"casket5":                              object.Casket5,
+	"shrine":                               object.Shrine,
+	"casket6":                              object.Casket6,
+	"largeurn1":                            object.LargeUrn1,
+	"largechestright":                      object.LargeChestRight,
+	"largechestleft":                       object.LargeChestLeft,
+	"barrel":                               object.Barrel,
+	"towertome":                            object.TowerTome,
+	"urn2":                                 object.Urn2,
+	"bench":                                object.Bench,
+	"barrelexploding":                      object.BarrelExploding,
+	"roguefountain":                        object.RogueFountain,
+	"doorgateleft":                         object.DoorGateLeft,
+	"doorgateright":                        object.DoorGateRight,
+	"doorwoodenleft":                       object.DoorWoodenLeft,
+	"doorwoodenright":                      object.DoorWoodenRight,
+	"cairnstonealpha":                      object.CairnStoneAlpha,
+	"cairnstonebeta":                       object.CairnStoneBeta,
+	"cairnstonegamma":                      object.CairnStoneGamma,
+	"cairnstonedelta":                      object.CairnStoneDelta,
+	"cairnstonelambda":                     object.CairnStoneLambda,
+	"cairnstonetheta":                      object.CairnStoneTheta,
+	"doorcourtyardleft":                    object.DoorCourtyardLeft,
+	"doorcourtyardright":                   object.DoorCourtyardRight,
+	"doorcathedraldouble":                  object.DoorCathedralDouble,
+	"caingibbet":                           object.CainGibbet,
+	"doormonasterydoubleright":             object.DoorMonasteryDoubleRight,
+	"holeanim":                             object.HoleAnim,
+	"brazier":                              object.Brazier,
+	"inifusstree":                          object.InifussTree,
+	"fountain":                             object.Fountain,
+	"crucifix":                             object.Crucifix,
+	"candles1":                             object.Candles1,
+	"candles2":                             object.Candles2,
+	"standard1":                            object.Standard1,
+	"standard2":                            object.Standard2,
+	"torch1tiki":                           object.Torch1Tiki,
+	"torch2wall":                           object.Torch2Wall,
+	"roguebonfire":                         object.RogueBonfire,
+	"river1":                               object.River1,
+	"river2":                               object.River2,
+	"river3":                               object.River3,
+	"river4":                               object.River4,
+	"river5":                               object.River5,
+	"ambientsoundgenerator":                object.AmbientSoundGenerator,
+	"crate":                                object.Crate,
+	"andarieldoor":                         object.AndarielDoor,
+	"roguetorch1":                          object.RogueTorch1,
+	"roguetorch2":                          object.RogueTorch2,
+	"casketr":                              object.CasketR,
+	"casketl":                              object.CasketL,
+	"urn3":                                 object.Urn3,
+	"casket":                               object.Casket,
+	"roguecorpse1":                         object.RogueCorpse1,
+	"roguecorpse2":                         object.RogueCorpse2,
+	"roguecorpserolling":                   object.RogueCorpseRolling,
+	"corpseonstick1":                       object.CorpseOnStick1,
+	"corpseonstick2":                       object.CorpseOnStick2,
+	"townportal":                           object.TownPortal,
+	"permanenttownportal":                  object.PermanentTownPortal,
+	"invisibleobject":                      object.InvisibleObject,
+	"doorcathedralleft":                    object.DoorCathedralLeft,
+	"doorcathedralright":                   object.DoorCathedralRight,
+	"doorwoodenleft2":                      object.DoorWoodenLeft2,
+	"invisibleriversound1":                 object.InvisibleRiverSound1,
+	"invisibleriversound2":                 object.InvisibleRiverSound2,
+	"ripple1":                              object.Ripple1,
+	"ripple2":                              object.Ripple2,
+	"ripple3":                              object.Ripple3,
+	"ripple4":                              object.Ripple4,
+	"forestnightsound1":                    object.ForestNightSound1,
+	"forestnightsound2":                    object.ForestNightSound2,
+	"yetidung":                             object.YetiDung,
+	"trappdoor":                            object.TrappDoor,
+	"doorbyact2dock":                       object.DoorByAct2Dock,
+	"sewerdrip":                            object.SewerDrip,
+	"healthorama":                          object.HealthOrama,
+	"invisibletownsound":                   object.InvisibleTownSound,
+	"casket3":                              object.Casket3,
+	"obelisk":                              object.Obelisk,
+	"forestaltar":                          object.ForestAltar,
+	"bubblingpoolofblood":                  object.BubblingPoolOfBlood,
+	"hornshrine":                           object.HornShrine,
+	"healingwell":                          object.HealingWell,
+	"bullhealthshrine":                     object.BullHealthShrine,
+	"steledesertmagicshrine":               object.SteleDesertMagicShrine,
+	"tomblargechestl":                      object.TombLargeChestL,
+	"tomblargechestr":                      object.TombLargeChestR,
+	"sarcophagus":                          object.Sarcophagus,
+	"desertobelisk":                        object.DesertObelisk,
+	"tombdoorleft":                         object.TombDoorLeft,
+	"tombdoorright":                        object.TombDoorRight,
+	"innerhellmanashrine":                  object.InnerHellManaShrine,
+	"largeurn4":                            object.LargeUrn4,
+	"largeurn5":                            object.LargeUrn5,
+	"innerhellhealthshrine":                object.InnerHellHealthShrine,
+	"innerhellshrine":                      object.InnerHellShrine,
+	"tombdoorleft2":                        object.TombDoorLeft2,
+	"tombdoorright2":                       object.TombDoorRight2,
+	"durielslairportal":                    object.DurielsLairPortal,
+	"brazier3":                             object.Brazier3,
+	"floorbrazier":                         object.FloorBrazier,
+	"flies":                                object.Flies,
+	"armorstandright":                      object.ArmorStandRight,
+	"armorstandleft":                       object.ArmorStandLeft,
+	"weaponrackright":                      object.WeaponRackRight,
+	"weaponrackleft":                       object.WeaponRackLeft,
+	"malus":                                object.Malus,
+	"palacehealthshrine":                   object.PalaceHealthShrine,
+	"drinker":                              object.Drinker,
+	"fountain1":                            object.Fountain1,
+	"gesturer":                             object.Gesturer,
+	"desertfountain":                       object.DesertFountain,
+	"turner":                               object.Turner,
+	"fountain3":                            object.Fountain3,
+	"snakewomanshrine":                     object.SnakeWomanShrine,
+	"jungletorch":                          object.JungleTorch,
+	"fountain4":                            object.Fountain4,
+	"waypointportal":                       object.WaypointPortal,
+	"dungeonhealthshrine":                  object.DungeonHealthShrine,
+	"jerhynplaceholder1":                   object.JerhynPlaceHolder1,
+	"jerhynplaceholder2":                   object.JerhynPlaceHolder2,
+	"innerhellshrine2":                     object.InnerHellShrine2,
+	"innerhellshrine3":                     object.InnerHellShrine3,
+	"innerhellhiddenstash":                 object.InnerHellHiddenStash,
+	"innerhellskullpile":                   object.InnerHellSkullPile,
+	"innerhellhiddenstash2":                object.InnerHellHiddenStash2,
+	"innerhellhiddenstash3":                object.InnerHellHiddenStash3,
+	"secretdoor1":                          object.SecretDoor1,
+	"act1wildernesswell":                   object.Act1WildernessWell,
+	"viledogafterglow":                     object.VileDogAfterglow,
+	"cathedralwell":                        object.CathedralWell,
+	"arcanesanctuaryshrine":                object.ArcaneSanctuaryShrine,
+	"desertshrine2":                        object.DesertShrine2,
+	"desertshrine3":                        object.DesertShrine3,
+	"desertshrine1":                        object.DesertShrine1,
+	"desertwell":                           object.DesertWell,
+	"cavewell":                             object.CaveWell,
+	"act1largechestright":                  object.Act1LargeChestRight,
+	"act1tallchestright":                   object.Act1TallChestRight,
+	"act1mediumchestright":                 object.Act1MediumChestRight,
+	"desertjug1":                           object.DesertJug1,
+	"desertjug2":                           object.DesertJug2,
+	"act1largechest1":                      object.Act1LargeChest1,
+	"innerhellwaypoint":                    object.InnerHellWaypoint,
+	"act2mediumchestright":                 object.Act2MediumChestRight,
+	"act2largechestright":                  object.Act2LargeChestRight,
+	"act2largechestleft":                   object.Act2LargeChestLeft,
+	"taintedsunaltar":                      object.TaintedSunAltar,
+	"desertshrine5":                        object.DesertShrine5,
+	"desertshrine4":                        object.DesertShrine4,
+	"horadricorifice":                      object.HoradricOrifice,
+	"tyraelsdoor":                          object.TyraelsDoor,
+	"guardcorpse":                          object.GuardCorpse,
+	"hiddenstashrock":                      object.HiddenStashRock,
+	"act2waypoint":                         object.Act2Waypoint,
+	"act1wildernesswaypoint":               object.Act1WildernessWaypoint,
+	"skeletoncorpseisanoxymoron":           object.SkeletonCorpseIsAnOxymoron,
+	"hiddenstashrockb":                     object.HiddenStashRockB,
+	"smallfire":                            object.SmallFire,
+	"mediumfire":                           object.MediumFire,
+	"largefire":                            object.LargeFire,
+	"act1cliffhidingspot":                  object.Act1CliffHidingSpot,
+	"manawell1":                            object.ManaWell1,
+	"manawell2":                            object.ManaWell2,
+	"manawell3":                            object.ManaWell3,
+	"manawell4":                            object.ManaWell4,
+	"manawell5":                            object.ManaWell5,
+	"hollowlog":                            object.HollowLog,
+	"junglehealwell":                       object.JungleHealWell,
+	"skeletoncorpseisstillanoxymoron":      object.SkeletonCorpseIsStillAnOxymoron,
+	"deserthealthshrine":                   object.DesertHealthShrine,
+	"manawell7":                            object.ManaWell7,
+	"looserock":                            object.LooseRock,
+	"looseboulder":                         object.LooseBoulder,
+	"mediumchestleft":                      object.MediumChestLeft,
+	"largechestleft2":                      object.LargeChestLeft2,
+	"guardcorpseonastick":                  object.GuardCorpseOnAStick,
+	"bookshelf1":                           object.Bookshelf1,
+	"bookshelf2":                           object.Bookshelf2,
+	"junglechest":                          object.JungleChest,
+	"tombcoffin":                           object.TombCoffin,
+	"junglemediumchestleft":                object.JungleMediumChestLeft,
+	"jungleshrine2":                        object.JungleShrine2,
+	"junglestashobject1":                   object.JungleStashObject1,
+	"junglestashobject2":                   object.JungleStashObject2,
+	"junglestashobject3":                   object.JungleStashObject3,
+	"junglestashobject4":                   object.JungleStashObject4,
+	"dummycainportal":                      object.DummyCainPortal,
+	"jungleshrine3":                        object.JungleShrine3,
+	"jungleshrine4":                        object.JungleShrine4,
+	"teleportationpad1":                    object.TeleportationPad1,
+	"lamesenstome":                         object.LamEsensTome,
+	"stairsl":                              object.StairsL,
+	"stairsr":                              object.StairsR,
+	"floortrap":                            object.FloorTrap,
+	"jungleshrine5":                        object.JungleShrine5,
+	"tallchestleft":                        object.TallChestLeft,
+	"mephistoshrine1":                      object.MephistoShrine1,
+	"mephistoshrine2":                      object.MephistoShrine2,
+	"mephistoshrine3":                      object.MephistoShrine3,
+	"mephistomanashrine":                   object.MephistoManaShrine,
+	"mephistolair":                         object.MephistoLair,
+	"stashbox":                             object.StashBox,
+	"stashaltar":                           object.StashAltar,
+	"mafistohealthshrine":                  object.MafistoHealthShrine,
+	"act3waterrocks":                       object.Act3WaterRocks,
+	"basket1":                              object.Basket1,
+	"basket2":                              object.Basket2,
+	"act3waterlogs":                        object.Act3WaterLogs,
+	"act3waterrocksgirl":                   object.Act3WaterRocksGirl,
+	"act3waterbubbles":                     object.Act3WaterBubbles,
+	"act3waterlogsx":                       object.Act3WaterLogsX,
+	"act3waterrocksb":                      object.Act3WaterRocksB,
+	"act3waterrocksgirlc":                  object.Act3WaterRocksGirlC,
+	"act3waterrocksy":                      object.Act3WaterRocksY,
+	"act3waterlogsz":                       object.Act3WaterLogsZ,
+	"webcoveredtree1":                      object.WebCoveredTree1,
+	"webcoveredtree2":                      object.WebCoveredTree2,
+	"webcoveredtree3":                      object.WebCoveredTree3,
+	"webcoveredtree4":                      object.WebCoveredTree4,
+	"pillar":                               object.Pillar,
+	"cocoon":                               object.Cocoon,
+	"cocoon2":                              object.Cocoon2,
+	"skullpileh1":                          object.SkullPileH1,
+	"outerhellshrine":                      object.OuterHellShrine,
+	"act3waterrocksgirlw":                  object.Act3WaterRocksGirlW,
+	"act3biglog":                           object.Act3BigLog,
+	"slimedoor1":                           object.SlimeDoor1,
+	"slimedoor2":                           object.SlimeDoor2,
+	"outerhellshrine2":                     object.OuterHellShrine2,
+	"outerhellshrine3":                     object.OuterHellShrine3,
+	"pillarh2":                             object.PillarH2,
+	"act3biglogc":                          object.Act3BigLogC,
+	"act3biglogd":                          object.Act3BigLogD,
+	"hellhealthshrine":                     object.HellHealthShrine,
+	"act3townwaypoint":                     object.Act3TownWaypoint,
+	"waypointh":                            object.WaypointH,
+	"burningbodytown":                      object.BurningBodyTown,
+	"gchest1l":                             object.Gchest1L,
+	"gchest2r":                             object.Gchest2R,
+	"gchest3r":                             object.Gchest3R,
+	"glchest3l":                            object.GLchest3L,
+	"sewersratnest":                        object.SewersRatNest,
+	"burningbodytown2":                     object.BurningBodyTown2,
+	"sewersratnest2":                       object.SewersRatNest2,
+	"act1bedbed1":                          object.Act1BedBed1,
+	"act1bedbed2":                          object.Act1BedBed2,
+	"hellmanashrine":                       object.HellManaShrine,
+	"explodingcow":                         object.ExplodingCow,
+	"gidbinnaltar":                         object.GidbinnAltar,
+	"gidbinnaltardecoy":                    object.GidbinnAltarDecoy,
+	"diablorightlight":                     object.DiabloRightLight,
+	"diabloleftlight":                      object.DiabloLeftLight,
+	"diablostartpoint":                     object.DiabloStartPoint,
+	"act1cabinstool":                       object.Act1CabinStool,
+	"act1cabinwood":                        object.Act1CabinWood,
+	"act1cabinwood2":                       object.Act1CabinWood2,
+	"hellskeletonspawnnw":                  object.HellSkeletonSpawnNW,
+	"act1holyshrine":                       object.Act1HolyShrine,
+	"tombsfloortrapspikes":                 object.TombsFloorTrapSpikes,
+	"act1cathedralshrine":                  object.Act1CathedralShrine,
+	"act1jailshrine1":                      object.Act1JailShrine1,
+	"act1jailshrine2":                      object.Act1JailShrine2,
+	"act1jailshrine3":                      object.Act1JailShrine3,
+	"maggotlairgoopile":                    object.MaggotLairGooPile,
+	"bank":                                 object.Bank,
+	"wirtcorpse":                           object.WirtCorpse,
+	"goldplaceholder":                      object.GoldPlaceHolder,
+	"guardcorpse2":                         object.GuardCorpse2,
+	"deadvillager1":                        object.DeadVillager1,
+	"deadvillager2":                        object.DeadVillager2,
+	"dummyflamenodamage":                   object.DummyFlameNoDamage,
+	"tinypixelshapedthingie":               object.TinyPixelShapedThingie,
+	"caveshealthshrine":                    object.CavesHealthShrine,
+	"cavesmanashrine":                      object.CavesManaShrine,
+	"cavemagicshrine":                      object.CaveMagicShrine,
+	"act3dungeonmanashrine":                object.Act3DungeonManaShrine,
+	"act3sewersmagicshrine1":               object.Act3SewersMagicShrine1,
+	"act3sewershealthwell":                 object.Act3SewersHealthWell,
+	"act3sewersmanawell":                   object.Act3SewersManaWell,
+	"act3sewersmagicshrine2":               object.Act3SewersMagicShrine2,
+	"act2brazierceller":                    object.Act2BrazierCeller,
+	"act2tombanubiscoffin":                 object.Act2TombAnubisCoffin,
+	"act2brazier":                          object.Act2Brazier,
+	"act2braziertall":                      object.Act2BrazierTall,
+	"act2braziersmall":                     object.Act2BrazierSmall,
+	"act2cellerwaypoint":                   object.Act2CellerWaypoint,
+	"harumbedbed":                          object.HarumBedBed,
+	"irongratedoorleft":                    object.IronGrateDoorLeft,
+	"irongratedoorright":                   object.IronGrateDoorRight,
+	"woodengratedoorleft":                  object.WoodenGrateDoorLeft,
+	"woodengratedoorright":                 object.WoodenGrateDoorRight,
+	"woodendoorleft":                       object.WoodenDoorLeft,
+	"woodendoorright":                      object.WoodenDoorRight,
+	"tombswalltorchleft":                   object.TombsWallTorchLeft,
+	"tombswalltorchright":                  object.TombsWallTorchRight,
+	"arcanesanctuaryportal":                object.ArcaneSanctuaryPortal,
+	"act2harammagicshrine1":                object.Act2HaramMagicShrine1,
+	"act2harammagicshrine2":                object.Act2HaramMagicShrine2,
+	"maggothealthwell":                     object.MaggotHealthWell,
+	"maggotmanawell":                       object.MaggotManaWell,
+	"arcanesanctuarymagicshrine":           object.ArcaneSanctuaryMagicShrine,
+	"teleportationpad2":                    object.TeleportationPad2,
+	"teleportationpad3":                    object.TeleportationPad3,
+	"teleportationpad4":                    object.TeleportationPad4,
+	"dummyarcanething1":                    object.DummyArcaneThing1,
+	"dummyarcanething2":                    object.DummyArcaneThing2,
+	"dummyarcanething3":                    object.DummyArcaneThing3,
+	"dummyarcanething4":                    object.DummyArcaneThing4,
+	"dummyarcanething5":                    object.DummyArcaneThing5,
+	"dummyarcanething6":                    object.DummyArcaneThing6,
+	"dummyarcanething7":                    object.DummyArcaneThing7,
+	"haremdeadguard1":                      object.HaremDeadGuard1,
+	"haremdeadguard2":                      object.HaremDeadGuard2,
+	"haremdeadguard3":                      object.HaremDeadGuard3,
+	"haremdeadguard4":                      object.HaremDeadGuard4,
+	"haremeunuchblocker":                   object.HaremEunuchBlocker,
+	"arcanehealthwell":                     object.ArcaneHealthWell,
+	"arcanemanawell":                       object.ArcaneManaWell,
+	"testdata2":                            object.TestData2,
+	"act2tombwell":                         object.Act2TombWell,
+	"act2sewerwaypoint":                    object.Act2SewerWaypoint,
+	"act3travincalwaypoint":                object.Act3TravincalWaypoint,
+	"act3sewermagicshrine":                 object.Act3SewerMagicShrine,
+	"act3sewerdeadbody":                    object.Act3SewerDeadBody,
+	"act3sewertorch":                       object.Act3SewerTorch,
+	"act3kurasttorch":                      object.Act3KurastTorch,
+	"mafistolargechestleft":                object.MafistoLargeChestLeft,
+	"mafistolargechestright":               object.MafistoLargeChestRight,
+	"mafistomediumchestleft":               object.MafistoMediumChestLeft,
+	"mafistomediumchestright":              object.MafistoMediumChestRight,
+	"spiderlairlargechestleft":             object.SpiderLairLargeChestLeft,
+	"spiderlairtallchestleft":              object.SpiderLairTallChestLeft,
+	"spiderlairmediumchestright":           object.SpiderLairMediumChestRight,
+	"spiderlairtallchestright":             object.SpiderLairTallChestRight,
+	"steegstone":                           object.SteegStone,
+	"guildvault":                           object.GuildVault,
+	"trophycase":                           object.TrophyCase,
+	"messageboard":                         object.MessageBoard,
+	"mephistobridge":                       object.MephistoBridge,
+	"hellgate":                             object.HellGate,
+	"act3kurastmanawell":                   object.Act3KurastManaWell,
+	"act3kurasthealthwell":                 object.Act3KurastHealthWell,
+	"hellfire1":                            object.HellFire1,
+	"hellfire2":                            object.HellFire2,
+	"hellfire3":                            object.HellFire3,
+	"helllava1":                            object.HellLava1,
+	"helllava2":                            object.HellLava2,
+	"helllava3":                            object.HellLava3,
+	"helllightsource1":                     object.HellLightSource1,
+	"helllightsource2":                     object.HellLightSource2,
+	"helllightsource3":                     object.HellLightSource3,
+	"horadriccubechest":                    object.HoradricCubeChest,
+	"horadricscrollchest":                  object.HoradricScrollChest,
+	"staffofkingschest":                    object.StaffOfKingsChest,
+	"yetanothertome":                       object.YetAnotherTome,
+	"hellbrazier1":                         object.HellBrazier1,
+	"hellbrazier2":                         object.HellBrazier2,
+	"dungeonrockpile":                      object.DungeonRockPile,
+	"act3dungeonmagicshrine":               object.Act3DungeonMagicShrine,
+	"act3dungeonbasket":                    object.Act3DungeonBasket,
+	"outerhellhungskeleton":                object.OuterHellHungSkeleton,
+	"guyfordungeon":                        object.GuyForDungeon,
+	"act3dungeoncasket":                    object.Act3DungeonCasket,
+	"act3sewerstairs":                      object.Act3SewerStairs,
+	"act3sewerstairstolevel3":              object.Act3SewerStairsToLevel3,
+	"darkwandererstartposition":            object.DarkWandererStartPosition,
+	"trappedsoulplaceholder":               object.TrappedSoulPlaceHolder,
+	"act3towntorch":                        object.Act3TownTorch,
+	"largechestr":                          object.LargeChestR,
+	"innerhellbonechest":                   object.InnerHellBoneChest,
+	"hellskeletonspawnne":                  object.HellSkeletonSpawnNE,
+	"act3waterfog":                         object.Act3WaterFog,
+	"dummynotused":                         object.DummyNotUsed,
+	"hellforge":                            object.HellForge,
+	"guildportal":                          object.GuildPortal,
+	"hratlistartposition":                  object.HratliStartPosition,
+	"hratliendposition":                    object.HratliEndPosition,
+	"burningtrappedsoul1":                  object.BurningTrappedSoul1,
+	"burningtrappedsoul2":                  object.BurningTrappedSoul2,
+	"natalyastartposition":                 object.NatalyaStartPosition,
+	"stuckedtrappedsoul1":                  object.StuckedTrappedSoul1,
+	"stuckedtrappedsoul2":                  object.StuckedTrappedSoul2,
+	"cainstartposition":                    object.CainStartPosition,
+	"arcanelargechestleft":                 object.ArcaneLargeChestLeft,
+	"arcanecasket":                         object.ArcaneCasket,
+	"arcanelargechestright":                object.ArcaneLargeChestRight,
+	"arcanesmallchestleft":                 object.ArcaneSmallChestLeft,
+	"arcanesmallchestright":                object.ArcaneSmallChestRight,
+	"diabloseal1":                          object.DiabloSeal1,
+	"diabloseal2":                          object.DiabloSeal2,
+	"diabloseal3":                          object.DiabloSeal3,
+	"diabloseal4":                          object.DiabloSeal4,
+	"diabloseal5":                          object.DiabloSeal5,
+	"sparklychest":                         object.SparklyChest,
+	"pandamoniumfortresswaypoint":          object.PandamoniumFortressWaypoint,
+	"innerhellfissure":                     object.InnerHellFissure,
+	"hellmesabrazier":                      object.HellMesaBrazier,
+	"smoke":                                object.Smoke,
+	"valleywaypoint":                       object.ValleyWaypoint,
+	"hellbrazier3":                         object.HellBrazier3,
+	"compellingorb":                        object.CompellingOrb,
+	"khalimchest1":                         object.KhalimChest1,
+	"khalimchest2":                         object.KhalimChest2,
+	"khalimchest3":                         object.KhalimChest3,
+	"siegemachinecontrol":                  object.SiegeMachineControl,
+	"pototorch":                            object.PotOTorch,
+	"pyoxfirepit":                          object.PyoxFirePit,
+	"expansionchestright":                  object.ExpansionChestRight,
+	"expansionwildernessshrine1":           object.ExpansionWildernessShrine1,
+	"expansionwildernessshrine2":           object.ExpansionWildernessShrine2,
+	"expansionhiddenstash":                 object.ExpansionHiddenStash,
+	"expansionwildernessflag":              object.ExpansionWildernessFlag,
+	"expansionwildernessbarrel":            object.ExpansionWildernessBarrel,
+	"expansionsiegebarrel":                 object.ExpansionSiegeBarrel,
+	"expansionwoodchestleft":               object.ExpansionWoodChestLeft,
+	"expansionwildernessshrine3":           object.ExpansionWildernessShrine3,
+	"expansionmanashrine":                  object.ExpansionManaShrine,
+	"expansionhealthshrine":                object.ExpansionHealthShrine,
+	"burialchestleft":                      object.BurialChestLeft,
+	"burialchestright":                     object.BurialChestRight,
+	"expansionwell":                        object.ExpansionWell,
+	"expansionwildernessshrine4":           object.ExpansionWildernessShrine4,
+	"expansionwildernessshrine5":           object.ExpansionWildernessShrine5,
+	"expansionwaypoint":                    object.ExpansionWaypoint,
+	"expansionchestleft":                   object.ExpansionChestLeft,
+	"expansionwoodchestright":              object.ExpansionWoodChestRight,
+	"expansionsmallchestleft":              object.ExpansionSmallChestLeft,
+	"expansionsmallchestright":             object.ExpansionSmallChestRight,
+	"expansiontorch1":                      object.ExpansionTorch1,
+	"expansioncampfire":                    object.ExpansionCampFire,
+	"expansiontowntorch":                   object.ExpansionTownTorch,
+	"expansiontorch2":                      object.ExpansionTorch2,
+	"expansionburningbodies":               object.ExpansionBurningBodies,
+	"expansionburningpit":                  object.ExpansionBurningPit,
+	"expansiontribalflag":                  object.ExpansionTribalFlag,
+	"expansiontownflag":                    object.ExpansionTownFlag,
+	"expansionchandelier":                  object.ExpansionChandelier,
+	"expansionjar1":                        object.ExpansionJar1,
+	"expansionjar2":                        object.ExpansionJar2,
+	"expansionjar3":                        object.ExpansionJar3,
+	"expansionswingingheads":               object.ExpansionSwingingHeads,
+	"expansionwildernesspole":              object.ExpansionWildernessPole,
+	"animatedskullandrockpile":             object.AnimatedSkullAndRockPile,
+	"expansiontowngate":                    object.ExpansionTownGate,
+	"skullandrockpile":                     object.SkullAndRockPile,
+	"siegehellgate":                        object.SiegeHellGate,
+	"enemycampbanner1":                     object.EnemyCampBanner1,
+	"enemycampbanner2":                     object.EnemyCampBanner2,
+	"expansionexplodingchest":              object.ExpansionExplodingChest,
+	"expansionspecialchest":                object.ExpansionSpecialChest,
+	"expansiondeathpole":                   object.ExpansionDeathPole,
+	"expansiondeathpoleleft":               object.ExpansionDeathPoleLeft,
+	"templealtar":                          object.TempleAltar,
+	"drehyatownstartposition":              object.DrehyaTownStartPosition,
+	"drehyawildernessstartposition":        object.DrehyaWildernessStartPosition,
+	"nihlathaktownstartposition":           object.NihlathakTownStartPosition,
+	"nihlathakwildernessstartpositionname": object.NihlathakWildernessStartPositionName,
+	"icecavehiddenstash":                   object.IceCaveHiddenStash,
+	"icecavehealthshrine":                  object.IceCaveHealthShrine,
+	"icecavemanashrine":                    object.IceCaveManaShrine,
+	"icecaveevilurn":                       object.IceCaveEvilUrn,
+	"icecavejar1":                          object.IceCaveJar1,
+	"icecavejar2":                          object.IceCaveJar2,
+	"icecavejar3":                          object.IceCaveJar3,
+	"icecavejar4":                          object.IceCaveJar4,
+	"icecavejar5":                          object.IceCaveJar5,
+	"icecavemagicshrine":                   object.IceCaveMagicShrine,
+	"cagedwussie":                          object.CagedWussie,
+	"ancientstatue3":                       object.AncientStatue3,
+	"ancientstatue1":                       object.AncientStatue1,
+	"ancientstatue2":                       object.AncientStatue2,
+	"deadbarbarian":                        object.DeadBarbarian,
+	"clientsmoke":                          object.ClientSmoke,
+	"icecavemagicshrine2":                  object.IceCaveMagicShrine2,
+	"icecavetorch1":                        object.IceCaveTorch1,
+	"icecavetorch2":                        object.IceCaveTorch2,
+	"expansiontikitorch":                   object.ExpansionTikiTorch,
+	"worldstonemanashrine":                 object.WorldstoneManaShrine,
+	"worldstonehealthshrine":               object.WorldstoneHealthShrine,
+	"worldstonetomb1":                      object.WorldstoneTomb1,
+	"worldstonetomb2":                      object.WorldstoneTomb2,
+	"worldstonetomb3":                      object.WorldstoneTomb3,
+	"worldstonemagicshrine":                object.WorldstoneMagicShrine,
+	"worldstonetorch1":                     object.WorldstoneTorch1,
+	"worldstonetorch2":                     object.WorldstoneTorch2,
+	"expansionsnowymanashrine1":            object.ExpansionSnowyManaShrine1,
+	"expansionsnowyhealthshrine":           object.ExpansionSnowyHealthShrine,
+	"expansionsnowywell":                   object.ExpansionSnowyWell,
+	"worldstonewaypoint":                   object.WorldstoneWaypoint,
+	"expansionsnowymagicshrine2":           object.ExpansionSnowyMagicShrine2,
+	"expansionwildernesswaypoint":          object.ExpansionWildernessWaypoint,
+	"expansionsnowymagicshrine3":           object.ExpansionSnowyMagicShrine3,
+	"worldstonewell":                       object.WorldstoneWell,
+	"worldstonemagicshrine2":               object.WorldstoneMagicShrine2,
+	"expansionsnowyobject1":                object.ExpansionSnowyObject1,
+	"expansionsnowywoodchestleft":          object.ExpansionSnowyWoodChestLeft,
+	"expansionsnowywoodchestright":         object.ExpansionSnowyWoodChestRight,
+	"worldstonemagicshrine3":               object.WorldstoneMagicShrine3,
+	"expansionsnowywoodchest2left":         object.ExpansionSnowyWoodChest2Left,
+	"expansionsnowywoodchest2right":        object.ExpansionSnowyWoodChest2Right,
+	"snowyswingingheads":                   object.SnowySwingingHeads,
+	"snowydebris":                          object.SnowyDebris,
+	"penbreakabledoor":                     object.PenBreakableDoor,
+	"expansiontemplemagicshrine1":          object.ExpansionTempleMagicShrine1,
+	"expansionsnowypolemr":                 object.ExpansionSnowyPoleMR,
+	"icecavewaypoint":                      object.IceCaveWaypoint,
+	"expansiontemplemagicshrine2":          object.ExpansionTempleMagicShrine2,
+	"expansiontemplewell":                  object.ExpansionTempleWell,
+	"expansiontempletorch1":                object.ExpansionTempleTorch1,
+	"expansiontempletorch2":                object.ExpansionTempleTorch2,
+	"expansiontempleobject1":               object.ExpansionTempleObject1,
+	"expansiontempleobject2":               object.ExpansionTempleObject2,
+	"worldstonemrbox":                      object.WorldstoneMrBox,
+	"icecavewell":                          object.IceCaveWell,
+	"expansiontemplemagicshrine":           object.ExpansionTempleMagicShrine,
+	"expansiontemplehealthshrine":          object.ExpansionTempleHealthShrine,
+	"expansiontemplemanashrine":            object.ExpansionTempleManaShrine,
+	"blacksmithforge":                      object.BlacksmithForge,
+	"worldstonetomb1left":                  object.WorldstoneTomb1Left,
+	"worldstonetomb2left":                  object.WorldstoneTomb2Left,
+	"worldstonetomb3left":                  object.WorldstoneTomb3Left,
+	"icecavebubblesu":                      object.IceCaveBubblesU,
+	"icecavebubbless":                      object.IceCaveBubblesS,
+	"redbaalslairtomb1":                    object.RedBaalsLairTomb1,
+	"redbaalslairtomb1left":                object.RedBaalsLairTomb1Left,
+	"redbaalslairtomb2":                    object.RedBaalsLairTomb2,
+	"redbaalslairtomb2left":                object.RedBaalsLairTomb2Left,
+	"redbaalslairtomb3":                    object.RedBaalsLairTomb3,
+	"redbaalslairtomb3left":                object.RedBaalsLairTomb3Left,
+	"redbaalslairmrbox":                    object.RedBaalsLairMrBox,
+	"redbaalslairtorch1":                   object.RedBaalsLairTorch1,
+	"redbaalslairtorch2":                   object.RedBaalsLairTorch2,
+	"candlestemple":                        object.CandlesTemple,
+	"templewaypoint":                       object.TempleWaypoint,
+	"expansiondeadperson1":                 object.ExpansionDeadPerson1,
+	"templegroundtomb":                     object.TempleGroundTomb,
+	"larzukgreeting":                       object.LarzukGreeting,
+	"larzukstandard":                       object.LarzukStandard,
+	"templegroundtombleft":                 object.TempleGroundTombLeft,
+	"expansiondeadperson2":                 object.ExpansionDeadPerson2,
+	"ancientsaltar":                        object.AncientsAltar,
+	"arreatsummitdoortoworldstone":         object.ArreatSummitDoorToWorldstone,
+	"expansionweaponrackright":             object.ExpansionWeaponRackRight,
+	"expansionweaponrackleft":              object.ExpansionWeaponRackLeft,
+	"expansionarmorstandright":             object.ExpansionArmorStandRight,
+	"expansionarmorstandleft":              object.ExpansionArmorStandLeft,
+	"arreatssummittorch2":                  object.ArreatsSummitTorch2,
+	"expansionfuneralspire":                object.ExpansionFuneralSpire,
+	"expansionburninglogs":                 object.ExpansionBurningLogs,
+	"icecavesteam":                         object.IceCaveSteam,
+	"expansiondeadperson3":                 object.ExpansionDeadPerson3,
+	"baalslair":                            object.BaalsLair,
+	"frozenanya":                           object.FrozenAnya,
+	"bbqbunny":                             object.BBQBunny,
+	"baaltorchbig":                         object.BaalTorchBig,
+	"invisibleancient":                     object.InvisibleAncient,
+	"invisiblebase":                        object.InvisibleBase,
+	"baalsportal":                          object.BaalsPortal,
+	"arreatsummitdoor":                     object.ArreatSummitDoor,
+	"lastportal":                           object.LastPortal,
+	"lastlastportal":                       object.LastLastPortal,
+	"zootestdata":                          object.ZooTestData,
+	"keepertestdata":                       object.KeeperTestData,
+	"baalsportal2":                         object.BaalsPortal2,
+	"fireplaceguy":                         object.FirePlaceGuy,
+	"doorblocker1":                         object.DoorBlocker1,
+	"doorblocker2":                         object.DoorBlocker2,
+	"goodchest":                            object.GoodChest,
+}
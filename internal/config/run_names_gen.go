@@ -0,0 +1,84 @@
+//go:build ignore
+
+// Generates run_names.go, the NPC and object names that can be used in the run definitions. Names are the d2go
+// constant names, d2go doesn't have a name table for them.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "github.com/hectorgimenez/d2go").Output()
+	if err != nil {
+		log.Fatalf("error finding d2go module: %s", err)
+	}
+	d2go := strings.TrimSpace(string(out))
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("// Code generated by run_names_gen.go; DO NOT EDIT.\n\npackage config\n\n")
+	buf.WriteString("import (\n\t\"github.com/hectorgimenez/d2go/pkg/data/npc\"\n\t\"github.com/hectorgimenez/d2go/pkg/data/object\"\n)\n\n")
+	writeNames(buf, "npcNames", "npc.ID", "npc", filepath.Join(d2go, "pkg", "data", "npc", "npc.go"), "ID")
+	buf.WriteString("\n")
+	writeNames(buf, "objectNames", "object.Name", "object", filepath.Join(d2go, "pkg", "data", "object", "ids.go"), "Name")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("error formatting generated code: %s", err)
+	}
+	if err = os.WriteFile("run_names.go", src, 0644); err != nil {
+		log.Fatalf("error writing run_names.go: %s", err)
+	}
+}
+
+// writeNames writes a map from the normalized constant name to the constant, for all the constants of the given type
+func writeNames(buf *bytes.Buffer, varName, typeName, pkg, file, constType string) {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		log.Fatalf("error parsing %s: %s", file, err)
+	}
+
+	fmt.Fprintf(buf, "var %s = map[string]%s{\n", varName, typeName)
+	// A few constants only differ in case, the first one is used
+	written := make(map[string]bool)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+
+		// Constants without type and value repeat the previous spec, the iota ones
+		currentType := ""
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if vs.Type != nil {
+				if ident, ok := vs.Type.(*ast.Ident); ok {
+					currentType = ident.Name
+				}
+			} else if len(vs.Values) > 0 {
+				currentType = ""
+			}
+			if currentType != constType {
+				continue
+			}
+			for _, name := range vs.Names {
+				key := strings.ToLower(name.Name)
+				if name.IsExported() && !written[key] {
+					written[key] = true
+					fmt.Fprintf(buf, "\t%q: %s.%s,\n", key, pkg, name.Name)
+				}
+			}
+		}
+	}
+	buf.WriteString("}\n")
+}
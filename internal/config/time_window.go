@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const minutesPerDay = 24 * 60
+
+// TimeWindow is a wall-clock time range of the day, written as 15:04-15:04 in the config. Windows ending before they
+// start span midnight, like 22:00-06:00, and 00:00-24:00 is the whole day.
+type TimeWindow struct {
+	// From and To are minutes since midnight, From is included and To is not
+	From int
+	To   int
+}
+
+func ParseTimeWindow(s string) (TimeWindow, error) {
+	from, to, found := strings.Cut(s, "-")
+	if !found {
+		return TimeWindow{}, fmt.Errorf("time window %s must be written as from-to, like 22:00-06:00", s)
+	}
+
+	fromMinute, err := minuteOfDay(from)
+	if err != nil {
+		return TimeWindow{}, err
+	}
+	toMinute, err := minuteOfDay(to)
+	if err != nil {
+		return TimeWindow{}, err
+	}
+
+	// 24:00 only makes sense as the end of the window, as the start it's the same as 00:00
+	if fromMinute == minutesPerDay {
+		fromMinute = 0
+	}
+	if fromMinute == toMinute {
+		return TimeWindow{}, fmt.Errorf("time window %s is empty, use 00:00-24:00 for the whole day", s)
+	}
+
+	return TimeWindow{From: fromMinute, To: toMinute}, nil
+}
+
+func (w *TimeWindow) UnmarshalYAML(value *yaml.Node) error {
+	tw, err := ParseTimeWindow(value.Value)
+	if err != nil {
+		return err
+	}
+	*w = tw
+
+	return nil
+}
+
+func (w TimeWindow) MarshalYAML() (interface{}, error) {
+	return w.String(), nil
+}
+
+func (w TimeWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.From/60, w.From%60, w.To/60, w.To%60)
+}
+
+// Contains checks if the time of the day is inside the window
+func (w TimeWindow) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if w.From <= w.To {
+		return minute >= w.From && minute < w.To
+	}
+
+	return minute >= w.From || minute < w.To
+}
+
+// NextStart returns t if it's inside the window, otherwise the next time the window starts
+func (w TimeWindow) NextStart(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+
+	start := time.Date(t.Year(), t.Month(), t.Day(), w.From/60, w.From%60, 0, 0, t.Location())
+	if start.Before(t) {
+		start = start.AddDate(0, 0, 1)
+	}
+
+	return start
+}
+
+// InsideTimeWindows checks if the time is inside any of the windows
+func InsideTimeWindows(windows []TimeWindow, t time.Time) bool {
+	for _, w := range windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextTimeInWindows returns the first time from t that is inside any of the windows, t if there are no windows
+func NextTimeInWindows(windows []TimeWindow, t time.Time) time.Time {
+	if len(windows) == 0 {
+		return t
+	}
+
+	next := windows[0].NextStart(t)
+	for _, w := range windows[1:] {
+		if start := w.NextStart(t); start.Before(next) {
+			next = start
+		}
+	}
+
+	return next
+}
+
+func minuteOfDay(clock string) (int, error) {
+	clock = strings.TrimSpace(clock)
+	if clock == "24:00" {
+		return minutesPerDay, nil
+	}
+
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %s: %w", clock, err)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
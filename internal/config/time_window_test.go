@@ -0,0 +1,129 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func clock(hour, minute int) time.Time {
+	return time.Date(2024, 8, 1, hour, minute, 0, 0, time.Local)
+}
+
+func TestParseTimeWindow(t *testing.T) {
+	tests := []struct {
+		window  string
+		want    TimeWindow
+		invalid bool
+	}{
+		{window: "08:00-23:30", want: TimeWindow{From: 8 * 60, To: 23*60 + 30}},
+		{window: " 22:00 - 06:00 ", want: TimeWindow{From: 22 * 60, To: 6 * 60}},
+		{window: "00:00-24:00", want: TimeWindow{From: 0, To: 24 * 60}},
+		{window: "24:00-06:00", want: TimeWindow{From: 0, To: 6 * 60}},
+		{window: "10:00-10:00", invalid: true},
+		{window: "00:00-00:00", invalid: true},
+		{window: "22:00", invalid: true},
+		{window: "22:00-25:00", invalid: true},
+		{window: "night-day", invalid: true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseTimeWindow(tc.window)
+		if tc.invalid {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %v", tc.window, got)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("%q: expected %v, got %v (err: %v)", tc.window, tc.want, got, err)
+		}
+	}
+}
+
+func TestTimeWindowContains(t *testing.T) {
+	tests := []struct {
+		window string
+		at     time.Time
+		want   bool
+	}{
+		{window: "08:00-23:30", at: clock(8, 0), want: true},
+		{window: "08:00-23:30", at: clock(23, 29), want: true},
+		{window: "08:00-23:30", at: clock(23, 30), want: false},
+		{window: "08:00-23:30", at: clock(7, 59), want: false},
+		// Windows crossing midnight
+		{window: "22:00-06:00", at: clock(22, 0), want: true},
+		{window: "22:00-06:00", at: clock(23, 59), want: true},
+		{window: "22:00-06:00", at: clock(0, 0), want: true},
+		{window: "22:00-06:00", at: clock(5, 59), want: true},
+		{window: "22:00-06:00", at: clock(6, 0), want: false},
+		{window: "22:00-06:00", at: clock(12, 0), want: false},
+		{window: "23:30-00:30", at: clock(0, 15), want: true},
+		{window: "23:30-00:30", at: clock(23, 15), want: false},
+		// Whole day
+		{window: "00:00-24:00", at: clock(0, 0), want: true},
+		{window: "00:00-24:00", at: clock(23, 59), want: true},
+	}
+
+	for _, tc := range tests {
+		w, err := ParseTimeWindow(tc.window)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := w.Contains(tc.at); got != tc.want {
+			t.Errorf("%s contains %s: expected %t, got %t", tc.window, tc.at.Format("15:04"), tc.want, got)
+		}
+	}
+}
+
+func TestNextTimeInWindows(t *testing.T) {
+	night, _ := ParseTimeWindow("22:00-06:00")
+	morning, _ := ParseTimeWindow("09:00-12:00")
+	windows := []TimeWindow{night, morning}
+
+	tests := []struct {
+		at   time.Time
+		want time.Time
+	}{
+		{at: clock(23, 0), want: clock(23, 0)},
+		{at: clock(3, 0), want: clock(3, 0)},
+		{at: clock(7, 0), want: clock(9, 0)},
+		{at: clock(13, 0), want: clock(22, 0)},
+	}
+	for _, tc := range tests {
+		if got := NextTimeInWindows(windows, tc.at); !got.Equal(tc.want) {
+			t.Errorf("from %s: expected %s, got %s", tc.at.Format("15:04"), tc.want.Format("15:04"), got.Format("15:04"))
+		}
+	}
+
+	// Window already started today, next start is tomorrow
+	if got := night.NextStart(clock(6, 30)); !got.Equal(clock(22, 0)) {
+		t.Errorf("expected today at 22:00, got %s", got)
+	}
+	if got := morning.NextStart(clock(12, 30)); !got.Equal(clock(9, 0).AddDate(0, 0, 1)) {
+		t.Errorf("expected tomorrow at 09:00, got %s", got)
+	}
+	if got := NextTimeInWindows(nil, clock(7, 0)); !got.Equal(clock(7, 0)) {
+		t.Errorf("without windows any time is valid, got %s", got)
+	}
+}
+
+func TestTimeWindowYAML(t *testing.T) {
+	var windows []TimeWindow
+	if err := yaml.Unmarshal([]byte(`["22:00-06:00", "00:00-24:00"]`), &windows); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := yaml.Marshal(windows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "- 22:00-06:00\n- 00:00-24:00\n" {
+		t.Errorf("unexpected yaml %q", out)
+	}
+
+	if err = yaml.Unmarshal([]byte(`["10:00-10:00"]`), &windows); err == nil {
+		t.Error("expected an error for an empty window")
+	}
+}
@@ -13,10 +13,11 @@ import (
 type Container struct {
 	Supervisor    string
 	Logger        *slog.Logger
-	Reader        *game.MemoryReader
+	Reader        game.DataReader
+	Screenshotter game.Screenshotter
 	HID           *game.HID
-	Injector      *game.MemoryInjector
-	Manager       *game.Manager
+	Injector      game.Injector
+	Manager       game.GameManager
 	PathFinder    *pather.PathFinder
 	CharacterCfg  *config.CharacterCfg
 	EventListener *event.Listener
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
 )
 
 const (
@@ -17,10 +18,17 @@ const (
 	InteractionTypeEntrance InteractionType = "entrance"
 	InteractionTypeNPC      InteractionType = "npc"
 	InteractionTypeObject   InteractionType = "object"
+
+	StuckBlockedByDoor  StuckReason = "door"
+	StuckBodyBlocked    StuckReason = "monster"
+	StuckWrongArea      StuckReason = "wrong area"
+	StuckTeleportFailed StuckReason = "teleport"
+	StuckTerrain        StuckReason = "terrain"
 )
 
 type FinishReason string
 type InteractionType string
+type StuckReason string
 
 type Event interface {
 	Message() string
@@ -69,6 +77,16 @@ func Text(supervisor string, message string) BaseEvent {
 	}
 }
 
+// Restored rebuilds the base of an event that already happened, like the ones read from a journal
+func Restored(supervisor string, message string, img image.Image, occurredAt time.Time) BaseEvent {
+	return BaseEvent{
+		message:    message,
+		image:      img,
+		occurredAt: occurredAt,
+		supervisor: supervisor,
+	}
+}
+
 type UsedPotionEvent struct {
 	BaseEvent
 	PotionType data.PotionType
@@ -113,6 +131,8 @@ type RunFinishedEvent struct {
 	BaseEvent
 	RunName string
 	Reason  FinishReason
+	// ActionSkipped is set when an action failed but it could be skipped, the run is not finished and continues
+	ActionSkipped bool
 }
 
 func RunFinished(be BaseEvent, runName string, reason FinishReason) RunFinishedEvent {
@@ -123,15 +143,29 @@ func RunFinished(be BaseEvent, runName string, reason FinishReason) RunFinishedE
 	}
 }
 
+// RunActionSkipped notifies an error on an action that can be skipped, it's sent as a RunFinishedEvent with error
+// reason but the run goes on
+func RunActionSkipped(be BaseEvent, runName string) RunFinishedEvent {
+	return RunFinishedEvent{
+		BaseEvent:     be,
+		RunName:       runName,
+		Reason:        FinishedError,
+		ActionSkipped: true,
+	}
+}
+
 type ItemStashedEvent struct {
 	BaseEvent
 	Item data.Drop
+	// RunName is the run in progress when the item was picked up, empty for items the bot didn't pick up
+	RunName string
 }
 
-func ItemStashed(be BaseEvent, drop data.Drop) ItemStashedEvent {
+func ItemStashed(be BaseEvent, drop data.Drop, runName string) ItemStashedEvent {
 	return ItemStashedEvent{
 		BaseEvent: be,
 		Item:      drop,
+		RunName:   runName,
 	}
 }
 
@@ -232,3 +266,52 @@ func IdentifiedItem(be BaseEvent, screenshot []byte, drop data.Drop) IdentifiedI
 		Item:       drop,
 	}
 }
+
+type StuckEvent struct {
+	BaseEvent
+	Reason   StuckReason
+	Recovery string
+	Attempt  int
+	Area     area.ID
+	Position data.Position
+}
+
+func Stuck(be BaseEvent, reason StuckReason, recovery string, attempt int, a area.ID, position data.Position) StuckEvent {
+	return StuckEvent{
+		BaseEvent: be,
+		Reason:    reason,
+		Recovery:  recovery,
+		Attempt:   attempt,
+		Area:      a,
+		Position:  position,
+	}
+}
+
+type StashFullEvent struct {
+	BaseEvent
+	// Items are the names of the items that couldn't be stashed
+	Items     []string
+	FreeCells map[int]int
+}
+
+func StashFull(be BaseEvent, items []string, freeCells map[int]int) StashFullEvent {
+	return StashFullEvent{
+		BaseEvent: be,
+		Items:     items,
+		FreeCells: freeCells,
+	}
+}
+
+type SessionBreakEvent struct {
+	BaseEvent
+	NextStart time.Time
+	Reason    string
+}
+
+func SessionBreak(be BaseEvent, nextStart time.Time, reason string) SessionBreakEvent {
+	return SessionBreakEvent{
+		BaseEvent: be,
+		NextStart: nextStart,
+		Reason:    reason,
+	}
+}
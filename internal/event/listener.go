@@ -4,94 +4,268 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"math"
-	"math/rand"
 	"os"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hectorgimenez/koolo/internal/config"
 	"github.com/hectorgimenez/koolo/internal/helper"
 )
 
-var events = make(chan Event)
+const (
+	// DropNewest discards the incoming event when the subscriber queue is full
+	DropNewest OverflowPolicy = iota
+	// DropOldest discards the oldest queued event to make room for the incoming one
+	DropOldest
+	// Block never discards events, the queue grows over its size until the handler catches up. Other subscribers
+	// are not delayed, use it only for fast handlers that can't lose events
+	Block
 
-type Listener struct {
-	handlers         []Handler
-	deliveryHandlers map[int]Handler
-	logger           *slog.Logger
-}
+	defaultQueueSize = 100
+	busSize          = 1000
+)
+
+// Events are buffered so Send doesn't wait for the handlers, the listener just fans them out to the subscribers
+var events = make(chan Event, busSize)
+
+// droppedEvents counts the events discarded by Send because the bus was full, reported by the listener
+var droppedEvents atomic.Int64
+
+type OverflowPolicy int
+
+type SubscriptionID int
 
 type Handler func(ctx context.Context, e Event) error
 
+type SubscriptionOption func(s *subscription)
+
+// WithQueueSize sets how many events can be waiting for the handler
+func WithQueueSize(size int) SubscriptionOption {
+	return func(s *subscription) {
+		s.size = size
+	}
+}
+
+func WithOverflowPolicy(policy OverflowPolicy) SubscriptionOption {
+	return func(s *subscription) {
+		s.policy = policy
+	}
+}
+
+type subscription struct {
+	id     SubscriptionID
+	name   string
+	h      Handler
+	policy OverflowPolicy
+	done   chan struct{}
+
+	mu      sync.Mutex
+	pending []Event
+	size    int
+	// wake is signaled when events are added to pending
+	wake chan struct{}
+}
+
+type Listener struct {
+	mu            sync.RWMutex
+	subscriptions map[SubscriptionID]*subscription
+	lastID        SubscriptionID
+	ctx           context.Context
+	cancel        context.CancelFunc
+	logger        *slog.Logger
+}
+
 func NewListener(logger *slog.Logger) *Listener {
-	return &Listener{
-		logger:           logger,
-		deliveryHandlers: make(map[int]Handler),
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &Listener{
+		subscriptions: make(map[SubscriptionID]*subscription),
+		ctx:           ctx,
+		cancel:        cancel,
+		logger:        logger,
 	}
+	l.Register(l.saveScreenshot)
+
+	return l
 }
 
-func (l *Listener) Register(h Handler) {
-	l.handlers = append(l.handlers, h)
+// Register subscribes the handler to all the events, every subscriber has its own queue and goroutine, so a
+// slow handler only delays its own events. Default is a queue of 100 events dropping the newest on overflow.
+func (l *Listener) Register(h Handler, opts ...SubscriptionOption) SubscriptionID {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastID++
+	s := &subscription{
+		id:     l.lastID,
+		name:   handlerName(h),
+		h:      h,
+		size:   defaultQueueSize,
+		policy: DropNewest,
+		done:   make(chan struct{}),
+		wake:   make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	l.subscriptions[s.id] = s
+
+	go l.consume(s)
+
+	return s.id
+}
+
+// Unregister stops delivering events to the subscriber, events already queued are discarded
+func (l *Listener) Unregister(id SubscriptionID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if s, found := l.subscriptions[id]; found {
+		close(s.done)
+		delete(l.subscriptions, id)
+	}
+}
+
+// Subscribe registers a handler that only receives events of type T
+func Subscribe[T Event](l *Listener, h func(ctx context.Context, e T) error, opts ...SubscriptionOption) SubscriptionID {
+	return l.Register(func(ctx context.Context, e Event) error {
+		if evt, ok := e.(T); ok {
+			return h(ctx, evt)
+		}
+
+		return nil
+	}, opts...)
 }
 
 func (l *Listener) Listen(ctx context.Context) error {
+	defer l.cancel()
+
 	for {
 		select {
 		case e := <-events:
-			if _, err := os.Stat("screenshots"); os.IsNotExist(err) {
-				err = os.MkdirAll("screenshots", os.ModePerm)
-				if err != nil {
-					l.logger.Error("error creating screenshots directory", slog.Any("error", err))
-				}
+			if dropped := droppedEvents.Swap(0); dropped > 0 {
+				l.logger.Warn("event bus is full, events discarded", slog.Int64("events", dropped))
 			}
 
-			if e.Image() != nil && config.Koolo.Debug.Screenshots {
-				fileName := fmt.Sprintf("screenshots/error-%s.jpeg", time.Now().Format("2006-01-02 15_04_05"))
-				err := helper.SaveImageJPEG(e.Image(), fileName)
-				if err != nil {
-					l.logger.Error("error saving screenshot", slog.Any("error", err))
-				}
+			l.mu.RLock()
+			subscriptions := make([]*subscription, 0, len(l.subscriptions))
+			for _, s := range l.subscriptions {
+				subscriptions = append(subscriptions, s)
 			}
+			l.mu.RUnlock()
 
-			for _, h := range l.handlers {
-				if err := h(ctx, e); err != nil && e.Message() != "" {
-					l.logger.Error("error running event handler", slog.Any("error", err))
-				}
+			for _, s := range subscriptions {
+				l.enqueue(s, e)
 			}
-			for _, h := range l.deliveryHandlers {
-				if err := h(ctx, e); err != nil {
-					l.logger.Error("error running event delivery handler", slog.Any("error", err))
-				}
-			}
-
 		case <-ctx.Done():
 			return nil
 		}
 	}
 }
 
-func (l *Listener) WaitForEvent(ctx context.Context) Event {
-	evtChan := make(chan Event)
-	idx := rand.Intn(math.MaxInt64)
-	l.deliveryHandlers[idx] = func(ctx context.Context, e Event) error {
-		evtChan <- e
-		return nil
+// enqueue never waits for the subscriber, so a slow handler doesn't delay the events of the rest
+func (l *Listener) enqueue(s *subscription, e Event) {
+	s.mu.Lock()
+	if len(s.pending) >= s.size {
+		switch s.policy {
+		case DropNewest:
+			s.mu.Unlock()
+			l.logger.Warn("event queue is full, event discarded", slog.String("handler", s.name), slog.String("event", reflect.TypeOf(e).Name()))
+			return
+		case DropOldest:
+			s.pending = s.pending[1:]
+			l.logger.Warn("event queue is full, oldest event discarded", slog.String("handler", s.name))
+		}
 	}
-	// Clean up the handler when we're done
-	defer func() {
-		delete(l.deliveryHandlers, idx)
-	}()
+	s.pending = append(s.pending, e)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
 
+func (l *Listener) consume(s *subscription) {
 	for {
 		select {
-		case e := <-evtChan:
-			return e
-		case <-ctx.Done():
-			return nil
+		case <-s.wake:
+		case <-s.done:
+			return
+		case <-l.ctx.Done():
+			return
+		}
+
+		s.mu.Lock()
+		pending := s.pending
+		s.pending = nil
+		s.mu.Unlock()
+
+		for _, e := range pending {
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+			if err := s.h(l.ctx, e); err != nil && e.Message() != "" {
+				l.logger.Error("error running event handler", slog.String("handler", s.name), slog.Any("error", err))
+			}
 		}
 	}
 }
 
+func (l *Listener) WaitForEvent(ctx context.Context) Event {
+	evtChan := make(chan Event, 1)
+	id := l.Register(func(ctx context.Context, e Event) error {
+		select {
+		case evtChan <- e:
+		default:
+		}
+		return nil
+	})
+	// Clean up the handler when we're done
+	defer l.Unregister(id)
+
+	select {
+	case e := <-evtChan:
+		return e
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+func (l *Listener) saveScreenshot(_ context.Context, e Event) error {
+	if e.Image() == nil || !config.Koolo.Debug.Screenshots {
+		return nil
+	}
+
+	if _, err := os.Stat("screenshots"); os.IsNotExist(err) {
+		err = os.MkdirAll("screenshots", os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("error creating screenshots directory: %w", err)
+		}
+	}
+
+	fileName := fmt.Sprintf("screenshots/error-%s.jpeg", time.Now().Format("2006-01-02 15_04_05"))
+	if err := helper.SaveImageJPEG(e.Image(), fileName); err != nil {
+		return fmt.Errorf("error saving screenshot: %w", err)
+	}
+
+	return nil
+}
+
+func handlerName(h Handler) string {
+	return runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name()
+}
+
+// Send publishes the event without blocking, if the listener is not keeping up and the bus is full the event is
+// discarded and reported by the listener
 func Send(e Event) {
-	events <- e
+	select {
+	case events <- e:
+	default:
+		droppedEvents.Add(1)
+	}
 }
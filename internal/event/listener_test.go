@@ -0,0 +1,171 @@
+package event_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+func startListener(t *testing.T) *event.Listener {
+	config.Koolo = &config.KooloCfg{}
+	l := event.NewListener(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go l.Listen(ctx)
+
+	return l
+}
+
+func TestSubscribeOnlyReceivesItsType(t *testing.T) {
+	l := startListener(t)
+
+	received := make(chan string, 10)
+	id := event.Subscribe(l, func(_ context.Context, e event.RunStartedEvent) error {
+		received <- e.RunName
+		return nil
+	})
+	defer l.Unregister(id)
+
+	event.Send(event.GameCreated(event.Text("sorc", ""), "", ""))
+	event.Send(event.RunStarted(event.Text("sorc", ""), "baal"))
+
+	select {
+	case name := <-received:
+		if name != "baal" {
+			t.Errorf("expected baal, got %s", name)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("event not received")
+	}
+	select {
+	case name := <-received:
+		t.Errorf("unexpected event %s", name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBlockingSubscriberDoesNotDelayOthers(t *testing.T) {
+	l := startListener(t)
+
+	release := make(chan struct{})
+	blocked := make(chan string, 100)
+	slowID := event.Subscribe(l, func(_ context.Context, e event.RunStartedEvent) error {
+		<-release
+		blocked <- e.RunName
+		return nil
+	}, event.WithOverflowPolicy(event.Block), event.WithQueueSize(1))
+	defer l.Unregister(slowID)
+
+	fast := make(chan string, 100)
+	fastID := event.Subscribe(l, func(_ context.Context, e event.RunStartedEvent) error {
+		fast <- e.RunName
+		return nil
+	})
+	defer l.Unregister(fastID)
+
+	runs := []string{"andariel", "mephisto", "diablo", "baal"}
+	for _, r := range runs {
+		event.Send(event.RunStarted(event.Text("sorc", ""), r))
+	}
+
+	for _, r := range runs {
+		select {
+		case got := <-fast:
+			if got != r {
+				t.Errorf("expected %s, got %s", r, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("fast subscriber was delayed by the blocking one")
+		}
+	}
+
+	// The blocking subscriber gets every event once it catches up, even over its queue size
+	close(release)
+	for _, r := range runs {
+		select {
+		case got := <-blocked:
+			if got != r {
+				t.Errorf("expected %s, got %s", r, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("blocking subscriber lost %s", r)
+		}
+	}
+}
+
+func TestDropNewestDiscardsOverQueueSize(t *testing.T) {
+	l := startListener(t)
+
+	release := make(chan struct{})
+	received := make(chan string, 100)
+	id := event.Subscribe(l, func(_ context.Context, e event.RunStartedEvent) error {
+		<-release
+		received <- e.RunName
+		return nil
+	}, event.WithQueueSize(1))
+	defer l.Unregister(id)
+
+	event.Send(event.RunStarted(event.Text("sorc", ""), "first"))
+	// Wait until the handler took the first event, the queue is empty again
+	time.Sleep(50 * time.Millisecond)
+	for _, r := range []string{"second", "third", "fourth"} {
+		event.Send(event.RunStarted(event.Text("sorc", ""), r))
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	got := make([]string, 0)
+	timeout := time.After(200 * time.Millisecond)
+	for done := false; !done; {
+		select {
+		case r := <-received:
+			got = append(got, r)
+		case <-timeout:
+			done = true
+		}
+	}
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Errorf("expected first and second, got %v", got)
+	}
+}
+
+func TestSendDoesNotBlockWhenTheBusIsFull(t *testing.T) {
+	sent := make(chan struct{})
+	go func() {
+		// Nobody is listening, the bus fills up and the rest of the events are discarded
+		for i := 0; i < 2000; i++ {
+			event.Send(event.RunStarted(event.Text("sorc", ""), "baal"))
+		}
+		close(sent)
+	}()
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("Send blocked with a full bus")
+	}
+
+	// Drain the bus for the next tests, the marker is received once the events sent before are gone
+	l := startListener(t)
+	drained := make(chan struct{}, 1)
+	id := event.Subscribe(l, func(_ context.Context, e event.GameCreatedEvent) error {
+		if e.Name == "drained" {
+			drained <- struct{}{}
+		}
+		return nil
+	})
+	defer l.Unregister(id)
+	for {
+		event.Send(event.GameCreated(event.Text("sorc", ""), "drained", ""))
+		select {
+		case <-drained:
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
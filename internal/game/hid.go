@@ -1,13 +1,21 @@
 package game
 
+// InputSender delivers the mouse and keyboard input, HID builds the clicks, key presses and timings on top of it.
+// By default input is sent to the game window, but it can be replaced to capture it (replays, simulations...)
+type InputSender interface {
+	MovePointer(x, y int)
+	MouseButton(btn MouseButton, x, y int, down bool)
+	Key(key byte, down bool)
+	OverrideModifier(modifier ModifierKey)
+	RestoreModifier()
+}
+
 type HID struct {
-	gr *MemoryReader
-	gi *MemoryInjector
+	sender InputSender
 }
 
-func NewHID(gr *MemoryReader, gi *MemoryInjector) *HID {
+func NewHIDWithSender(sender InputSender) *HID {
 	return &HID{
-		gr: gr,
-		gi: gi,
+		sender: sender,
 	}
 }
@@ -6,8 +6,6 @@ import (
 	"time"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
-	"github.com/inkeliz/w32"
-	"github.com/lxn/win"
 )
 
 const (
@@ -17,17 +15,17 @@ const (
 
 // PressKey receives an ASCII code and sends a key press event to the game window
 func (hid *HID) PressKey(key byte) {
-	win.PostMessage(hid.gr.HWND, win.WM_KEYDOWN, uintptr(key), hid.calculatelParam(key, true))
+	hid.sender.Key(key, true)
 	sleepTime := rand.Intn(keyPressMaxTime-keyPressMinTime) + keyPressMinTime
 	time.Sleep(time.Duration(sleepTime) * time.Millisecond)
-	win.PostMessage(hid.gr.HWND, win.WM_KEYUP, uintptr(key), hid.calculatelParam(key, false))
+	hid.sender.Key(key, false)
 }
 
 // PressKeyWithModifier works the same as PressKey but with a modifier key (shift, ctrl, alt)
 func (hid *HID) PressKeyWithModifier(key byte, modifier ModifierKey) {
-	hid.gi.OverrideGetKeyState(byte(modifier))
+	hid.sender.OverrideModifier(modifier)
 	hid.PressKey(key)
-	hid.gi.RestoreGetKeyState()
+	hid.sender.RestoreModifier()
 }
 
 func (hid *HID) PressKeyBinding(kb data.KeyBinding) {
@@ -43,13 +41,13 @@ func (hid *HID) PressKeyBinding(kb data.KeyBinding) {
 // KeyDown sends a key down event to the game window
 func (hid *HID) KeyDown(kb data.KeyBinding) {
 	keys := getKeysForKB(kb)
-	win.PostMessage(hid.gr.HWND, win.WM_KEYDOWN, uintptr(keys[0]), hid.calculatelParam(keys[0], true))
+	hid.sender.Key(keys[0], true)
 }
 
 // KeyUp sends a key up event to the game window
 func (hid *HID) KeyUp(kb data.KeyBinding) {
 	keys := getKeysForKB(kb)
-	win.PostMessage(hid.gr.HWND, win.WM_KEYUP, uintptr(keys[0]), hid.calculatelParam(keys[0], false))
+	hid.sender.Key(keys[0], false)
 }
 
 func getKeysForKB(kb data.KeyBinding) [2]byte {
@@ -70,50 +68,35 @@ func (hid *HID) GetASCIICode(key string) byte {
 }
 
 var specialChars = map[string]byte{
-	"esc":       win.VK_ESCAPE,
-	"enter":     win.VK_RETURN,
-	"f1":        win.VK_F1,
-	"f2":        win.VK_F2,
-	"f3":        win.VK_F3,
-	"f4":        win.VK_F4,
-	"f5":        win.VK_F5,
-	"f6":        win.VK_F6,
-	"f7":        win.VK_F7,
-	"f8":        win.VK_F8,
-	"f9":        win.VK_F9,
-	"f10":       win.VK_F10,
-	"f11":       win.VK_F11,
-	"f12":       win.VK_F12,
-	"lctrl":     win.VK_LCONTROL,
-	"home":      win.VK_HOME,
-	"down":      win.VK_DOWN,
-	"up":        win.VK_UP,
-	"left":      win.VK_LEFT,
-	"right":     win.VK_RIGHT,
-	"tab":       win.VK_TAB,
-	"space":     win.VK_SPACE,
-	"alt":       win.VK_MENU,
-	"lalt":      win.VK_LMENU,
-	"ralt":      win.VK_RMENU,
-	"shift":     win.VK_LSHIFT,
-	"backspace": win.VK_BACK,
-	"lwin":      win.VK_LWIN,
-	"rwin":      win.VK_RWIN,
-	"end":       win.VK_END,
-	"-":         win.VK_OEM_MINUS,
-}
-
-func (hid *HID) calculatelParam(keyCode byte, down bool) uintptr {
-	scanCode := int(w32.MapVirtualKey(uint(keyCode), w32.MAPVK_VK_TO_VSC))
-	repeatCount := 1
-	extendedKeyFlag := 0
-	contextCode := 0
-	previousKeyState := 0
-	transitionState := 0
-	if !down {
-		transitionState = 1
-	}
-
-	lParam := uintptr((repeatCount & 0xFFFF) | (scanCode << 16) | (extendedKeyFlag << 24) | (contextCode << 29) | (previousKeyState << 30) | (transitionState << 31))
-	return lParam
+	"esc":       VKEscape,
+	"enter":     VKReturn,
+	"f1":        VKF1,
+	"f2":        VKF2,
+	"f3":        VKF3,
+	"f4":        VKF4,
+	"f5":        VKF5,
+	"f6":        VKF6,
+	"f7":        VKF7,
+	"f8":        VKF8,
+	"f9":        VKF9,
+	"f10":       VKF10,
+	"f11":       VKF11,
+	"f12":       VKF12,
+	"lctrl":     VKLControl,
+	"home":      VKHome,
+	"down":      VKDown,
+	"up":        VKUp,
+	"left":      VKLeft,
+	"right":     VKRight,
+	"tab":       VKTab,
+	"space":     VKSpace,
+	"alt":       VKMenu,
+	"lalt":      VKLMenu,
+	"ralt":      VKRMenu,
+	"shift":     VKLShift,
+	"backspace": VKBack,
+	"lwin":      VKLWin,
+	"rwin":      VKRWin,
+	"end":       VKEnd,
+	"-":         VKOEMMinus,
 }
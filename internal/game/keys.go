@@ -0,0 +1,39 @@
+package game
+
+// Windows virtual key codes, the ones used by the bot. They are defined here so the packages sending input
+// don't depend on Windows APIs and can be built everywhere (simulator and tests).
+const (
+	VKBack     = 8
+	VKTab      = 9
+	VKReturn   = 13
+	VKShift    = 16
+	VKControl  = 17
+	VKMenu     = 18
+	VKEscape   = 0x1B
+	VKSpace    = 32
+	VKEnd      = 35
+	VKHome     = 36
+	VKLeft     = 37
+	VKUp       = 38
+	VKRight    = 39
+	VKDown     = 40
+	VKLWin     = 0x5B
+	VKRWin     = 0x5C
+	VKF1       = 0x70
+	VKF2       = 0x71
+	VKF3       = 0x72
+	VKF4       = 0x73
+	VKF5       = 0x74
+	VKF6       = 0x75
+	VKF7       = 0x76
+	VKF8       = 0x77
+	VKF9       = 0x78
+	VKF10      = 0x79
+	VKF11      = 0x7A
+	VKF12      = 0x7B
+	VKLShift   = 0xA0
+	VKLControl = 0xA2
+	VKLMenu    = 0xA4
+	VKRMenu    = 0xA5
+	VKOEMMinus = 0xBD
+)
@@ -0,0 +1,230 @@
+package map_client
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+	"github.com/hectorgimenez/koolo/internal/config"
+)
+
+const cacheFileSuffix = ".json.gz"
+
+var ErrCorruptedCache = errors.New("cached map data is corrupted")
+
+// Cache keeps the map data on disk, one compressed file per seed and difficulty. Files are evicted in least
+// recently used order when the cache grows over the max size, access time is tracked using the modification time.
+type Cache struct {
+	dir     string
+	maxSize int64
+	mu      sync.Mutex
+}
+
+type cachedMapData struct {
+	Seed       string                `json:"seed"`
+	Difficulty difficulty.Difficulty `json:"difficulty"`
+	Checksum   string                `json:"checksum"`
+	Levels     json.RawMessage       `json:"levels"`
+}
+
+var (
+	sharedCache     *Cache
+	sharedCacheOnce sync.Once
+)
+
+// defaultCache returns the cache configured in koolo.yaml, nil if it's disabled or can not be created
+func defaultCache() *Cache {
+	sharedCacheOnce.Do(func() {
+		if !config.Koolo.MapCache.Enabled {
+			return
+		}
+		sharedCache, _ = NewCache(config.Koolo.MapCache.Directory, config.Koolo.MapCache.MaxSizeMB)
+	})
+
+	return sharedCache
+}
+
+func NewCache(dir string, maxSizeMB int) (*Cache, error) {
+	if dir == "" {
+		dir = "map_cache"
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("error creating map cache directory: %w", err)
+	}
+
+	return &Cache{
+		dir:     dir,
+		maxSize: int64(maxSizeMB) * 1024 * 1024,
+	}, nil
+}
+
+// Path returns the cache file for the seed and difficulty
+func (c *Cache) Path(seed string, df difficulty.Difficulty) string {
+	return filepath.Join(c.dir, fmt.Sprintf("%s-%s%s", strings.ToLower(string(df)), seed, cacheFileSuffix))
+}
+
+// Get returns the cached map data, corrupted files are removed and reported as not found
+func (c *Cache) Get(seed string, df difficulty.Difficulty) (MapData, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := c.Path(seed, df)
+	md, err := LoadCacheFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			os.Remove(path)
+		}
+		return nil, false
+	}
+
+	now := time.Now()
+	os.Chtimes(path, now, now)
+
+	return md, true
+}
+
+func (c *Cache) Put(seed string, df difficulty.Difficulty, md MapData) error {
+	levels, err := json.Marshal(md)
+	if err != nil {
+		return err
+	}
+	checksum := sha256.Sum256(levels)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Written to a temporary file first, so a crash never leaves a half written file with the final name
+	path := c.Path(seed, df)
+	tmp := path + ".tmp"
+	if err = writeCacheFile(tmp, cachedMapData{
+		Seed:       seed,
+		Difficulty: df,
+		Checksum:   hex.EncodeToString(checksum[:]),
+		Levels:     levels,
+	}); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("error saving map cache: %w", err)
+	}
+
+	return c.evict()
+}
+
+// Size returns the number of cached maps and their total size in bytes
+func (c *Cache) Size() (int, int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	files := c.files()
+	total := int64(0)
+	for _, f := range files {
+		total += f.Size()
+	}
+
+	return len(files), total
+}
+
+// evict removes the least recently used files until the cache fits in the max size
+func (c *Cache) evict() error {
+	if c.maxSize <= 0 {
+		return nil
+	}
+
+	files := c.files()
+	total := int64(0)
+	for _, f := range files {
+		total += f.Size()
+	}
+
+	slices.SortFunc(files, func(a, b os.FileInfo) int {
+		return a.ModTime().Compare(b.ModTime())
+	})
+	var errs []error
+	for _, f := range files {
+		if total <= c.maxSize {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.dir, f.Name())); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		total -= f.Size()
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Cache) files() []os.FileInfo {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil
+	}
+
+	files := make([]os.FileInfo, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), cacheFileSuffix) {
+			continue
+		}
+		if info, err := e.Info(); err == nil {
+			files = append(files, info)
+		}
+	}
+
+	return files
+}
+
+// LoadCacheFile reads a map cache file checking its integrity, cache files can be used as map data fixtures
+func LoadCacheFile(path string) (MapData, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptedCache, err)
+	}
+	defer gz.Close()
+
+	var cached cachedMapData
+	if err = json.NewDecoder(gz).Decode(&cached); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptedCache, err)
+	}
+
+	checksum := sha256.Sum256(cached.Levels)
+	if hex.EncodeToString(checksum[:]) != cached.Checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrCorruptedCache)
+	}
+
+	var md MapData
+	if err = json.Unmarshal(cached.Levels, &md); err != nil || len(md) == 0 {
+		return nil, fmt.Errorf("%w: no levels found", ErrCorruptedCache)
+	}
+
+	return md, nil
+}
+
+func writeCacheFile(path string, cached cachedMapData) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating map cache file: %w", err)
+	}
+
+	gz := gzip.NewWriter(f)
+	err = json.NewEncoder(gz).Encode(cached)
+
+	return errors.Join(err, gz.Close(), f.Close())
+}
@@ -1,54 +1,81 @@
 package map_client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"strings"
-	"syscall"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/area"
 	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
 	"github.com/hectorgimenez/d2go/pkg/data/npc"
 	"github.com/hectorgimenez/d2go/pkg/data/object"
-	"github.com/hectorgimenez/koolo/internal/config"
 )
 
+// GetMapData returns the map data for the seed and difficulty, from the map cache if it's enabled and the seed is
+// known, otherwise it's requested to the configured provider
 func GetMapData(seed string, difficulty difficulty.Difficulty) (MapData, error) {
-	cmd := exec.Command("./tools/koolo-map.exe", config.Koolo.D2LoDPath, "-s", seed, "-d", getDifficultyAsNum(difficulty))
-	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
-	stdout, err := cmd.Output()
+	return getMapData(defaultProvider(), defaultCache(), seed, difficulty)
+}
+
+func getMapData(provider MapDataProvider, cache *Cache, seed string, difficulty difficulty.Difficulty) (MapData, error) {
+	if cache != nil {
+		if md, found := cache.Get(seed, difficulty); found {
+			return md, nil
+		}
+	}
+
+	md, err := provider.GetMapData(seed, difficulty)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching Map Data from Diablo II: LoD 1.13c game: %w", err)
+		return nil, err
+	}
+
+	if cache != nil {
+		// Cache is just an optimization, map data is still valid if it can not be stored
+		_ = cache.Put(seed, difficulty, md)
+	}
+
+	return md, nil
+}
+
+// Prewarm generates and caches the map data for the given seeds, already cached seeds are skipped
+func Prewarm(ctx context.Context, provider MapDataProvider, cache *Cache, seeds []string, difficulty difficulty.Difficulty) (int, error) {
+	generated := 0
+	for _, seed := range seeds {
+		if ctx.Err() != nil {
+			return generated, ctx.Err()
+		}
+		if _, found := cache.Get(seed, difficulty); found {
+			continue
+		}
+
+		md, err := provider.GetMapData(seed, difficulty)
+		if err != nil {
+			return generated, fmt.Errorf("seed %s: %w", seed, err)
+		}
+		if err = cache.Put(seed, difficulty, md); err != nil {
+			return generated, err
+		}
+		generated++
 	}
 
-	stdoutLines := strings.Split(string(stdout), "\r\n")
+	return generated, nil
+}
 
+// ParseMapData reads the koolo-map output, one level per line, it can be used to load map data saved to disk
+func ParseMapData(output []byte) MapData {
 	lvls := make([]serverLevel, 0)
-	for _, line := range stdoutLines {
+	for _, line := range strings.Split(string(output), "\n") {
 		var lvl serverLevel
-		err = json.Unmarshal([]byte(line), &lvl)
+		err := json.Unmarshal([]byte(strings.TrimSuffix(line, "\r")), &lvl)
 		// Discard empty lines or lines that don't contain level information
 		if err == nil && lvl.Type != "" && len(lvl.Map) > 0 {
 			lvls = append(lvls, lvl)
 		}
 	}
 
-	return lvls, nil
-}
-
-func getDifficultyAsNum(df difficulty.Difficulty) string {
-	switch df {
-	case difficulty.Normal:
-		return "0"
-	case difficulty.Nightmare:
-		return "1"
-	case difficulty.Hell:
-		return "2"
-	}
-
-	return "0"
+	return lvls
 }
 
 type MapData []serverLevel
@@ -181,6 +208,17 @@ func (md MapData) Origin(area area.ID) data.Position {
 	}
 }
 
+// LevelSize returns the size of the level without building its collision grid
+func (md MapData) LevelSize(id area.ID) (data.Position, bool) {
+	for _, lvl := range md {
+		if lvl.ID == int(id) {
+			return data.Position{X: lvl.Size.Width, Y: lvl.Size.Height}, true
+		}
+	}
+
+	return data.Position{}, false
+}
+
 func (md MapData) getLevel(area area.ID) serverLevel {
 	for _, level := range md {
 		if level.ID == int(area) {
@@ -0,0 +1,157 @@
+package map_client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+	"github.com/hectorgimenez/koolo/internal/config"
+)
+
+const (
+	ProviderLocal  = "local"
+	ProviderFile   = "file"
+	ProviderRemote = "remote"
+)
+
+var ErrMapDataNotFound = errors.New("map data not found")
+
+// MapDataProvider generates or fetches the map data for a seed and difficulty
+type MapDataProvider interface {
+	GetMapData(seed string, difficulty difficulty.Difficulty) (MapData, error)
+}
+
+var (
+	sharedProvider     MapDataProvider
+	sharedProviderOnce sync.Once
+)
+
+// defaultProvider returns the provider configured in koolo.yaml, local executable by default
+func defaultProvider() MapDataProvider {
+	sharedProviderOnce.Do(func() {
+		sharedProvider = NewProvider(config.Koolo.MapProvider.Type, config.Koolo.MapProvider.URL, config.Koolo.MapProvider.Token, config.Koolo.MapProvider.Directory)
+	})
+
+	return sharedProvider
+}
+
+func NewProvider(providerType, url, token, dir string) MapDataProvider {
+	switch providerType {
+	case ProviderRemote:
+		return NewHTTPProvider(url, token)
+	case ProviderFile:
+		return FileProvider{Directory: dir}
+	default:
+		return ExecutableProvider{D2LoDPath: config.Koolo.D2LoDPath}
+	}
+}
+
+// ExecutableProvider generates the map data running koolo-map, it requires Diablo II: LoD 1.13c installed
+type ExecutableProvider struct {
+	D2LoDPath string
+}
+
+func (p ExecutableProvider) GetMapData(seed string, difficulty difficulty.Difficulty) (MapData, error) {
+	cmd := exec.Command("./tools/koolo-map.exe", p.D2LoDPath, "-s", seed, "-d", getDifficultyAsNum(difficulty))
+	cmd.SysProcAttr = hiddenWindow()
+	stdout, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching Map Data from Diablo II: LoD 1.13c game: %w", err)
+	}
+
+	return ParseMapData(stdout), nil
+}
+
+func getDifficultyAsNum(df difficulty.Difficulty) string {
+	switch df {
+	case difficulty.Normal:
+		return "0"
+	case difficulty.Nightmare:
+		return "1"
+	case difficulty.Hell:
+		return "2"
+	}
+
+	return "0"
+}
+
+// FileProvider reads the map data from a directory, files can be map cache files or koolo-map output:
+// <difficulty>-<seed>.json.gz or <difficulty>-<seed>.jsonl. Mostly useful for fixtures.
+type FileProvider struct {
+	Directory string
+}
+
+func (p FileProvider) GetMapData(seed string, difficulty difficulty.Difficulty) (MapData, error) {
+	name := fmt.Sprintf("%s-%s", strings.ToLower(string(difficulty)), seed)
+
+	md, err := LoadCacheFile(filepath.Join(p.Directory, name+cacheFileSuffix))
+	if !errors.Is(err, os.ErrNotExist) {
+		return md, err
+	}
+
+	raw, err := os.ReadFile(filepath.Join(p.Directory, name+".jsonl"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrMapDataNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseMapData(raw), nil
+}
+
+// HTTPProvider fetches the map data from a koolo map server, response uses the koolo-map output format
+type HTTPProvider struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewHTTPProvider(url, token string) *HTTPProvider {
+	return &HTTPProvider{
+		url:   strings.TrimSuffix(url, "/"),
+		token: token,
+		// Map generation can take a while if the server doesn't have the seed cached
+		client: &http.Client{Timeout: time.Minute},
+	}
+}
+
+func (p *HTTPProvider) GetMapData(seed string, difficulty difficulty.Difficulty) (MapData, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/maps/%s/%s", p.url, url.PathEscape(string(difficulty)), url.PathEscape(seed)), nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching map data from %s: %w", p.url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading map data from %s: %w", p.url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("map server returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	md := ParseMapData(body)
+	if len(md) == 0 {
+		return nil, fmt.Errorf("map server returned no levels for seed %s", seed)
+	}
+
+	return md, nil
+}
@@ -0,0 +1,122 @@
+package map_client
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+	"golang.org/x/sync/singleflight"
+)
+
+// Server serves map data to the remote providers of other koolo instances, maps are served from the cache when
+// possible and generated by the provider otherwise
+type Server struct {
+	provider MapDataProvider
+	cache    *Cache
+	token    string
+	logger   *slog.Logger
+	group    singleflight.Group
+}
+
+func NewServer(provider MapDataProvider, cache *Cache, token string, logger *slog.Logger) *Server {
+	return &Server{
+		provider: provider,
+		cache:    cache,
+		token:    token,
+		logger:   logger,
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /maps/{difficulty}/{seed}", s.getMapData)
+
+	return mux
+}
+
+func (s *Server) getMapData(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	df, err := ParseDifficulty(r.PathValue("difficulty"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	seed := r.PathValue("seed")
+	for _, c := range seed {
+		if c < '0' || c > '9' {
+			http.Error(w, "invalid seed", http.StatusBadRequest)
+			return
+		}
+	}
+
+	start := time.Now()
+	// Several hosts usually ask for the same seed at the same time, generate it only once
+	result, err, _ := s.group.Do(fmt.Sprintf("%s-%s", df, seed), func() (interface{}, error) {
+		return getMapData(s.provider, s.cache, seed, df)
+	})
+	if err != nil {
+		s.logger.Error("Error getting map data", slog.String("seed", seed), slog.String("difficulty", string(df)), slog.Any("error", err))
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrMapDataNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	// Same format as koolo-map output, one level per line
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	for _, lvl := range result.(MapData) {
+		if err = enc.Encode(lvl); err != nil {
+			return
+		}
+	}
+	s.logger.Debug("Map data served", slog.String("seed", seed), slog.String("difficulty", string(df)), slog.String("remote", r.RemoteAddr), slog.Int64("ms", time.Since(start).Milliseconds()))
+}
+
+// authorized compares the token in constant time, so it can't be guessed from the response times
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+s.token)) == 1
+}
+
+// ParseDifficulty accepts the difficulty names in any case, anything else is an error
+func ParseDifficulty(name string) (difficulty.Difficulty, error) {
+	df := difficulty.Difficulty(strings.ToLower(strings.TrimSpace(name)))
+	if df != difficulty.Normal && df != difficulty.Nightmare && df != difficulty.Hell {
+		return "", fmt.Errorf("invalid difficulty %q, it must be normal, nightmare or hell", name)
+	}
+
+	return df, nil
+}
+
+// IsLoopback returns true if the listen address only accepts local connections, an empty host listens on every
+// interface
+func IsLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil || host == "" {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,96 @@
+package map_client
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+)
+
+func TestIsLoopback(t *testing.T) {
+	tests := map[string]bool{
+		":8090":            false,
+		"0.0.0.0:8090":     false,
+		"192.168.1.2:8090": false,
+		"[::]:8090":        false,
+		"127.0.0.1:8090":   true,
+		"localhost:8090":   true,
+		"[::1]:8090":       true,
+		"invalid":          false,
+	}
+
+	for addr, want := range tests {
+		if got := IsLoopback(addr); got != want {
+			t.Errorf("IsLoopback(%q) = %t, want %t", addr, got, want)
+		}
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	tests := map[string]difficulty.Difficulty{
+		"normal":    difficulty.Normal,
+		"Nightmare": difficulty.Nightmare,
+		" HELL ":    difficulty.Hell,
+		"hel":       "",
+		"":          "",
+		"2":         "",
+	}
+
+	for name, want := range tests {
+		got, err := ParseDifficulty(name)
+		if got != want || (err != nil) != (want == "") {
+			t.Errorf("ParseDifficulty(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+}
+
+// providerFunc adapts a function to MapDataProvider
+type providerFunc func(seed string, df difficulty.Difficulty) (MapData, error)
+
+func (f providerFunc) GetMapData(seed string, df difficulty.Difficulty) (MapData, error) {
+	return f(seed, df)
+}
+
+func TestServerRequiresTokenAndValidatesRequest(t *testing.T) {
+	provider := providerFunc(func(seed string, df difficulty.Difficulty) (MapData, error) {
+		return MapData{{Type: "map", ID: 1, Name: "Rogue Encampment", Map: [][]int{{1}}}}, nil
+	})
+	srv := httptest.NewServer(NewServer(provider, nil, "secret", slog.New(slog.NewTextHandler(io.Discard, nil))).Handler())
+	defer srv.Close()
+
+	tests := []struct {
+		path   string
+		auth   string
+		status int
+	}{
+		{path: "/maps/hell/1234", auth: "Bearer secret", status: http.StatusOK},
+		{path: "/maps/Hell/1234", auth: "Bearer secret", status: http.StatusOK},
+		{path: "/maps/hell/1234", status: http.StatusUnauthorized},
+		{path: "/maps/hell/1234", auth: "Bearer secre", status: http.StatusUnauthorized},
+		{path: "/maps/hell/1234", auth: "Bearer secret2", status: http.StatusUnauthorized},
+		{path: "/maps/torment/1234", auth: "Bearer secret", status: http.StatusBadRequest},
+		{path: "/maps/hell/12a4", auth: "Bearer secret", status: http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+tc.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.auth != "" {
+			req.Header.Set("Authorization", tc.auth)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s with %q: got status %d, want %d", tc.path, tc.auth, resp.StatusCode, tc.status)
+		}
+	}
+}
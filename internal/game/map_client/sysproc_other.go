@@ -0,0 +1,10 @@
+//go:build !windows
+
+package map_client
+
+import "syscall"
+
+// hiddenWindow has nothing to hide outside Windows, koolo-map can't run there but the remote and file providers can
+func hiddenWindow() *syscall.SysProcAttr {
+	return nil
+}
@@ -0,0 +1,7 @@
+package map_client
+
+import "syscall"
+
+func hiddenWindow() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{HideWindow: true}
+}
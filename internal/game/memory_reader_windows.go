@@ -76,6 +76,14 @@ func (gd *MemoryReader) GetCachedMapData(isNewGame bool) map_client.MapData {
 	return gd.cachedMapData
 }
 
+func (gd *MemoryReader) MapSeed() uint {
+	return gd.CachedMapSeed
+}
+
+func (gd *MemoryReader) GameAreaSize() (x, y int) {
+	return gd.GameAreaSizeX, gd.GameAreaSizeY
+}
+
 func (gd *MemoryReader) updateWindowPositionData() {
 	pos := win.WINDOWPLACEMENT{}
 	point := win.POINT{}
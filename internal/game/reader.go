@@ -0,0 +1,40 @@
+package game
+
+import (
+	"image"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/game/map_client"
+)
+
+// DataReader gives access to the game state, MemoryReader reads it from the game process memory
+type DataReader interface {
+	GetData(isNewGame bool) Data
+	GetCachedMapData(isNewGame bool) map_client.MapData
+	MapSeed() uint
+	GameAreaSize() (x, y int)
+	LegacyGraphics() bool
+	GetKeyBindings() data.KeyBindings
+}
+
+// Screenshotter captures the game window
+type Screenshotter interface {
+	Screenshot() image.Image
+	ScreenshotWithRelease() (image.Image, error)
+}
+
+// GameManager creates, joins and leaves games, Manager does it through the game menus
+type GameManager interface {
+	NewGame() error
+	CreateOnlineGame(gameCounter int) (string, error)
+	JoinOnlineGame(gameName, password string) error
+	ExitGame() error
+	InGame() bool
+}
+
+// Injector patches the game process memory, MemoryInjector is the implementation for the real game
+type Injector interface {
+	Load() error
+	Unload() error
+	RestoreMemory() error
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"github.com/inkeliz/w32"
+	"github.com/lxn/win"
+)
+
+func NewHID(gr *MemoryReader, gi *MemoryInjector) *HID {
+	return NewHIDWithSender(&windowSender{
+		gr: gr,
+		gi: gi,
+	})
+}
+
+// windowSender posts the input as window messages to the game window
+type windowSender struct {
+	gr *MemoryReader
+	gi *MemoryInjector
+}
+
+func (ws *windowSender) MovePointer(x, y int) {
+	ws.gr.updateWindowPositionData()
+	x = ws.gr.WindowLeftX + x
+	y = ws.gr.WindowTopY + y
+
+	ws.gi.CursorPos(x, y)
+	lParam := calculateLparam(x, y)
+	win.SendMessage(ws.gr.HWND, win.WM_NCHITTEST, 0, lParam)
+	win.SendMessage(ws.gr.HWND, win.WM_SETCURSOR, 0x000105A8, 0x2010001)
+	win.PostMessage(ws.gr.HWND, win.WM_MOUSEMOVE, 0, lParam)
+}
+
+func (ws *windowSender) MouseButton(btn MouseButton, x, y int, down bool) {
+	lParam := calculateLparam(ws.gr.WindowLeftX+x, ws.gr.WindowTopY+y)
+	msg := uint32(win.WM_LBUTTONUP)
+	switch {
+	case btn == RightButton && down:
+		msg = win.WM_RBUTTONDOWN
+	case btn == RightButton:
+		msg = win.WM_RBUTTONUP
+	case down:
+		msg = win.WM_LBUTTONDOWN
+	}
+
+	win.SendMessage(ws.gr.HWND, msg, 1, lParam)
+}
+
+func (ws *windowSender) Key(key byte, down bool) {
+	msg := uint32(win.WM_KEYUP)
+	if down {
+		msg = win.WM_KEYDOWN
+	}
+
+	win.PostMessage(ws.gr.HWND, msg, uintptr(key), calculatelParam(key, down))
+}
+
+func (ws *windowSender) OverrideModifier(modifier ModifierKey) {
+	ws.gi.OverrideGetKeyState(byte(modifier))
+}
+
+func (ws *windowSender) RestoreModifier() {
+	ws.gi.RestoreGetKeyState()
+}
+
+func calculateLparam(x, y int) uintptr {
+	return uintptr(y<<16 | x)
+}
+
+func calculatelParam(keyCode byte, down bool) uintptr {
+	scanCode := int(w32.MapVirtualKey(uint(keyCode), w32.MAPVK_VK_TO_VSC))
+	repeatCount := 1
+	extendedKeyFlag := 0
+	contextCode := 0
+	previousKeyState := 0
+	transitionState := 0
+	if !down {
+		transitionState = 1
+	}
+
+	lParam := uintptr((repeatCount & 0xFFFF) | (scanCode << 16) | (extendedKeyFlag << 24) | (contextCode << 29) | (previousKeyState << 30) | (transitionState << 31))
+	return lParam
+}
@@ -27,7 +27,7 @@ const (
 type Manager struct {
 	logger        *slog.Logger
 	beltManager   BeltManager
-	gameManager   *game.Manager
+	gameManager   game.GameManager
 	cfg           *config.CharacterCfg
 	lastRejuv     time.Time
 	lastRejuvMerc time.Time
@@ -36,7 +36,7 @@ type Manager struct {
 	lastMercHeal  time.Time
 }
 
-func NewHealthManager(logger *slog.Logger, beltManager BeltManager, gm *game.Manager, cfg *config.CharacterCfg) *Manager {
+func NewHealthManager(logger *slog.Logger, beltManager BeltManager, gm game.GameManager, cfg *config.CharacterCfg) *Manager {
 	return &Manager{
 		logger:      logger,
 		beltManager: beltManager,
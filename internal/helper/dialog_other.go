@@ -0,0 +1,13 @@
+//go:build !windows
+
+package helper
+
+import (
+	"fmt"
+	"os"
+)
+
+// ShowDialog prints the message, there are no dialogs outside Windows (simulator and tests)
+func ShowDialog(title, message string) {
+	fmt.Fprintf(os.Stderr, "%s: %s\n", title, message)
+}
@@ -1,9 +1,7 @@
 package helper
 
 import (
-	"golang.org/x/sys/windows"
 	"os"
-	"syscall"
 )
 
 func HasAdminPermission() bool {
@@ -11,10 +9,3 @@ func HasAdminPermission() bool {
 
 	return err == nil
 }
-
-func ShowDialog(title, message string) {
-	t, _ := syscall.UTF16PtrFromString(title)
-	txt, _ := syscall.UTF16PtrFromString(message)
-
-	windows.MessageBox(0, txt, t, 0)
-}
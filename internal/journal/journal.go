@@ -0,0 +1,114 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"reflect"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+// Event types that can be stored in the journal, events defined in other packages (like overseer terminal
+// messages) hold connections and are not serializable
+var eventTypes = typesOf(
+	event.UsedPotionEvent{},
+	event.GameCreatedEvent{},
+	event.GameFinishedEvent{},
+	event.RunFinishedEvent{},
+	event.ItemStashedEvent{},
+	event.RunStartedEvent{},
+	event.CompanionLeaderAttackEvent{},
+	event.CompanionRequestedTPEvent{},
+	event.InteractedToEvent{},
+	event.GamePausedEvent{},
+	event.LogEvent{},
+	event.AboutToStashItemEvent{},
+	event.IdentifiedItemEvent{},
+	event.StuckEvent{},
+	event.SessionBreakEvent{},
+	event.StashFullEvent{},
+)
+
+// Entry is a single line of the journal
+type Entry struct {
+	Type       string          `json:"type"`
+	Supervisor string          `json:"supervisor"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Message    string          `json:"message,omitempty"`
+	Image      string          `json:"image,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+func typesOf(events ...event.Event) map[string]reflect.Type {
+	types := make(map[string]reflect.Type)
+	for _, e := range events {
+		t := reflect.TypeOf(e)
+		types[t.Name()] = t
+	}
+
+	return types
+}
+
+// NewEntry serializes the event, returns false for event types that can not be journaled
+func NewEntry(e event.Event, withImage bool) (Entry, bool, error) {
+	eventType := reflect.TypeOf(e).Name()
+	if _, found := eventTypes[eventType]; !found {
+		return Entry{}, false, nil
+	}
+
+	// BaseEvent has no exported fields, so only the event specific fields end up in the payload
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return Entry{}, false, err
+	}
+
+	entry := Entry{
+		Type:       eventType,
+		Supervisor: e.Supervisor(),
+		OccurredAt: e.OccurredAt(),
+		Message:    e.Message(),
+		Payload:    payload,
+	}
+
+	if withImage && e.Image() != nil {
+		buf := new(bytes.Buffer)
+		if err = jpeg.Encode(buf, e.Image(), &jpeg.Options{Quality: 80}); err != nil {
+			return Entry{}, false, err
+		}
+		entry.Image = base64.StdEncoding.EncodeToString(buf.Bytes())
+	}
+
+	return entry, true, nil
+}
+
+// Event rebuilds the original event, keeping the original timestamp
+func (en Entry) Event() (event.Event, error) {
+	t, found := eventTypes[en.Type]
+	if !found {
+		return nil, fmt.Errorf("unknown event type: %s", en.Type)
+	}
+
+	var img image.Image
+	if en.Image != "" {
+		raw, err := base64.StdEncoding.DecodeString(en.Image)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding image: %w", err)
+		}
+		if img, err = jpeg.Decode(bytes.NewReader(raw)); err != nil {
+			return nil, fmt.Errorf("error decoding image: %w", err)
+		}
+	}
+
+	v := reflect.New(t)
+	if err := json.Unmarshal(en.Payload, v.Interface()); err != nil {
+		return nil, fmt.Errorf("error decoding %s payload: %w", en.Type, err)
+	}
+	v.Elem().FieldByName("BaseEvent").Set(reflect.ValueOf(event.Restored(en.Supervisor, en.Message, img, en.OccurredAt)))
+
+	return v.Elem().Interface().(event.Event), nil
+}
@@ -0,0 +1,135 @@
+package journal_test
+
+import (
+	"context"
+	"image"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/journal"
+)
+
+var occurredAt = time.Date(2024, 8, 1, 20, 30, 0, 0, time.UTC)
+
+func base(message string) event.BaseEvent {
+	return event.Restored("sorc", message, nil, occurredAt)
+}
+
+func TestEntryEventRoundTrip(t *testing.T) {
+	drop := data.Drop{
+		Item: data.Item{
+			UnitID:     42,
+			ID:         item.GetIDByName("Ring"),
+			Name:       "Ring",
+			Quality:    item.QualityUnique,
+			Identified: true,
+			Stats:      stat.Stats{{ID: stat.FasterCastRate, Value: 10}},
+		},
+		Rule:         "[type] == ring && [quality] == unique",
+		RuleFile:     "uniques.nip:3",
+		DropLocation: "Worldstone Keep Level 2",
+	}
+
+	events := []event.Event{
+		event.GameCreated(base("New game created"), "baal-12", "pass"),
+		event.RunStarted(base("Starting run"), "baal"),
+		event.RunFinished(base("Finished run"), "baal", event.FinishedOK),
+		event.GameFinished(base("chicken"), event.FinishedChicken),
+		event.ItemStashed(base("Item stashed"), drop, "baal"),
+		event.GamePaused(base("Game paused"), true),
+		event.Stuck(base("Stuck"), event.StuckBlockedByDoor, "open door", 2, area.ThroneOfDestruction, data.Position{X: 10, Y: 20}),
+		event.SessionBreak(base("Taking a break"), occurredAt.Add(time.Hour), "session length"),
+		event.StashFull(base("Stash is full"), []string{"Ring"}, map[int]int{1: 0, 2: 3}),
+	}
+
+	for _, e := range events {
+		entry, ok, err := journal.NewEntry(e, false)
+		if err != nil || !ok {
+			t.Fatalf("%T: expected entry, got %v %v", e, ok, err)
+		}
+
+		restored, err := entry.Event()
+		if err != nil {
+			t.Fatalf("%T: %v", e, err)
+		}
+		if !reflect.DeepEqual(e, restored) {
+			t.Errorf("%T: round trip mismatch\nexpected %+v\ngot      %+v", e, e, restored)
+		}
+	}
+}
+
+func TestEntryEventImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 4))
+	e := event.GameFinished(event.Restored("sorc", "death", img, occurredAt), event.FinishedDied)
+
+	entry, _, err := journal.NewEntry(e, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := entry.Event()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Image() == nil || restored.Image().Bounds() != img.Bounds() {
+		t.Errorf("expected an image with bounds %v, got %v", img.Bounds(), restored.Image())
+	}
+
+	withoutImage, _, err := journal.NewEntry(e, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withoutImage.Image != "" {
+		t.Error("image shouldn't be stored when disabled")
+	}
+}
+
+func TestRecorderReplay(t *testing.T) {
+	dir := t.TempDir()
+	r, err := journal.NewRecorder(dir, 0, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent := []event.Event{
+		event.RunStarted(base("Starting run"), "baal"),
+		event.UsedPotion(base(""), data.HealingPotion, false),
+		event.RunFinished(base("Finished run"), "baal", event.FinishedOK),
+	}
+	for _, e := range sent {
+		if err = r.Handle(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := journal.Files(dir, "sorc")
+	if len(files) != 1 {
+		t.Fatalf("expected 1 journal file, got %v", files)
+	}
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	replayed := make([]event.Event, 0)
+	n, err := journal.Replay(context.Background(), f, 0, func(_ context.Context, e event.Event) error {
+		replayed = append(replayed, e)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(sent) || !reflect.DeepEqual(sent, replayed) {
+		t.Errorf("expected %+v, got %+v", sent, replayed)
+	}
+}
@@ -0,0 +1,140 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+const globalJournal = "koolo"
+
+// Recorder writes every event into a journal file per supervisor, files are rotated when they reach maxSize,
+// keeping up to maxFiles rotated files (<supervisor>.1.jsonl is the most recent one)
+type Recorder struct {
+	dir        string
+	maxSize    int64
+	maxFiles   int
+	withImages bool
+
+	mu    sync.Mutex
+	files map[string]*os.File
+}
+
+func NewRecorder(dir string, maxSizeMB, maxFiles int, withImages bool) (*Recorder, error) {
+	if dir == "" {
+		dir = "journal"
+	}
+	// File 0 is the one being written, it would be removed on the first rotation
+	if maxSizeMB > 0 && maxFiles < 1 {
+		return nil, fmt.Errorf("journal maxFiles must be at least 1 when maxSizeMB is set, got %d", maxFiles)
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("error creating journal directory: %w", err)
+	}
+
+	return &Recorder{
+		dir:        dir,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxFiles:   maxFiles,
+		withImages: withImages,
+		files:      make(map[string]*os.File),
+	}, nil
+}
+
+func (r *Recorder) Handle(_ context.Context, e event.Event) error {
+	entry, ok, err := NewEntry(e, r.withImages)
+	if err != nil || !ok {
+		return err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	name := e.Supervisor()
+	if name == "" {
+		name = globalJournal
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.file(name)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing journal: %w", err)
+	}
+
+	return r.rotateIfNeeded(name, f)
+}
+
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for name, f := range r.files {
+		errs = append(errs, f.Close())
+		delete(r.files, name)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *Recorder) file(name string) (*os.File, error) {
+	if f, found := r.files[name]; found {
+		return f, nil
+	}
+
+	f, err := os.OpenFile(Path(r.dir, name, 0), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening journal: %w", err)
+	}
+	r.files[name] = f
+
+	return f, nil
+}
+
+func (r *Recorder) rotateIfNeeded(name string, f *os.File) error {
+	if r.maxSize <= 0 {
+		return nil
+	}
+
+	info, err := f.Stat()
+	if err != nil || info.Size() < r.maxSize {
+		return err
+	}
+
+	f.Close()
+	delete(r.files, name)
+
+	// Drop the oldest one and shift the rest, current file becomes number 1
+	os.Remove(Path(r.dir, name, r.maxFiles))
+	for i := r.maxFiles - 1; i >= 0; i-- {
+		if _, err = os.Stat(Path(r.dir, name, i)); err == nil {
+			if err = os.Rename(Path(r.dir, name, i), Path(r.dir, name, i+1)); err != nil {
+				return fmt.Errorf("error rotating journal: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Path returns the journal file for the supervisor, index 0 is the file being written
+func Path(dir, supervisor string, index int) string {
+	if index == 0 {
+		return filepath.Join(dir, supervisor+".jsonl")
+	}
+
+	return filepath.Join(dir, fmt.Sprintf("%s.%d.jsonl", supervisor, index))
+}
@@ -0,0 +1,76 @@
+package journal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+// Files returns the existing journal files for the supervisor, oldest first
+func Files(dir, supervisor string) []string {
+	files := make([]string, 0)
+	for i := 1; ; i++ {
+		if _, err := os.Stat(Path(dir, supervisor, i)); err != nil {
+			break
+		}
+		files = append([]string{Path(dir, supervisor, i)}, files...)
+	}
+	if _, err := os.Stat(Path(dir, supervisor, 0)); err == nil {
+		files = append(files, Path(dir, supervisor, 0))
+	}
+
+	return files
+}
+
+// Replay reads the journal and calls every handler for each event, in order. Speed is relative to the
+// original timing (2 is twice as fast), zero or negative replays as fast as possible.
+// Handler errors don't stop the replay, they are returned together at the end with the number of replayed events.
+func Replay(ctx context.Context, r io.Reader, speed float64, handlers ...event.Handler) (int, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) // Lines with screenshots can be big
+
+	replayed := 0
+	var handlerErrs []error
+	var previous time.Time
+	for sc.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(sc.Bytes(), &entry); err != nil {
+			return replayed, fmt.Errorf("error reading journal entry %d: %w", replayed+1, err)
+		}
+
+		e, err := entry.Event()
+		if err != nil {
+			return replayed, err
+		}
+
+		if speed > 0 && !previous.IsZero() {
+			wait := time.Duration(float64(entry.OccurredAt.Sub(previous)) / speed)
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return replayed, ctx.Err()
+			}
+		}
+		previous = entry.OccurredAt
+
+		for _, h := range handlers {
+			if err = h(ctx, e); err != nil {
+				handlerErrs = append(handlerErrs, fmt.Errorf("error handling %s: %w", entry.Type, err))
+			}
+		}
+		replayed++
+	}
+
+	if err := sc.Err(); err != nil {
+		return replayed, err
+	}
+
+	return replayed, errors.Join(handlerErrs...)
+}
@@ -0,0 +1,55 @@
+package ledger
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var csvHeader = []string{"occurredAt", "supervisor", "runName", "game", "gameStartedAt", "gameName", "area", "name", "quality", "ethereal", "identified", "rule", "ruleFile", "stats", "screenshot"}
+
+// WriteCSV exports the entries with one row per item, stats are joined in a single column as name=value
+func WriteCSV(w io.Writer, entries []Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		stats := make([]string, 0, len(e.Stats))
+		for _, s := range e.Stats {
+			stats = append(stats, s.Name+"="+strconv.Itoa(s.Value))
+		}
+
+		err := cw.Write([]string{
+			e.OccurredAt.Format(time.RFC3339),
+			e.Supervisor,
+			e.RunName,
+			strconv.Itoa(e.Game),
+			e.GameStartedAt.Format(time.RFC3339),
+			e.GameName,
+			e.Area,
+			e.Name,
+			e.Quality,
+			strconv.FormatBool(e.Ethereal),
+			strconv.FormatBool(e.Identified),
+			e.Rule,
+			e.RuleFile,
+			strings.Join(stats, "; "),
+			e.Screenshot,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func WriteJSON(w io.Writer, entries []Entry) error {
+	return json.NewEncoder(w).Encode(entries)
+}
@@ -0,0 +1,326 @@
+package ledger
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+const (
+	DefaultDirectory = "drops"
+
+	ledgerFile     = "ledger.jsonl"
+	screenshotsDir = "screenshots"
+	screenshotExt  = ".jpeg"
+)
+
+var ErrLedgerClosed = errors.New("drop ledger is closed")
+
+// Entry is a single stashed item, with the context it was found in
+type Entry struct {
+	Supervisor string    `json:"supervisor"`
+	OccurredAt time.Time `json:"occurredAt"`
+	RunName    string    `json:"runName,omitempty"`
+	// Game is the number of the game for the supervisor, it keeps counting after a restart from the last game in
+	// the ledger. GameStartedAt and GameName identify the game as well, the name is empty in single player.
+	Game          int       `json:"game,omitempty"`
+	GameStartedAt time.Time `json:"gameStartedAt"`
+	GameName      string    `json:"gameName,omitempty"`
+	Area          string    `json:"area,omitempty"`
+	Name          string    `json:"name"`
+	Quality       string    `json:"quality"`
+	Ethereal      bool      `json:"ethereal"`
+	Identified    bool      `json:"identified"`
+	Rule          string    `json:"rule,omitempty"`
+	RuleFile      string    `json:"ruleFile,omitempty"`
+	Stats         []Stat    `json:"stats"`
+	// Screenshot is the file name inside the screenshots directory, taken with the item tooltip visible
+	Screenshot string `json:"screenshot,omitempty"`
+}
+
+type Stat struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+	Layer int    `json:"layer,omitempty"`
+}
+
+// Ledger persists every stashed item of all the supervisors as JSON lines, entries are kept in memory as well
+type Ledger struct {
+	mu          sync.RWMutex
+	dir         string
+	screenshots bool
+	file        *os.File
+	closed      bool
+	entries     []Entry
+	// Run and game in progress for each supervisor, and the number of the last game created
+	runs        map[string]string
+	games       map[string]game
+	gameNumbers map[string]int
+}
+
+type game struct {
+	number    int
+	startedAt time.Time
+	name      string
+}
+
+func Open(dir string, screenshots bool) (*Ledger, error) {
+	if dir == "" {
+		dir = DefaultDirectory
+	}
+	if err := os.MkdirAll(filepath.Join(dir, screenshotsDir), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("error creating drop ledger directory: %w", err)
+	}
+
+	path := filepath.Join(dir, ledgerFile)
+	entries, err := readEntries(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening drop ledger: %w", err)
+	}
+
+	gameNumbers := make(map[string]int)
+	for _, e := range entries {
+		gameNumbers[e.Supervisor] = max(gameNumbers[e.Supervisor], e.Game)
+	}
+
+	return &Ledger{
+		dir:         dir,
+		screenshots: screenshots,
+		file:        f,
+		entries:     entries,
+		runs:        make(map[string]string),
+		games:       make(map[string]game),
+		gameNumbers: gameNumbers,
+	}, nil
+}
+
+// ReadEntries reads the ledger stored in the directory without opening it for writing, most recent first
+func ReadEntries(dir string, f Filter) ([]Entry, error) {
+	if dir == "" {
+		dir = DefaultDirectory
+	}
+	entries, err := readEntries(filepath.Join(dir, ledgerFile))
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Ledger{entries: entries}
+
+	return l.Entries(f), nil
+}
+
+func readEntries(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading drop ledger: %w", err)
+	}
+	defer f.Close()
+
+	entries := make([]Entry, 0)
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var e Entry
+		// A truncated last line (process killed while writing) is skipped, not fatal
+		if err = json.Unmarshal(sc.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, sc.Err()
+}
+
+// Handle tracks the run and game in progress and stores the stashed items, meant to be registered in the event listener
+func (l *Ledger) Handle(_ context.Context, e event.Event) error {
+	sup := e.Supervisor()
+
+	switch evt := e.(type) {
+	case event.GameCreatedEvent:
+		l.mu.Lock()
+		l.gameNumbers[sup]++
+		l.games[sup] = game{number: l.gameNumbers[sup], startedAt: evt.OccurredAt(), name: evt.Name}
+		l.runs[sup] = ""
+		l.mu.Unlock()
+	case event.RunStartedEvent:
+		l.mu.Lock()
+		l.runs[sup] = evt.RunName
+		l.mu.Unlock()
+	case event.ItemStashedEvent:
+		return l.stashed(evt)
+	}
+
+	return nil
+}
+
+func (l *Ledger) stashed(evt event.ItemStashedEvent) error {
+	i := evt.Item.Item
+	entry := Entry{
+		Supervisor: evt.Supervisor(),
+		OccurredAt: evt.OccurredAt(),
+		Area:       evt.Item.DropLocation,
+		Name:       string(i.Name),
+		Quality:    QualityName(i.Quality),
+		Ethereal:   i.Ethereal,
+		Identified: i.Identified,
+		Rule:       evt.Item.Rule,
+		RuleFile:   evt.Item.RuleFile,
+		Stats:      make([]Stat, 0, len(i.Stats)),
+	}
+	for _, s := range i.Stats {
+		entry.Stats = append(entry.Stats, Stat{Name: stat.StringStats[s.ID], Value: s.Value, Layer: s.Layer})
+	}
+
+	l.mu.RLock()
+	entry.RunName = l.runs[entry.Supervisor]
+	if evt.RunName != "" {
+		entry.RunName = evt.RunName
+	}
+	entry.Game = l.games[entry.Supervisor].number
+	entry.GameStartedAt = l.games[entry.Supervisor].startedAt
+	entry.GameName = l.games[entry.Supervisor].name
+	l.mu.RUnlock()
+
+	if l.screenshots && evt.Image() != nil {
+		name, err := l.saveScreenshot(evt)
+		if err != nil {
+			return err
+		}
+		entry.Screenshot = name
+	}
+
+	return l.append(entry)
+}
+
+func (l *Ledger) saveScreenshot(evt event.ItemStashedEvent) (string, error) {
+	name := fmt.Sprintf("%s-%s-%d%s", evt.Supervisor(), evt.OccurredAt().Format("20060102-150405"), evt.Item.Item.UnitID, screenshotExt)
+	f, err := os.Create(filepath.Join(l.dir, screenshotsDir, name))
+	if err != nil {
+		return "", fmt.Errorf("error saving drop screenshot: %w", err)
+	}
+	defer f.Close()
+
+	if err = jpeg.Encode(f, evt.Image(), &jpeg.Options{Quality: 80}); err != nil {
+		return "", fmt.Errorf("error saving drop screenshot: %w", err)
+	}
+
+	return name, nil
+}
+
+func (l *Ledger) append(e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return ErrLedgerClosed
+	}
+	if _, err = l.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing drop ledger: %w", err)
+	}
+	l.entries = append(l.entries, e)
+
+	return nil
+}
+
+// Filter selects ledger entries, zero values match everything. Text fields are case-insensitive, Name matches
+// any part of the item name. Game is the number of the game, usually combined with the supervisor.
+type Filter struct {
+	Supervisor string
+	Quality    string
+	RunName    string
+	Name       string
+	Game       int
+	From       time.Time
+	To         time.Time
+}
+
+func (f Filter) matches(e Entry) bool {
+	switch {
+	case f.Supervisor != "" && !strings.EqualFold(f.Supervisor, e.Supervisor):
+		return false
+	case f.Quality != "" && !strings.EqualFold(f.Quality, e.Quality):
+		return false
+	case f.RunName != "" && !strings.EqualFold(f.RunName, e.RunName):
+		return false
+	case f.Name != "" && !strings.Contains(strings.ToLower(e.Name), strings.ToLower(f.Name)):
+		return false
+	case f.Game != 0 && f.Game != e.Game:
+		return false
+	case !f.From.IsZero() && e.OccurredAt.Before(f.From):
+		return false
+	case !f.To.IsZero() && !e.OccurredAt.Before(f.To):
+		return false
+	}
+
+	return true
+}
+
+// Entries returns the entries matching the filter, most recent first
+func (l *Ledger) Entries(f Filter) []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := make([]Entry, 0)
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		if f.matches(l.entries[i]) {
+			entries = append(entries, l.entries[i])
+		}
+	}
+
+	return entries
+}
+
+// ScreenshotPath returns the path of a screenshot stored by the ledger, false if the name is not valid
+func (l *Ledger) ScreenshotPath(name string) (string, bool) {
+	if strings.ContainsAny(name, `/\`) || name == "." || name == ".." || !strings.HasSuffix(name, screenshotExt) {
+		return "", false
+	}
+
+	return filepath.Join(l.dir, screenshotsDir, name), true
+}
+
+// QualityName returns the quality as stored in the ledger, d2go doesn't name crafted items
+func QualityName(q item.Quality) string {
+	if q == item.QualityCrafted {
+		return "Crafted"
+	}
+
+	return q.ToString()
+}
+
+func (l *Ledger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+
+	return l.file.Close()
+}
@@ -0,0 +1,138 @@
+package ledger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/ledger"
+)
+
+func TestStashedItemsKeepTheirGame(t *testing.T) {
+	l, err := ledger.Open(t.TempDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	created := event.GameCreated(event.Text("sorc", "New game created"), "baal-12", "")
+	drop := data.Drop{Item: data.Item{Name: "Ring", Quality: item.QualityCrafted}}
+	for _, e := range []event.Event{created, event.ItemStashed(event.Text("sorc", "Item stashed"), drop, "baal")} {
+		if err = l.Handle(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries := l.Entries(ledger.Filter{})
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if !e.GameStartedAt.Equal(created.OccurredAt()) || e.GameName != "baal-12" {
+		t.Errorf("expected game baal-12 started at %s, got %s at %s", created.OccurredAt(), e.GameName, e.GameStartedAt)
+	}
+	if e.Quality != "Crafted" {
+		t.Errorf("expected Crafted quality, got %s", e.Quality)
+	}
+}
+
+func TestStashedItemsAreNumberedByGame(t *testing.T) {
+	dir := t.TempDir()
+	l, err := ledger.Open(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stash := func(l *ledger.Ledger, sup string, name item.Name, newGame bool) {
+		t.Helper()
+		events := []event.Event{event.ItemStashed(event.Text(sup, "Item stashed"), data.Drop{Item: data.Item{Name: name}}, "")}
+		if newGame {
+			events = append([]event.Event{event.GameCreated(event.Text(sup, "New game created"), "", "")}, events...)
+		}
+		for _, e := range events {
+			if err := l.Handle(context.Background(), e); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	stash(l, "sorc", "Ring", true)
+	stash(l, "sorc", "Amulet", false)
+	stash(l, "pala", "Jewel", true)
+	// Game without drops is counted as well
+	if err = l.Handle(context.Background(), event.GameCreated(event.Text("sorc", "New game created"), "", "")); err != nil {
+		t.Fatal(err)
+	}
+	stash(l, "sorc", "Charm", true)
+	l.Close()
+
+	// Numbers keep counting after a restart
+	l, err = ledger.Open(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	stash(l, "sorc", "Belt", true)
+
+	tests := []struct {
+		filter ledger.Filter
+		want   []item.Name
+	}{
+		{filter: ledger.Filter{Supervisor: "sorc", Game: 1}, want: []item.Name{"Amulet", "Ring"}},
+		{filter: ledger.Filter{Supervisor: "pala", Game: 1}, want: []item.Name{"Jewel"}},
+		{filter: ledger.Filter{Supervisor: "sorc", Game: 2}},
+		{filter: ledger.Filter{Supervisor: "sorc", Game: 3}, want: []item.Name{"Charm"}},
+		{filter: ledger.Filter{Supervisor: "sorc", Game: 4}, want: []item.Name{"Belt"}},
+		{filter: ledger.Filter{Game: 1}, want: []item.Name{"Jewel", "Amulet", "Ring"}},
+	}
+	for _, tc := range tests {
+		entries := l.Entries(tc.filter)
+		got := make([]item.Name, 0, len(entries))
+		for _, e := range entries {
+			got = append(got, item.Name(e.Name))
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("filter %+v: expected %v, got %v", tc.filter, tc.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("filter %+v: expected %v, got %v", tc.filter, tc.want, got)
+				break
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = ledger.WriteCSV(&buf, l.Entries(ledger.Filter{Supervisor: "sorc", Game: 4})); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0][3] != "game" || rows[1][3] != "4" {
+		t.Errorf("expected the game number in the CSV, got %v", rows)
+	}
+}
+
+func TestScreenshotPath(t *testing.T) {
+	l, err := ledger.Open(t.TempDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	for _, name := range []string{"", ".", "..", "../ledger.jsonl", "ledger.jsonl", "sub/drop.jpeg", `..\drop.jpeg`} {
+		if _, valid := l.ScreenshotPath(name); valid {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+	if _, valid := l.ScreenshotPath("sorc-20240101-120000-42.jpeg"); !valid {
+		t.Error("expected a stored screenshot name to be valid")
+	}
+}
@@ -1,11 +1,14 @@
 package koolo
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"image"
 	"log/slog"
 	"runtime/debug"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/hectorgimenez/koolo/cmd/koolo/log"
@@ -19,24 +22,41 @@ import (
 	"github.com/hectorgimenez/koolo/internal/helper"
 	"github.com/hectorgimenez/koolo/internal/pather"
 	"github.com/hectorgimenez/koolo/internal/run"
+	"github.com/hectorgimenez/koolo/internal/snapshot"
+	"github.com/hectorgimenez/koolo/internal/stats"
 	"github.com/hectorgimenez/koolo/internal/town"
 	"github.com/hectorgimenez/koolo/internal/ui"
 	"github.com/lxn/win"
 )
 
 type SupervisorManager struct {
-	logger         *slog.Logger
+	logger *slog.Logger
+	// mu guards the maps below, break timers and the HTTP handlers access them from other goroutines
+	mu             sync.RWMutex
 	supervisors    map[string]Supervisor
 	crashDetectors map[string]*game.CrashDetector
+	subscriptions  map[string][]event.SubscriptionID
 	eventListener  *event.Listener
+	// Supervisors stopped for a session break with the game closed, they are started again when the timer fires
+	breaks map[string]sessionBreak
+	// Supervisors being started, reserved until they are added to supervisors so they can't be started twice
+	starting map[string]struct{}
+}
+
+type sessionBreak struct {
+	nextStart time.Time
+	timer     *time.Timer
 }
 
 func NewSupervisorManager(logger *slog.Logger, eventListener *event.Listener) *SupervisorManager {
 	return &SupervisorManager{
 		logger:         logger,
 		supervisors:    make(map[string]Supervisor),
+		starting:       make(map[string]struct{}),
 		crashDetectors: make(map[string]*game.CrashDetector),
+		subscriptions:  make(map[string][]event.SubscriptionID),
 		eventListener:  eventListener,
+		breaks:         make(map[string]sessionBreak),
 	}
 }
 
@@ -54,9 +74,19 @@ func (mng *SupervisorManager) AvailableSupervisors() []string {
 func (mng *SupervisorManager) Start(supervisorName string) error {
 
 	// Avoid multiple instances of the supervisor - shitstorm prevention
-	if _, exists := mng.supervisors[supervisorName]; exists {
+	if !mng.reserve(supervisorName) {
 		return fmt.Errorf("supervisor %s is already running", supervisorName)
 	}
+	// Released when the supervisor is added, or when it fails before that
+	reserved := true
+	defer func() {
+		if reserved {
+			mng.release(supervisorName)
+		}
+	}()
+
+	// Started manually during a break, don't wait for the next session
+	mng.cancelBreak(supervisorName)
 
 	// Reload config to get the latest local changes before starting the supervisor
 	err := config.Load()
@@ -64,6 +94,20 @@ func (mng *SupervisorManager) Start(supervisorName string) error {
 		return fmt.Errorf("error loading config: %w", err)
 	}
 
+	for _, defErr := range config.RunDefinitionErrors {
+		mng.logger.Warn("Run definition skipped", slog.String("supervisor", supervisorName), slog.Any("error", defErr))
+	}
+
+	if cfg, found := config.Characters[supervisorName]; found {
+		problems := cfg.Runtime.PickitProblems
+		for _, p := range problems {
+			mng.logger.Warn("Pickit rule problem", slog.String("supervisor", supervisorName), slog.String("severity", p.Severity), slog.String("problem", p.String()))
+		}
+		if problems.Errors() > 0 {
+			return fmt.Errorf("pickit rules or quotas of %s have %d errors, check the character settings", supervisorName, problems.Errors())
+		}
+	}
+
 	supervisorLogger, err := log.NewEventLogger(config.Koolo.Debug.Log, config.Koolo.LogSaveDirectory, supervisorName)
 	if err != nil {
 		return err
@@ -131,12 +175,17 @@ func (mng *SupervisorManager) Start(supervisorName string) error {
 		return err
 	}
 
-	if oldCrashDetector, exists := mng.crashDetectors[supervisorName]; exists {
-		oldCrashDetector.Stop() // Stop the old crash detector if it exists
-	}
-
+	mng.mu.Lock()
+	oldCrashDetector, exists := mng.crashDetectors[supervisorName]
 	mng.supervisors[supervisorName] = supervisor
 	mng.crashDetectors[supervisorName] = crashDetector
+	delete(mng.starting, supervisorName)
+	reserved = false
+	mng.mu.Unlock()
+
+	if exists {
+		oldCrashDetector.Stop() // Stop the old crash detector if it exists
+	}
 
 	if config.Koolo.GameWindowArrangement {
 		go func() {
@@ -162,6 +211,11 @@ func (mng *SupervisorManager) Start(supervisorName string) error {
 	go crashDetector.Start()
 
 	err = supervisor.Start()
+	var breakErr SessionBreakError
+	if errors.As(err, &breakErr) {
+		mng.startAfterBreak(supervisorName, breakErr.NextStart)
+		return nil
+	}
 	if err != nil {
 		mng.logger.Error(fmt.Sprintf("error running supervisor %s: %s", supervisorName, err.Error()))
 	}
@@ -169,78 +223,171 @@ func (mng *SupervisorManager) Start(supervisorName string) error {
 	return nil
 }
 
+// startAfterBreak stops the supervisor closing the game, and starts it again for the next session
+func (mng *SupervisorManager) startAfterBreak(supervisorName string, nextStart time.Time) {
+	mng.logger.Info("Supervisor stopped for a break", slog.String("supervisor", supervisorName), slog.Time("nextStart", nextStart))
+	mng.Stop(supervisorName)
+
+	timer := time.AfterFunc(time.Until(nextStart), func() {
+		mng.logger.Info("Starting supervisor after the break", slog.String("supervisor", supervisorName))
+		if err := mng.Start(supervisorName); err != nil {
+			mng.logger.Error("Failed to start supervisor after the break", slog.String("supervisor", supervisorName), slog.Any("error", err))
+		}
+	})
+
+	mng.mu.Lock()
+	mng.breaks[supervisorName] = sessionBreak{nextStart: nextStart, timer: timer}
+	mng.mu.Unlock()
+}
+
+func (mng *SupervisorManager) cancelBreak(supervisorName string) {
+	mng.mu.Lock()
+	defer mng.mu.Unlock()
+
+	if b, found := mng.breaks[supervisorName]; found {
+		b.timer.Stop()
+		delete(mng.breaks, supervisorName)
+	}
+}
+
+// reserve marks the supervisor as starting, false if it's already running or being started
+func (mng *SupervisorManager) reserve(name string) bool {
+	mng.mu.Lock()
+	defer mng.mu.Unlock()
+
+	if _, found := mng.supervisors[name]; found {
+		return false
+	}
+	if _, found := mng.starting[name]; found {
+		return false
+	}
+	mng.starting[name] = struct{}{}
+
+	return true
+}
+
+func (mng *SupervisorManager) release(name string) {
+	mng.mu.Lock()
+	defer mng.mu.Unlock()
+
+	delete(mng.starting, name)
+}
+
+// supervisor returns the running supervisor with the given name
+func (mng *SupervisorManager) supervisor(name string) (Supervisor, bool) {
+	mng.mu.RLock()
+	defer mng.mu.RUnlock()
+
+	s, found := mng.supervisors[name]
+	return s, found
+}
+
+// runningSupervisors returns a copy of the running supervisors, so they can be iterated without holding the lock
+func (mng *SupervisorManager) runningSupervisors() map[string]Supervisor {
+	mng.mu.RLock()
+	defer mng.mu.RUnlock()
+
+	supervisors := make(map[string]Supervisor, len(mng.supervisors))
+	for name, s := range mng.supervisors {
+		supervisors[name] = s
+	}
+
+	return supervisors
+}
+
 func (mng *SupervisorManager) StopAll() {
-	for _, s := range mng.supervisors {
+	for _, s := range mng.runningSupervisors() {
 		s.Stop()
 	}
 }
 
 func (mng *SupervisorManager) StopAllByName() {
-	for name, _ := range mng.supervisors {
+	for name := range mng.runningSupervisors() {
 		mng.Stop(name)
 	}
+
+	mng.mu.RLock()
+	names := make([]string, 0, len(mng.breaks))
+	for name := range mng.breaks {
+		names = append(names, name)
+	}
+	mng.mu.RUnlock()
+
+	for _, name := range names {
+		mng.cancelBreak(name)
+	}
 }
 
 func (mng *SupervisorManager) Stop(supervisor string) {
+	mng.cancelBreak(supervisor)
 
+	// Remove it from the maps first, the supervisor and crash detector are stopped without holding the lock
+	mng.mu.Lock()
 	s, found := mng.supervisors[supervisor]
+	cd, cdFound := mng.crashDetectors[supervisor]
+	subscriptions := mng.subscriptions[supervisor]
 	if found {
+		delete(mng.supervisors, supervisor)
+		delete(mng.crashDetectors, supervisor)
+		delete(mng.subscriptions, supervisor)
+	}
+	mng.mu.Unlock()
 
+	if found {
 		// Stop the Supervisor
 		s.Stop()
 
-		// Delete him from the list of Supervisors
-		delete(mng.supervisors, supervisor)
-
-		if cd, ok := mng.crashDetectors[supervisor]; ok {
+		if cdFound {
 			cd.Stop()
-			delete(mng.crashDetectors, supervisor)
+		}
+
+		for _, id := range subscriptions {
+			mng.eventListener.Unregister(id)
 		}
 	}
 }
 
 func (mng *SupervisorManager) TogglePause(supervisor string) {
-	s, found := mng.supervisors[supervisor]
+	s, found := mng.supervisor(supervisor)
 	if found {
 		s.TogglePause()
 	}
 }
 
 func (mng *SupervisorManager) Status(characterName string) Stats {
-	for name, supervisor := range mng.supervisors {
-		if name == characterName {
-			return supervisor.Stats()
-		}
+	if supervisor, found := mng.supervisor(characterName); found {
+		return supervisor.Stats()
+	}
+
+	mng.mu.RLock()
+	defer mng.mu.RUnlock()
+
+	if b, found := mng.breaks[characterName]; found {
+		return Stats{SupervisorStatus: OnBreak, NextSessionAt: b.nextStart}
 	}
 
 	return Stats{}
 }
 
 func (mng *SupervisorManager) GetData(characterName string) game.Data {
-	for name, supervisor := range mng.supervisors {
-		if name == characterName {
-			return supervisor.GetData()
-		}
+	if supervisor, found := mng.supervisor(characterName); found {
+		return supervisor.GetData()
 	}
 
 	return game.Data{}
 }
 
 func (mng *SupervisorManager) GetImg(supervisorName string) (image.Image, error) {
-	for name, supervisor := range mng.supervisors {
-		if name == supervisorName {
-			return supervisor.GetImg()
-		}
+	if supervisor, found := mng.supervisor(supervisorName); found {
+		return supervisor.GetImg()
 	}
 
 	return nil, nil
 }
 
 func (mng *SupervisorManager) GetMapSeed(supervisorName string) string {
-	for name, supervisor := range mng.supervisors {
-		if name == supervisorName {
-			return supervisor.GetMapSeed()
-		}
+	if supervisor, found := mng.supervisor(supervisorName); found {
+		return supervisor.GetMapSeed()
 	}
 
 	return ""
@@ -276,6 +423,7 @@ func (mng *SupervisorManager) buildSupervisor(supervisorName string, logger *slo
 		Supervisor:    supervisorName,
 		Logger:        logger,
 		Reader:        gr,
+		Screenshotter: gr,
 		HID:           hidM,
 		Injector:      gi,
 		Manager:       gm,
@@ -294,16 +442,32 @@ func (mng *SupervisorManager) buildSupervisor(supervisorName string, logger *slo
 
 	ab := action.NewBuilder(c, sm, bm, char)
 	bot := NewBot(logger, hm, ab, c, supervisorName)
+	if config.Koolo.Debug.Snapshots {
+		bot.RecordSnapshots(snapshot.NewRecorder(snapshot.DefaultDirectory, snapshot.DefaultInterval))
+	}
 	runFactory := run.NewFactory(logger, ab, char, bm, c)
 
 	statsHandler := NewStatsHandler(supervisorName, logger)
-	mng.eventListener.Register(statsHandler.Handle)
+	// Stats must not lose any event, the handler is fast so its queue never grows much
+	subscriptions := []event.SubscriptionID{
+		mng.eventListener.Register(statsHandler.Handle, event.WithOverflowPolicy(event.Block)),
+		// Items would be lost if the bot keeps playing, it waits until the user makes room and resumes it
+		event.Subscribe(mng.eventListener, func(_ context.Context, e event.StashFullEvent) error {
+			if e.Supervisor() == supervisorName {
+				bot.Pause()
+			}
+			return nil
+		}),
+	}
+	mng.mu.Lock()
+	mng.subscriptions[supervisorName] = subscriptions
+	mng.mu.Unlock()
 
 	var supervisor Supervisor
 	if config.Characters[supervisorName].Companion.Enabled {
-		supervisor, err = NewCompanionSupervisor(supervisorName, bot, runFactory, statsHandler, c, pid, uintptr(hwnd))
+		supervisor, err = NewCompanionSupervisor(supervisorName, bot, runFactory, statsHandler, c, gr, pid, uintptr(hwnd))
 	} else {
-		supervisor, err = NewSinglePlayerSupervisor(supervisorName, bot, runFactory, statsHandler, c, pid, uintptr(hwnd))
+		supervisor, err = NewSinglePlayerSupervisor(supervisorName, bot, runFactory, statsHandler, c, gr, pid, uintptr(hwnd))
 	}
 
 	if err != nil {
@@ -316,10 +480,20 @@ func (mng *SupervisorManager) buildSupervisor(supervisorName string, logger *slo
 }
 
 func (mng *SupervisorManager) GetSupervisorStats(supervisor string) Stats {
-	if mng.supervisors[supervisor] == nil {
+	s, found := mng.supervisor(supervisor)
+	if !found || s == nil {
 		return Stats{}
 	}
-	return mng.supervisors[supervisor].Stats()
+	return s.Stats()
+}
+
+// GetSupervisorHistory returns the persisted stats of a running supervisor, nil if it's not running
+func (mng *SupervisorManager) GetSupervisorHistory(supervisor string) *stats.Store {
+	s, found := mng.supervisor(supervisor)
+	if !found || s == nil {
+		return nil
+	}
+	return s.History()
 }
 
 func (mng *SupervisorManager) rearrangeWindows() {
@@ -340,7 +514,7 @@ func (mng *SupervisorManager) rearrangeWindows() {
 	)
 
 	var column, row int32
-	for _, sp := range mng.supervisors {
+	for _, sp := range mng.runningSupervisors() {
 		// reminder that columns are vertical (they go up and down) and rows are horizontal (they go left and right)
 		if column > maxColumns {
 			column = 0
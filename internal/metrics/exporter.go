@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+var (
+	gamesCreated = NewCounter("koolo_games_created_total", "Number of games created.", "supervisor")
+	runsFinished = NewCounter("koolo_runs_finished_total", "Number of runs finished by reason.", "supervisor", "run", "reason")
+	chickens     = NewCounter("koolo_chickens_total", "Number of games finished by chicken, including merc chicken.", "supervisor", "reason")
+	deaths       = NewCounter("koolo_deaths_total", "Number of games finished by character death.", "supervisor")
+	potionsUsed  = NewCounter("koolo_potions_used_total", "Number of potions used by type.", "supervisor", "potion_type", "merc")
+	itemsStashed = NewCounter("koolo_items_stashed_total", "Number of items stashed by quality.", "supervisor", "quality")
+	stuck        = NewCounter("koolo_stuck_total", "Number of times the character got stuck while moving, by reason and recovery.", "supervisor", "reason", "recovery")
+	stashFull    = NewCounter("koolo_stash_full_total", "Number of times items couldn't be stashed because the stash was full.", "supervisor")
+	gameLoop     = NewHistogram(
+		"koolo_game_loop_duration_seconds",
+		"Time spent reading game data and executing the next action step on each bot loop iteration.",
+		[]float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+		"supervisor",
+	)
+
+	// Run in progress for each supervisor, needed to know which run was interrupted by a death or chicken
+	currentRuns   = make(map[string]string)
+	currentRunsMu sync.Mutex
+)
+
+// Handle updates the counters from the events, meant to be registered in the event listener
+func Handle(_ context.Context, e event.Event) error {
+	sup := e.Supervisor()
+
+	switch evt := e.(type) {
+	case event.GameCreatedEvent:
+		gamesCreated.Inc(sup)
+	case event.RunStartedEvent:
+		setCurrentRun(sup, evt.RunName)
+	case event.RunFinishedEvent:
+		// The run goes on after a skipped action, it will be finished later
+		if evt.ActionSkipped {
+			return nil
+		}
+		runsFinished.Inc(sup, evt.RunName, string(evt.Reason))
+		setCurrentRun(sup, "")
+	case event.GameFinishedEvent:
+		if runName := setCurrentRun(sup, ""); runName != "" {
+			runsFinished.Inc(sup, runName, string(evt.Reason))
+		}
+		switch evt.Reason {
+		case event.FinishedChicken, event.FinishedMercChicken:
+			chickens.Inc(sup, string(evt.Reason))
+		case event.FinishedDied:
+			deaths.Inc(sup)
+		}
+	case event.UsedPotionEvent:
+		potionsUsed.Inc(sup, string(evt.PotionType), strconv.FormatBool(evt.OnMerc))
+	case event.ItemStashedEvent:
+		itemsStashed.Inc(sup, evt.Item.Item.Quality.ToString())
+	case event.StuckEvent:
+		stuck.Inc(sup, string(evt.Reason), evt.Recovery)
+	case event.StashFullEvent:
+		stashFull.Inc(sup)
+	}
+
+	return nil
+}
+
+// setCurrentRun stores the new run in progress and returns the previous one
+func setCurrentRun(supervisor, runName string) string {
+	currentRunsMu.Lock()
+	defer currentRunsMu.Unlock()
+
+	previous := currentRuns[supervisor]
+	currentRuns[supervisor] = runName
+
+	return previous
+}
+
+// ObserveGameLoop records the duration of a single bot loop iteration
+func ObserveGameLoop(supervisor string, d time.Duration) {
+	gameLoop.ObserveDuration(d, supervisor)
+}
+
+// Write exposes all the metrics in Prometheus text format, extra gauges can be added by the caller for values
+// owned by other packages (like supervisor status)
+func Write(w io.Writer, gauges ...*GaugeFunc) {
+	gamesCreated.writeTo(w)
+	runsFinished.writeTo(w)
+	chickens.writeTo(w)
+	deaths.writeTo(w)
+	potionsUsed.writeTo(w)
+	itemsStashed.writeTo(w)
+	stuck.writeTo(w)
+	stashFull.writeTo(w)
+	gameLoop.writeTo(w)
+
+	for _, g := range gauges {
+		g.writeTo(w)
+	}
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+func TestFormatLabelsEscaping(t *testing.T) {
+	got := formatLabels([]string{"name"}, []string{"Ñ \"quoted\" back\\slash\nnew line"})
+	expected := `{name="Ñ \"quoted\" back\\slash\nnew line"}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestSkippedActionDoesNotFinishTheRun(t *testing.T) {
+	sup := "metrics-test"
+	_ = Handle(context.Background(), event.RunStarted(event.Text(sup, ""), "pit"))
+	_ = Handle(context.Background(), event.RunActionSkipped(event.Text(sup, ""), "pit"))
+	_ = Handle(context.Background(), event.RunFinished(event.Text(sup, ""), "pit", event.FinishedOK))
+
+	buf := &bytes.Buffer{}
+	runsFinished.writeTo(buf)
+	out := buf.String()
+	if !strings.Contains(out, `supervisor="metrics-test",run="pit",reason="ok"} 1`) {
+		t.Errorf("expected a successful run, got:\n%s", out)
+	}
+	if strings.Contains(out, `supervisor="metrics-test",run="pit",reason="error"`) {
+		t.Errorf("skipped action should not be counted as a finished run, got:\n%s", out)
+	}
+}
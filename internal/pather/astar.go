@@ -0,0 +1,156 @@
+package pather
+
+// searchBuffers are reused between searches to avoid allocating on every path, a generation counter marks which
+// values belong to the current search so buffers don't need to be cleared.
+type searchBuffers struct {
+	generation uint32
+	reached    []uint32
+	closed     []uint32
+	cost       []float64
+	parent     []int32
+	open       openList
+}
+
+// findPath runs A* over the World tiles, from and to are indexes. Path is returned from destination to origin, both
+// included, the order MoveThroughPath and the steps expect.
+func (w *World) findPath(from, to int) ([]int, bool) {
+	b := &w.search
+	b.reset(len(w.kinds))
+	gen := b.generation
+
+	toX, toY := to%w.width, to/w.width
+	heuristic := func(idx int) float64 {
+		return float64(abs(idx%w.width-toX) + abs(idx/w.width-toY))
+	}
+
+	b.reached[from] = gen
+	b.cost[from] = 0
+	b.parent[from] = -1
+	b.open.push(openNode{idx: int32(from), priority: heuristic(from)})
+
+	for len(b.open) > 0 {
+		current := int(b.open.pop().idx)
+		if b.closed[current] == gen {
+			continue
+		}
+		b.closed[current] = gen
+
+		if current == to {
+			return b.path(to), true
+		}
+
+		x, y := current%w.width, current/w.width
+		for _, m := range allowedMovement {
+			nX, nY := x+m[0], y+m[1]
+			if !w.inside(nX, nY) {
+				continue
+			}
+
+			next := w.index(nX, nY)
+			if b.closed[next] == gen {
+				continue
+			}
+			cost := w.cost(next)
+			if cost < 0 {
+				continue
+			}
+
+			cost += b.cost[current]
+			if b.reached[next] == gen && cost >= b.cost[next] {
+				continue
+			}
+			b.reached[next] = gen
+			b.cost[next] = cost
+			b.parent[next] = int32(current)
+			b.open.push(openNode{idx: int32(next), priority: cost + heuristic(next)})
+		}
+	}
+
+	return nil, false
+}
+
+func (b *searchBuffers) reset(size int) {
+	if len(b.reached) != size {
+		b.reached = make([]uint32, size)
+		b.closed = make([]uint32, size)
+		b.cost = make([]float64, size)
+		b.parent = make([]int32, size)
+		b.generation = 0
+	}
+
+	b.generation++
+	// Overflow, buffers contain values from old generations that could be confused with the current one
+	if b.generation == 0 {
+		clear(b.reached)
+		clear(b.closed)
+		b.generation = 1
+	}
+	b.open = b.open[:0]
+}
+
+func (b *searchBuffers) path(to int) []int {
+	path := make([]int, 0)
+	for idx := int32(to); idx != -1; idx = b.parent[idx] {
+		path = append(path, int(idx))
+	}
+
+	return path
+}
+
+type openNode struct {
+	idx      int32
+	priority float64
+}
+
+// openList is a binary min-heap, implemented here instead of using container/heap to avoid the interface conversions
+type openList []openNode
+
+func (l *openList) push(n openNode) {
+	*l = append(*l, n)
+	h := *l
+	i := len(h) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if h[parent].priority <= h[i].priority {
+			break
+		}
+		h[parent], h[i] = h[i], h[parent]
+		i = parent
+	}
+}
+
+func (l *openList) pop() openNode {
+	h := *l
+	top := h[0]
+	last := len(h) - 1
+	h[0] = h[last]
+	h = h[:last]
+
+	i := 0
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+		if left < len(h) && h[left].priority < h[smallest].priority {
+			smallest = left
+		}
+		if right < len(h) && h[right].priority < h[smallest].priority {
+			smallest = right
+		}
+		if smallest == i {
+			break
+		}
+		h[i], h[smallest] = h[smallest], h[i]
+		i = smallest
+	}
+	*l = h
+
+	return top
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
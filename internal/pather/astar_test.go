@@ -0,0 +1,138 @@
+package pather
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/beefsack/go-astar"
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/koolo/internal/game"
+)
+
+func TestApplyOverridesOnlyUpdatesChangedTiles(t *testing.T) {
+	w := newWorld(5, 5)
+	w.SetKind(KindBlocker, 1, 1)
+	w.SetKind(KindBlocker, 2, 2)
+	w.ApplyOverrides()
+
+	w.SetKind(KindBlocker, 2, 2)
+	w.SetKind(KindDoor, 3, 3)
+	if w.Kind(1, 1) != KindPlain || w.Kind(3, 3) != KindDoor {
+		t.Errorf("Kind should return the kinds for the next path, got %d and %d", w.Kind(1, 1), w.Kind(3, 3))
+	}
+	w.ApplyOverrides()
+
+	if w.kinds[w.index(1, 1)] != KindPlain {
+		t.Errorf("override not set again should be reverted, got kind %d", w.kinds[w.index(1, 1)])
+	}
+	if w.kinds[w.index(2, 2)] != KindBlocker || w.kinds[w.index(3, 3)] != KindDoor {
+		t.Errorf("overrides not applied, got kinds %d and %d", w.kinds[w.index(2, 2)], w.kinds[w.index(3, 3)])
+	}
+	if len(w.overrides) != 2 || w.overrides[w.index(2, 2)] != KindPlain {
+		t.Errorf("expected the original kind of 2 tiles, got %v", w.overrides)
+	}
+}
+
+// benchmarkLevels are random outdoor levels the size of the big ones, where pathing is slower: cows and Outer Steppes
+var benchmarkLevels = []struct {
+	name          string
+	width, height int
+}{
+	{name: "cows", width: 600, height: 600},
+	{name: "outer_steppes", width: 880, height: 480},
+}
+
+// BenchmarkGetPath compares the previous go-astar search with the grid-native one, walking from corner to corner
+func BenchmarkGetPath(b *testing.B) {
+	for _, lvl := range benchmarkLevels {
+		grid := benchmarkGrid(lvl.width, lvl.height)
+		d := game.Data{Data: data.Data{PlayerUnit: data.PlayerUnit{Area: area.MooMooFarm}}}
+		w := parseWorld(grid, d)
+		from, to := w.index(5, 5), w.index(lvl.width-6, lvl.height-6)
+
+		b.Run(lvl.name+"/go-astar", func(b *testing.B) {
+			tiles := newLegacyTiles(&w)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, _, found := astar.Path(tiles[from], tiles[to]); !found {
+					b.Fatal("path not found")
+				}
+			}
+		})
+
+		b.Run(lvl.name+"/grid", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, found := w.findPath(from, to); !found {
+					b.Fatal("path not found")
+				}
+			}
+		})
+	}
+}
+
+// benchmarkGrid returns an open level with random walls, the same seed is used so results can be compared
+func benchmarkGrid(width, height int) [][]bool {
+	r := rand.New(rand.NewSource(1))
+	grid := make([][]bool, height)
+	for y := range grid {
+		grid[y] = make([]bool, width)
+		for x := range grid[y] {
+			grid[y][x] = true
+		}
+	}
+
+	for i := 0; i < width*height/2000; i++ {
+		x, y := r.Intn(width-20)+10, r.Intn(height-20)+10
+		length := r.Intn(40) + 10
+		for l := 0; l < length; l++ {
+			if r.Intn(2) == 0 && x+l < width-10 {
+				grid[y][x+l] = false
+			} else if y+l < height-10 {
+				grid[y+l][x] = false
+			}
+		}
+	}
+
+	return grid
+}
+
+// legacyTile is the tile graph used with go-astar before the grid-native search, only kept to benchmark against it
+type legacyTile struct {
+	x, y  int
+	cost  float64
+	tiles []*legacyTile
+	w     *World
+}
+
+func newLegacyTiles(w *World) []*legacyTile {
+	tiles := make([]*legacyTile, len(w.kinds))
+	for idx := range w.kinds {
+		if cost := w.cost(idx); cost >= 0 {
+			tiles[idx] = &legacyTile{x: idx % w.width, y: idx / w.width, cost: cost, tiles: tiles, w: w}
+		}
+	}
+
+	return tiles
+}
+
+func (t *legacyTile) PathNeighbors() []astar.Pather {
+	neighbors := make([]astar.Pather, 0, 4)
+	for _, m := range allowedMovement {
+		x, y := t.x+m[0], t.y+m[1]
+		if t.w.inside(x, y) && t.tiles[t.w.index(x, y)] != nil {
+			neighbors = append(neighbors, t.tiles[t.w.index(x, y)])
+		}
+	}
+
+	return neighbors
+}
+
+func (t *legacyTile) PathNeighborCost(to astar.Pather) float64 {
+	return to.(*legacyTile).cost
+}
+
+func (t *legacyTile) PathEstimatedCost(to astar.Pather) float64 {
+	toT := to.(*legacyTile)
+	return float64(abs(toT.x-t.x) + abs(toT.y-t.y))
+}
@@ -0,0 +1,104 @@
+package pather
+
+import (
+	"slices"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/game"
+)
+
+// BaalThronePosition is the spot in front of Baal's throne where we wait for the waves, they spawn right there
+var BaalThronePosition = data.Position{
+	X: 15095,
+	Y: 5042,
+}
+
+var duranceFires = []object.Name{object.HellFire1, object.HellFire2, object.HellFire3}
+
+// DangerZone is a circular area with an extra walking cost, the cost decreases with the distance to the center
+type DangerZone struct {
+	Position data.Position
+	Radius   int
+	Cost     float64
+}
+
+// DangerZones returns the zones a walking character should avoid, based on the danger layers enabled for the character
+func DangerZones(d game.Data, cfg *config.CharacterCfg) []DangerZone {
+	danger := cfg.Pathing.Danger
+	if !danger.Enabled {
+		return nil
+	}
+
+	zones := make([]DangerZone, 0)
+	for _, m := range d.Monsters.Enemies() {
+		if layerEnabled(danger.Elites) && m.IsElite() {
+			zones = append(zones, DangerZone{Position: m.Position, Radius: danger.Elites.Radius, Cost: danger.Elites.Cost})
+		}
+		if layerEnabled(danger.Immunities) && slices.ContainsFunc(danger.Resists, m.IsImmune) {
+			zones = append(zones, DangerZone{Position: m.Position, Radius: danger.Immunities.Radius, Cost: danger.Immunities.Cost})
+		}
+	}
+
+	if layerEnabled(danger.BaalWaves) && d.PlayerUnit.Area == area.ThroneOfDestruction {
+		zones = append(zones, DangerZone{Position: BaalThronePosition, Radius: danger.BaalWaves.Radius, Cost: danger.BaalWaves.Cost})
+	}
+
+	if layerEnabled(danger.DuranceFire) {
+		for _, o := range d.Objects {
+			if slices.Contains(duranceFires, o.Name) {
+				zones = append(zones, DangerZone{Position: o.Position, Radius: danger.DuranceFire.Radius, Cost: danger.DuranceFire.Cost})
+			}
+		}
+	}
+
+	return zones
+}
+
+func layerEnabled(l config.DangerLayer) bool {
+	return l.Radius > 0 && l.Cost > 0
+}
+
+// setDanger applies the extra cost of the danger zones to the World. Zones are usually the same between consecutive
+// paths, costs are only updated when they change and only around the previous and the new zones. Costs of
+// overlapping zones are added, so bigger packs are avoided harder.
+func (w *World) setDanger(d game.Data, zones []DangerZone, cgOffset data.Position) {
+	relativeZones := make([]DangerZone, 0, len(zones))
+	for _, z := range zones {
+		x, y := relativePosition(d, z.Position, cgOffset)
+		relativeZones = append(relativeZones, DangerZone{Position: data.Position{X: x, Y: y}, Radius: z.Radius, Cost: z.Cost})
+	}
+	if slices.Equal(relativeZones, w.dangerZones) {
+		return
+	}
+
+	if w.danger == nil {
+		w.danger = make([]float64, len(w.kinds))
+	}
+	for _, z := range w.dangerZones {
+		w.forEachDangerTile(z, func(idx int, _ float64) {
+			w.danger[idx] = 0
+		})
+	}
+	for _, z := range relativeZones {
+		w.forEachDangerTile(z, func(idx int, cost float64) {
+			w.danger[idx] += cost
+		})
+	}
+	w.dangerZones = relativeZones
+}
+
+// forEachDangerTile calls fn with every tile inside the zone and its cost, decreasing with the distance to the center
+func (w *World) forEachDangerTile(z DangerZone, fn func(idx int, cost float64)) {
+	for x := z.Position.X - z.Radius; x <= z.Position.X+z.Radius; x++ {
+		for y := z.Position.Y - z.Radius; y <= z.Position.Y+z.Radius; y++ {
+			distance := DistanceFromPoint(z.Position, data.Position{X: x, Y: y})
+			if distance > z.Radius || !w.inside(x, y) {
+				continue
+			}
+			fn(w.index(x, y), z.Cost*(1-float64(distance)/float64(z.Radius+1)))
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package pather_test
+
+import (
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/npc"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/pather"
+)
+
+func dangerCfg() *config.CharacterCfg {
+	cfg := &config.CharacterCfg{}
+	cfg.Pathing.Danger.Enabled = true
+	cfg.Pathing.Danger.Elites = config.DangerLayer{Radius: 8, Cost: 20}
+	cfg.Pathing.Danger.Immunities = config.DangerLayer{Radius: 8, Cost: 30}
+	cfg.Pathing.Danger.Resists = []stat.Resist{stat.ColdImmune}
+	cfg.Pathing.Danger.BaalWaves = config.DangerLayer{Radius: 15, Cost: 50}
+	cfg.Pathing.Danger.DuranceFire = config.DangerLayer{Radius: 4, Cost: 50}
+
+	return cfg
+}
+
+func monster(position data.Position, monsterType data.MonsterType, stats map[stat.ID]int) data.Monster {
+	m := data.Monster{Name: npc.Zombie, Type: monsterType, Position: position, Stats: map[stat.ID]int{stat.Life: 100}}
+	for id, value := range stats {
+		m.Stats[id] = value
+	}
+
+	return m
+}
+
+func TestDangerZonesElites(t *testing.T) {
+	elite := data.Position{X: 100, Y: 100}
+	d := game.Data{Data: data.Data{Monsters: data.Monsters{
+		monster(elite, data.MonsterTypeChampion, nil),
+		monster(data.Position{X: 200, Y: 200}, data.MonsterTypeNone, nil),
+	}}}
+
+	zones := pather.DangerZones(d, dangerCfg())
+	if len(zones) != 1 || zones[0].Position != elite || zones[0].Radius != 8 || zones[0].Cost != 20 {
+		t.Errorf("expected a single zone around the elite, got %+v", zones)
+	}
+}
+
+func TestDangerZonesImmunities(t *testing.T) {
+	immune := data.Position{X: 100, Y: 100}
+	d := game.Data{Data: data.Data{Monsters: data.Monsters{
+		monster(immune, data.MonsterTypeNone, map[stat.ID]int{stat.ColdResist: 100}),
+		// Fire immunes can be broken by the build
+		monster(data.Position{X: 200, Y: 200}, data.MonsterTypeNone, map[stat.ID]int{stat.FireResist: 100}),
+	}}}
+
+	zones := pather.DangerZones(d, dangerCfg())
+	if len(zones) != 1 || zones[0].Position != immune || zones[0].Cost != 30 {
+		t.Errorf("expected a single zone around the cold immune, got %+v", zones)
+	}
+}
+
+func TestDangerZonesBaalWaves(t *testing.T) {
+	d := game.Data{Data: data.Data{PlayerUnit: data.PlayerUnit{Area: area.ThroneOfDestruction}}}
+
+	zones := pather.DangerZones(d, dangerCfg())
+	if len(zones) != 1 || zones[0].Position != pather.BaalThronePosition || zones[0].Cost != 50 {
+		t.Errorf("expected a single zone in front of the throne, got %+v", zones)
+	}
+
+	d.PlayerUnit.Area = area.TheWorldStoneKeepLevel2
+	if zones = pather.DangerZones(d, dangerCfg()); len(zones) != 0 {
+		t.Errorf("Baal waves should only be avoided in the Throne of Destruction, got %+v", zones)
+	}
+}
+
+func TestDangerZonesDuranceFire(t *testing.T) {
+	fire := data.Position{X: 100, Y: 100}
+	d := game.Data{Data: data.Data{Objects: []data.Object{
+		{Name: object.HellFire2, Position: fire},
+		{Name: object.IronGrateDoorLeft, Position: data.Position{X: 200, Y: 200}},
+	}}}
+
+	zones := pather.DangerZones(d, dangerCfg())
+	if len(zones) != 1 || zones[0].Position != fire || zones[0].Radius != 4 {
+		t.Errorf("expected a single zone around the fire, got %+v", zones)
+	}
+}
+
+func TestDangerZonesDisabled(t *testing.T) {
+	cfg := dangerCfg()
+	cfg.Pathing.Danger.Enabled = false
+	d := game.Data{Data: data.Data{
+		PlayerUnit: data.PlayerUnit{Area: area.ThroneOfDestruction},
+		Monsters:   data.Monsters{monster(data.Position{X: 100, Y: 100}, data.MonsterTypeChampion, nil)},
+	}}
+
+	if zones := pather.DangerZones(d, cfg); len(zones) != 0 {
+		t.Errorf("expected no zones when danger is disabled, got %+v", zones)
+	}
+}
+
+func TestGetPathWalksAroundDanger(t *testing.T) {
+	pf, d := newPathFinder(dangerCfg(), openGrid(100, 100), data.Position{X: 10, Y: 50})
+	elite := data.Position{X: origin.X + 50, Y: origin.Y + 50}
+	to := data.Position{X: origin.X + 90, Y: origin.Y + 50}
+
+	straight, _, found := pf.GetPath(d, to)
+	if !found {
+		t.Fatal("path not found")
+	}
+
+	d.Monsters = data.Monsters{monster(elite, data.MonsterTypeChampion, nil)}
+	path, _, found := pf.GetPath(d, to)
+	if !found {
+		t.Fatal("path not found with danger")
+	}
+	if len(path) <= len(straight) {
+		t.Errorf("expected a detour, path has %d tiles and the straight one %d", len(path), len(straight))
+	}
+	for _, p := range path {
+		if pather.DistanceFromPoint(p, elite) < 4 {
+			t.Errorf("path goes through %v, next to the elite at %v", p, elite)
+		}
+	}
+
+	// Danger is gone once the elite is dead
+	d.Monsters = nil
+	if path, _, _ = pf.GetPath(d, to); len(path) != len(straight) {
+		t.Errorf("expected the straight path again, got %d tiles instead of %d", len(path), len(straight))
+	}
+}
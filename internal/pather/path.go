@@ -1,12 +1,12 @@
 package pather
 
 import (
-	"github.com/beefsack/go-astar"
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/koolo/internal/game"
 )
 
-type Pather []astar.Pather
+// Pather is a path in absolute coordinates, from destination to origin
+type Pather []data.Position
 
 func (p Pather) Distance() int {
 	return len(p)
@@ -14,13 +14,7 @@ func (p Pather) Distance() int {
 
 // Intersects checks if the given position intersects with the path, padding parameter is used to increase the area
 func (p Pather) Intersects(d game.Data, position data.Position, padding int) bool {
-	position = data.Position{
-		X: position.X - d.AreaOrigin.X,
-		Y: position.Y - d.AreaOrigin.Y,
-	}
-
-	for _, path := range p {
-		pT := path.(*Tile)
+	for _, pT := range p {
 		xMatch := false
 		yMatch := false
 		for i := range padding {
@@ -2,7 +2,6 @@ package pather
 
 import (
 	"fmt"
-	"github.com/beefsack/go-astar"
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/area"
 	"github.com/hectorgimenez/koolo/internal/config"
@@ -13,20 +12,116 @@ import (
 )
 
 type PathFinder struct {
-	gr             *game.MemoryReader
+	gr             game.DataReader
 	hid            *game.HID
 	cfg            *config.CharacterCfg
 	worldCache     World
 	worldCacheHash string
+	// Teleport moves depend on the game window size, they are computed once
+	teleportMoves     *teleportMoves
+	teleportMovesSize [2]int
 }
 
-func NewPathFinder(gr *game.MemoryReader, hid *game.HID, cfg *config.CharacterCfg) *PathFinder {
+func NewPathFinder(gr game.DataReader, hid *game.HID, cfg *config.CharacterCfg) *PathFinder {
 	return &PathFinder{gr: gr, hid: hid, cfg: cfg}
 }
 
 // The GetPath method definition, combined and corrected
 func (pf *PathFinder) GetPath(d game.Data, to data.Position, blacklistedCoords ...[2]int) (path Pather, distance int, found bool) {
-	outsideCurrentLevel := outsideBoundary(d, to)
+	collisionGrid, collisionGridOffset := pf.collisionGridFor(d, to)
+
+	// Convert to relative coordinates (Current player position)
+	fromX, fromY := relativePosition(d, d.PlayerUnit.Position, collisionGridOffset)
+
+	// Convert to relative coordinates (Target position)
+	toX, toY := relativePosition(d, to, collisionGridOffset)
+
+	// Ensure the target coordinates are within the collision grid bounds before accessing
+	if toX < 0 || toX >= len(collisionGrid[0]) || toY < 0 || toY >= len(collisionGrid) {
+		return nil, 0, false
+	}
+
+	// Ensure the origin coordinates are within the collision grid bounds before accessing
+	if fromX < 0 || fromX >= len(collisionGrid[0]) || fromY < 0 || fromY >= len(collisionGrid) {
+		return nil, 0, false
+	}
+
+	// Origin and destination are the same point
+	if fromX == toX && fromY == toY {
+		return nil, 0, true
+	}
+
+	// Cache the world map, so we don't need to calculate it every time
+	worldCacheHash := fmt.Sprintf("%d-%d-%d-%d-%t", pf.gr.MapSeed(), d.PlayerUnit.Area, len(collisionGrid), len(collisionGrid[0]), d.CanTeleport())
+	if pf.worldCacheHash != worldCacheHash {
+		pf.worldCache = parseWorld(collisionGrid, d)
+		pf.worldCacheHash = worldCacheHash
+	}
+
+	// Lut Gholein map is a bit bugged, we should close this fake path to avoid pathing issues
+	if d.PlayerUnit.Area == area.LutGholein {
+		pf.worldCache.SetKind(KindBlocker, 210, 13)
+	}
+
+	// Set Origin and Destination points
+	pf.worldCache.SetFrom(data.Position{X: fromX, Y: fromY})
+	pf.worldCache.SetTo(data.Position{X: toX, Y: toY})
+
+	// Add some padding to the origin/destination, sometimes when the origin or destination are close to a non-walkable
+	// area, pather is not able to calculate the path, so we add some padding around origin/dest to avoid this
+	// If character can not teleport if apply this hacky thing it will try to kill monsters across walls
+	if d.CanTeleport() {
+		for i := -3; i < 4; i++ {
+			for k := -3; k < 4; k++ {
+				pf.worldCache.SetKind(KindPlain, ensureValueInCG(toX+k, len(collisionGrid[0])), ensureValueInCG(toY+i, len(collisionGrid)))
+			}
+		}
+	}
+
+	// Closed doors can be opened, but it's faster to walk around them when possible
+	for _, o := range d.Objects {
+		if o.IsDoor() && o.Selectable {
+			doorX, doorY := relativePosition(d, o.Position, collisionGridOffset)
+			if pf.worldCache.Kind(doorX, doorY) != KindBlocker {
+				pf.worldCache.SetKind(KindDoor, doorX, doorY)
+			}
+		}
+	}
+
+	// Teleport goes over the danger, only walking characters need to take a detour
+	var dangerZones []DangerZone
+	if !d.CanTeleport() {
+		dangerZones = DangerZones(d, pf.cfg)
+	}
+	pf.worldCache.setDanger(d, dangerZones, collisionGridOffset)
+
+	for _, cord := range blacklistedCoords {
+		blX, blY := relativePosition(d, data.Position{X: cord[0], Y: cord[1]}, collisionGridOffset)
+		pf.worldCache.SetKind(KindBlocker, blX, blY)
+	}
+
+	// Only tiles changed from the previous path are updated, instead of parsing the whole world again
+	pf.worldCache.ApplyOverrides()
+
+	// We want to know the real distance in tiles, not the effort to reach that point, so we count the tiles in the path
+	tiles, found := pf.worldCache.findPath(pf.worldCache.index(fromX, fromY), pf.worldCache.index(toX, toY))
+	p := make(Pather, 0, len(tiles))
+	for _, idx := range tiles {
+		p = append(p, absolutePosition(d, data.Position{X: idx % pf.worldCache.width, Y: idx / pf.worldCache.width}, collisionGridOffset))
+	}
+
+	// Debug only, this will render a png file with map and origin/destination points
+	if config.Koolo.Debug.RenderMap {
+		pf.worldCache.renderPathImg(d, p, collisionGridOffset)
+	}
+
+	return p, len(p), found
+}
+
+// collisionGridFor returns the collision grid containing both the player and the destination, when destination is
+// in a different level both levels are merged into an expanded grid, offset is the position of the other level
+// relative to the current one
+func (pf *PathFinder) collisionGridFor(d game.Data, to data.Position) ([][]bool, data.Position) {
 	collisionGrid := d.CollisionGrid
 
 	collisionGridOffset := data.Position{
@@ -34,7 +129,7 @@ func (pf *PathFinder) GetPath(d game.Data, to data.Position, blacklistedCoords .
 		Y: 0,
 	}
 
-	if outsideCurrentLevel {
+	if outsideBoundary(d, to) {
 		lvl, lvlFound := pf.gr.GetCachedMapData(false).LevelDataForCoords(to, d.PlayerUnit.Area.Area())
 		if !lvlFound {
 			panic("Error occurred calculating path, destination point outside current level and matching level not found")
@@ -95,69 +190,7 @@ func (pf *PathFinder) GetPath(d game.Data, to data.Position, blacklistedCoords .
 		collisionGrid = expandedCG
 	}
 
-	// Convert to relative coordinates (Current player position)
-	fromX, fromY := relativePosition(d, d.PlayerUnit.Position, collisionGridOffset)
-
-	// Convert to relative coordinates (Target position)
-	toX, toY := relativePosition(d, to, collisionGridOffset)
-
-	// Ensure the target coordinates are within the collision grid bounds before accessing
-	if toX < 0 || toX >= len(collisionGrid[0]) || toY < 0 || toY >= len(collisionGrid) {
-		return nil, 0, false
-	}
-
-	// Ensure the origin coordinates are within the collision grid bounds before accessing
-	if fromX < 0 || fromX >= len(collisionGrid[0]) || fromY < 0 || fromY >= len(collisionGrid) {
-		return nil, 0, false
-	}
-
-	// Origin and destination are the same point
-	if fromX == toX && fromY == toY {
-		return nil, 0, true
-	}
-
-	// Lut Gholein map is a bit bugged, we should close this fake path to avoid pathing issues
-	if d.PlayerUnit.Area == area.LutGholein {
-		collisionGrid[13][210] = false
-	}
-
-	// Cache the world map, so we don't need to calculate it every time
-	worldCacheHash := fmt.Sprintf("%d-%d-%d-%d", pf.gr.CachedMapSeed, d.PlayerUnit.Area, len(collisionGrid), len(collisionGrid[0]))
-	if pf.worldCacheHash != worldCacheHash {
-		pf.worldCache = parseWorld(collisionGrid, d)
-		pf.worldCacheHash = worldCacheHash
-	}
-
-	// Set Origin and Destination points
-	pf.worldCache.SetFrom(data.Position{X: fromX, Y: fromY})
-	pf.worldCache.SetTo(data.Position{X: toX, Y: toY})
-
-	// Add some padding to the origin/destination, sometimes when the origin or destination are close to a non-walkable
-	// area, pather is not able to calculate the path, so we add some padding around origin/dest to avoid this
-	// If character can not teleport if apply this hacky thing it will try to kill monsters across walls
-	if d.CanTeleport() {
-		for i := -3; i < 4; i++ {
-			for k := -3; k < 4; k++ {
-				pf.worldCache.SetTile(pf.worldCache.NewTile(KindPlain, ensureValueInCG(toX+k, len(collisionGrid[0])), ensureValueInCG(toY+i, len(collisionGrid))))
-			}
-		}
-	}
-
-	for _, cord := range blacklistedCoords {
-		if len(collisionGrid) < cord[1] && len(collisionGrid[0]) < cord[1] {
-			pf.worldCache.SetTile(pf.worldCache.NewTile(KindBlocker, cord[0], cord[1]))
-		}
-	}
-
-	// aster path is returning the effort to reach that point, but we want to know the real distance in tiles, we count the tiles in the path
-	p, _, found := astar.Path(pf.worldCache.From(), pf.worldCache.To())
-
-	// Debug only, this will render a png file with map and origin/destination points
-	if config.Koolo.Debug.RenderMap {
-		pf.worldCache.renderPathImg(d, p, collisionGridOffset)
-	}
-
-	return p, len(p), found
+	return collisionGrid, collisionGridOffset
 }
 
 func ensureValueInCG(val, cgSize int) int {
@@ -206,15 +239,16 @@ func (pf *PathFinder) GetClosestWalkablePath(d game.Data, dest data.Position, bl
 }
 
 func (pf *PathFinder) MoveThroughPath(d game.Data, p Pather, distance int) {
-	moveTo := p[0].(*Tile)
+	moveTo := p[0]
 	if distance > 0 && len(p) > distance {
-		moveTo = p[len(p)-distance].(*Tile)
+		moveTo = p[len(p)-distance]
 	}
 
-	screenX, screenY := pf.GameCoordsToScreenCords(p[len(p)-1].(*Tile).X, p[len(p)-1].(*Tile).Y, moveTo.X, moveTo.Y)
+	screenX, screenY := pf.GameCoordsToScreenCords(p[len(p)-1].X, p[len(p)-1].Y, moveTo.X, moveTo.Y)
 	// Prevent mouse overlap the HUD
-	if screenY > int(float32(pf.gr.GameAreaSizeY)/1.21) {
-		screenY = int(float32(pf.gr.GameAreaSizeY) / 1.21)
+	_, gameAreaSizeY := pf.gr.GameAreaSize()
+	if screenY > int(float32(gameAreaSizeY)/1.21) {
+		screenY = int(float32(gameAreaSizeY) / 1.21)
 	}
 
 	if distance > 0 {
@@ -239,15 +273,17 @@ func (pf *PathFinder) GameCoordsToScreenCords(playerX, playerY, destinationX, de
 
 	// Transform cartesian movement (World) to isometric (screen)
 	// Helpful documentation: https://clintbellanger.net/articles/isometric_math/
-	screenX := int((float32(diffX-diffY) * 19.8) + float32(pf.gr.GameAreaSizeX/2))
-	screenY := int((float32(diffX+diffY) * 9.9) + float32(pf.gr.GameAreaSizeY/2))
+	gameAreaSizeX, gameAreaSizeY := pf.gr.GameAreaSize()
+	screenX := int((float32(diffX-diffY) * 19.8) + float32(gameAreaSizeX/2))
+	screenY := int((float32(diffX+diffY) * 9.9) + float32(gameAreaSizeY/2))
 
 	return screenX, screenY
 }
 
 func (pf *PathFinder) RandomMovement(d game.Data) {
-	midGameX := pf.gr.GameAreaSizeX / 2
-	midGameY := pf.gr.GameAreaSizeY / 2
+	gameAreaSizeX, gameAreaSizeY := pf.gr.GameAreaSize()
+	midGameX := gameAreaSizeX / 2
+	midGameY := gameAreaSizeY / 2
 	x := midGameX + rand.Intn(midGameX) - (midGameX / 2)
 	y := midGameY + rand.Intn(midGameY) - (midGameY / 2)
 	pf.hid.MovePointer(x, y)
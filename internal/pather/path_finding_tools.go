@@ -8,20 +8,15 @@ import (
 	"github.com/hectorgimenez/koolo/internal/game"
 )
 
-// parseWorld parses a textual representation of a World into a World map.
+// parseWorld parses the collision grid into a World map.
 func parseWorld(collisionGrid [][]bool, d game.Data) World {
 	gridSizeX := len(collisionGrid[0])
 	gridSizeY := len(collisionGrid)
 
-	w := World{
-		World: make([][]*Tile, gridSizeX),
-	}
-	for x := 0; x < gridSizeX; x++ {
-		w.World[x] = make([]*Tile, gridSizeY)
-	}
+	w := newWorld(gridSizeX, gridSizeY)
 
-	for x, xValues := range collisionGrid {
-		for y, walkable := range xValues {
+	for y, xValues := range collisionGrid {
+		for x, walkable := range xValues {
 			kind := KindBlocker
 
 			// Hacky solution to avoid Arcane Sanctuary A* errors
@@ -31,15 +26,17 @@ func parseWorld(collisionGrid [][]bool, d game.Data) World {
 
 			if walkable {
 				// Add some padding around non-walkable areas, this prevents problems when cornering without teleport
-				if !d.CanTeleport() && ((y > 1 && (!xValues[y-1] || !xValues[y-2])) || (y < len(xValues)-2 && (!xValues[y+1] || !xValues[y+2])) ||
-					(x > 1 && (!collisionGrid[x-1][y] || !collisionGrid[x-2][y])) || (x < len(collisionGrid)-2 && (!collisionGrid[x+1][y] || !collisionGrid[x+2][y]))) {
+				if !d.CanTeleport() && ((x > 1 && (!xValues[x-1] || !xValues[x-2])) || (x < len(xValues)-2 && (!xValues[x+1] || !xValues[x+2])) ||
+					(y > 1 && (!collisionGrid[y-1][x] || !collisionGrid[y-2][x])) || (y < len(collisionGrid)-2 && (!collisionGrid[y+1][x] || !collisionGrid[y+2][x]))) {
 					kind = KindSoftBlocker
 				} else {
 					kind = KindPlain
 				}
 			}
 
-			w.SetTile(w.NewTile(kind, y, x))
+			if x < gridSizeX {
+				w.kinds[w.index(x, y)] = kind
+			}
 		}
 	}
 
@@ -94,7 +91,7 @@ func FindFirstWalkable(from data.Position, areaOriginPos data.Position, grid [][
 			}
 			for _, pos := range positions {
 				newX, newY := pos[0]+areaOriginPos.X, pos[1]+areaOriginPos.Y
-				if pos[0] >= 0 && pos[0] < len(grid) && pos[1] >= 0 && pos[1] < len(grid[0]) && IsWalkable(data.Position{newX, newY}, areaOriginPos, grid) {
+				if pos[0] >= 0 && pos[0] < len(grid) && pos[1] >= 0 && pos[1] < len(grid[0]) && IsWalkable(data.Position{X: newX, Y: newY}, areaOriginPos, grid) {
 					return newX, newY
 				}
 			}
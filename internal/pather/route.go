@@ -0,0 +1,195 @@
+package pather
+
+import (
+	"math"
+	"slices"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/game/map_client"
+)
+
+const (
+	HopWalk       RouteHopKind = "walk"
+	HopWaypoint   RouteHopKind = "waypoint"
+	HopTownPortal RouteHopKind = "town portal"
+
+	// Costs are measured in walked tiles, waypoints and portals include the time spent in menus and loading screens
+	waypointCost     = 40
+	townPortalCost   = 60
+	unknownLevelCost = 100
+)
+
+type RouteHopKind string
+
+// RouteHop is a single movement of a route, Area is the area reached after the hop
+type RouteHop struct {
+	Kind RouteHopKind
+	Area area.ID
+}
+
+var actTowns = map[int]area.ID{
+	1: area.RogueEncampment,
+	2: area.LutGholein,
+	3: area.KurastDocks,
+	4: area.ThePandemoniumFortress,
+	5: area.Harrogath,
+}
+
+// Some areas are not connected by exits, like Arcane Sanctuary that is reached through a portal
+var specialExits = map[area.ID][]area.ID{
+	area.PalaceCellarLevel3: {area.ArcaneSanctuary},
+}
+
+// PlanRoute returns the cheapest route from the current area to the destination, combining level exits, the
+// waypoints discovered by the player and town portals, only when there is a portal tome with charges. Walking cost from the current area is based on the player
+// position, for the other areas we expect to walk half of the level size.
+func PlanRoute(md map_client.MapData, d game.Data, to area.ID) ([]RouteHop, bool) {
+	from := d.PlayerUnit.Area
+	if from == to {
+		return nil, true
+	}
+
+	planner := routePlanner{md: md, d: d, exits: make(map[area.ID][]data.Level), canCastPortal: canCastPortal(d)}
+	costs := map[area.ID]float64{from: 0}
+	previous := make(map[area.ID]area.ID)
+	hops := make(map[area.ID]RouteHop)
+	visited := make(map[area.ID]bool)
+
+	for {
+		current, found := cheapest(costs, visited)
+		if !found {
+			return nil, false
+		}
+		if current == to {
+			break
+		}
+		visited[current] = true
+
+		for _, edge := range planner.edges(current) {
+			cost := costs[current] + edge.cost
+			if known, found := costs[edge.hop.Area]; found && known <= cost {
+				continue
+			}
+			costs[edge.hop.Area] = cost
+			previous[edge.hop.Area] = current
+			hops[edge.hop.Area] = edge.hop
+		}
+	}
+
+	route := make([]RouteHop, 0)
+	for a := to; a != from; a = previous[a] {
+		route = append(route, hops[a])
+	}
+	slices.Reverse(route)
+
+	return route, true
+}
+
+type routeEdge struct {
+	hop  RouteHop
+	cost float64
+}
+
+type routePlanner struct {
+	md            map_client.MapData
+	d             game.Data
+	exits         map[area.ID][]data.Level
+	canCastPortal bool
+}
+
+func (p routePlanner) edges(a area.ID) []routeEdge {
+	edges := make([]routeEdge, 0)
+
+	for _, exit := range p.levelExits(a) {
+		edges = append(edges, routeEdge{
+			hop:  RouteHop{Kind: HopWalk, Area: exit.Area},
+			cost: p.walkingCost(a, exit.Position),
+		})
+	}
+	for _, dst := range specialExits[a] {
+		edges = append(edges, routeEdge{hop: RouteHop{Kind: HopWalk, Area: dst}, cost: p.walkingCost(a, data.Position{})})
+	}
+
+	if _, hasWaypoint := area.WPAddresses[a]; hasWaypoint {
+		wpCost := p.walkingCost(a, p.waypointPosition(a)) + waypointCost
+		for _, wp := range p.d.PlayerUnit.AvailableWaypoints {
+			if wp != a {
+				edges = append(edges, routeEdge{hop: RouteHop{Kind: HopWaypoint, Area: wp}, cost: wpCost})
+			}
+		}
+	}
+
+	if p.canCastPortal && !a.IsTown() {
+		edges = append(edges, routeEdge{hop: RouteHop{Kind: HopTownPortal, Area: actTowns[a.Act()]}, cost: townPortalCost})
+	}
+
+	return edges
+}
+
+func (p routePlanner) levelExits(a area.ID) []data.Level {
+	if exits, found := p.exits[a]; found {
+		return exits
+	}
+
+	if _, found := p.md.LevelSize(a); !found {
+		p.exits[a] = nil
+		return nil
+	}
+	_, exits, _, _ := p.md.NPCsExitsAndObjects(p.md.Origin(a), a)
+	p.exits[a] = exits
+
+	return exits
+}
+
+// walkingCost is the distance from the player to the destination in the current area, half of the level size otherwise
+func (p routePlanner) walkingCost(a area.ID, destination data.Position) float64 {
+	if a == p.d.PlayerUnit.Area && destination != (data.Position{}) {
+		return float64(DistanceFromMe(p.d, destination))
+	}
+
+	size, found := p.md.LevelSize(a)
+	if !found {
+		return unknownLevelCost
+	}
+
+	return math.Max(float64(size.X), float64(size.Y)) / 2
+}
+
+func (p routePlanner) waypointPosition(a area.ID) data.Position {
+	if a != p.d.PlayerUnit.Area {
+		return data.Position{}
+	}
+
+	for _, o := range p.d.Objects {
+		if o.IsWaypoint() {
+			return o.Position
+		}
+	}
+
+	return data.Position{}
+}
+
+func canCastPortal(d game.Data) bool {
+	tome, found := d.Inventory.Find(item.TomeOfTownPortal, item.LocationInventory)
+	if !found {
+		return false
+	}
+	qty, found := tome.FindStat(stat.Quantity, 0)
+
+	return found && qty.Value > 0
+}
+
+func cheapest(costs map[area.ID]float64, visited map[area.ID]bool) (area.ID, bool) {
+	cheapestArea, cheapestCost, found := area.ID(0), math.MaxFloat64, false
+	for a, cost := range costs {
+		if !visited[a] && (cost < cheapestCost || cost == cheapestCost && a < cheapestArea) {
+			cheapestArea, cheapestCost, found = a, cost, true
+		}
+	}
+
+	return cheapestArea, found
+}
@@ -0,0 +1,106 @@
+package pather_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/game/map_client"
+	"github.com/hectorgimenez/koolo/internal/pather"
+)
+
+// routeLevels is a simplified Act 1: Rogue Encampment, Blood Moor and Cold Plains connected by exits, Den of Evil as a
+// cave entrance in Blood Moor and Black Marsh only reachable by waypoint
+var routeLevels = map_client.ParseMapData([]byte(`
+{"type":"map","id":1,"name":"Rogue Encampment","offset":{"x":0,"y":0},"size":{"width":100,"height":100},"objects":[{"id":2,"type":"exit_area","x":99,"y":50}],"map":[[100]]}
+{"type":"map","id":2,"name":"Blood Moor","offset":{"x":100,"y":0},"size":{"width":300,"height":300},"objects":[{"id":1,"type":"exit_area","x":0,"y":50},{"id":3,"type":"exit_area","x":299,"y":150},{"id":8,"type":"exit","x":150,"y":250}],"map":[[300]]}
+{"type":"map","id":3,"name":"Cold Plains","offset":{"x":400,"y":0},"size":{"width":300,"height":300},"objects":[{"id":2,"type":"exit_area","x":0,"y":150}],"map":[[300]]}
+{"type":"map","id":6,"name":"Black Marsh","offset":{"x":2000,"y":0},"size":{"width":300,"height":300},"objects":[],"map":[[300]]}
+{"type":"map","id":8,"name":"Den of Evil","offset":{"x":0,"y":1000},"size":{"width":300,"height":300},"objects":[{"id":2,"type":"exit","x":10,"y":10}],"map":[[300]]}
+`))
+
+func routeData(a area.ID, position data.Position, waypoints ...area.ID) game.Data {
+	return game.Data{Data: data.Data{PlayerUnit: data.PlayerUnit{
+		Area:               a,
+		Position:           position,
+		AvailableWaypoints: waypoints,
+	}}}
+}
+
+func TestPlanRouteWalksThroughExits(t *testing.T) {
+	// Next to the Cold Plains exit, taking the waypoint from town would be slower
+	d := routeData(area.BloodMoor, data.Position{X: 390, Y: 150}, area.RogueEncampment, area.ColdPlains)
+
+	route, found := pather.PlanRoute(routeLevels, d, area.ColdPlains)
+	expected := []pather.RouteHop{{Kind: pather.HopWalk, Area: area.ColdPlains}}
+	if !found || !slices.Equal(route, expected) {
+		t.Errorf("expected %v, got %v (found: %t)", expected, route, found)
+	}
+}
+
+func TestPlanRouteUsesWaypoint(t *testing.T) {
+	d := routeData(area.RogueEncampment, data.Position{X: 50, Y: 50}, area.RogueEncampment, area.ColdPlains, area.BlackMarsh)
+	d.Objects = []data.Object{{Name: object.WaypointPortal, Position: data.Position{X: 52, Y: 50}}}
+
+	route, found := pather.PlanRoute(routeLevels, d, area.BlackMarsh)
+	expected := []pather.RouteHop{{Kind: pather.HopWaypoint, Area: area.BlackMarsh}}
+	if !found || !slices.Equal(route, expected) {
+		t.Errorf("expected %v, got %v (found: %t)", expected, route, found)
+	}
+
+	// Without the waypoint Black Marsh is not connected
+	d.PlayerUnit.AvailableWaypoints = []area.ID{area.RogueEncampment, area.ColdPlains}
+	if route, found = pather.PlanRoute(routeLevels, d, area.BlackMarsh); found {
+		t.Errorf("expected no route without the waypoint, got %v", route)
+	}
+}
+
+// withPortalTome adds a tome of town portal with the given charges to the inventory
+func withPortalTome(d game.Data, charges int) game.Data {
+	d.Inventory.AllItems = append(d.Inventory.AllItems, data.Item{
+		Name:     item.TomeOfTownPortal,
+		Location: item.Location{LocationType: item.LocationInventory},
+		Stats:    stat.Stats{{ID: stat.Quantity, Value: charges}},
+	})
+
+	return d
+}
+
+func TestPlanRouteUsesTownPortal(t *testing.T) {
+	// Far away from the Den of Evil exit, a town portal is faster than walking back to town
+	d := withPortalTome(routeData(area.DenOfEvil, data.Position{X: 290, Y: 1290}), 5)
+
+	route, found := pather.PlanRoute(routeLevels, d, area.RogueEncampment)
+	expected := []pather.RouteHop{{Kind: pather.HopTownPortal, Area: area.RogueEncampment}}
+	if !found || !slices.Equal(route, expected) {
+		t.Errorf("expected %v, got %v (found: %t)", expected, route, found)
+	}
+
+	// Next to the exit, walking out is faster than going through town
+	d.PlayerUnit.Position = data.Position{X: 12, Y: 1012}
+	route, found = pather.PlanRoute(routeLevels, d, area.BloodMoor)
+	expected = []pather.RouteHop{{Kind: pather.HopWalk, Area: area.BloodMoor}}
+	if !found || !slices.Equal(route, expected) {
+		t.Errorf("expected %v, got %v (found: %t)", expected, route, found)
+	}
+}
+
+func TestPlanRouteWalksWithoutPortalCharges(t *testing.T) {
+	expected := []pather.RouteHop{{Kind: pather.HopWalk, Area: area.BloodMoor}, {Kind: pather.HopWalk, Area: area.RogueEncampment}}
+	tests := map[string]game.Data{
+		"no tome":    routeData(area.DenOfEvil, data.Position{X: 290, Y: 1290}),
+		"empty tome": withPortalTome(routeData(area.DenOfEvil, data.Position{X: 290, Y: 1290}), 0),
+	}
+
+	for name, d := range tests {
+		route, found := pather.PlanRoute(routeLevels, d, area.RogueEncampment)
+		if !found || !slices.Equal(route, expected) {
+			t.Errorf("%s: expected %v, got %v (found: %t)", name, expected, route, found)
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package pather
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/game"
+)
+
+const (
+	// TeleportRange is the max distance in tiles covered by a single teleport
+	TeleportRange = 25
+	// Landing positions are sampled every few tiles, checking every single tile in range would be too slow
+	teleportGridStep = 3
+	// Landing position must be visible and not covered by the HUD
+	teleportScreenMargin = 20
+	// Planning runs inside the bot loop, we give up after expanding this number of landing positions instead of
+	// exploring the whole level, hops are taken along the walkable path then
+	teleportMaxExpanded = 1000
+	// Landing positions this close to a blacklisted coordinate are not used
+	teleportBlacklistRadius = 3
+	// The screen is wider than tall, so the distance covered by a teleport depends on the direction, the estimate
+	// uses the max reach of every direction sector
+	teleportDirections = 72
+	// Estimate is weighted to go straight to the goal, the plan can take a few more teleports than the optimal one
+	// but exploring every alternative with the same number of teleports is too slow
+	teleportEstimateWeight = 2
+)
+
+// GetTeleportPath plans the teleports needed to reach the destination, instead of following the walkable path any
+// walkable position in teleport range (and visible on the screen) can be the next hop, so usually it takes fewer
+// casts (and less mana) than teleporting along the walkable path. The search is weighted to be fast, the plan is not
+// guaranteed to have the minimum number of casts. When the planner gives up, hops are taken along the path returned
+// by GetPath instead. Hops are returned in order, the last one is the destination or the closest walkable position
+// to it. Destinations in adjacent levels and blacklisted coordinates are supported the same way GetPath does.
+func (pf *PathFinder) GetTeleportPath(d game.Data, to data.Position, blacklistedCoords ...[2]int) (hops []data.Position, found bool) {
+	collisionGrid, offset := pf.collisionGridFor(d, to)
+	if len(collisionGrid) == 0 {
+		return nil, false
+	}
+
+	fromX, fromY := relativePosition(d, d.PlayerUnit.Position, offset)
+	toX, toY := relativePosition(d, to, offset)
+	if !insideGrid(collisionGrid, fromX, fromY) || !insideGrid(collisionGrid, toX, toY) {
+		return nil, false
+	}
+
+	// Destination is usually an object or an entrance, not walkable, so we land next to it
+	if !collisionGrid[toY][toX] {
+		toX, toY, found = closestWalkable(collisionGrid, toX, toY, 5)
+		if !found {
+			return nil, false
+		}
+	}
+
+	blacklisted := make([]data.Position, 0, len(blacklistedCoords))
+	for _, c := range blacklistedCoords {
+		blX, blY := relativePosition(d, data.Position{X: c[0], Y: c[1]}, offset)
+		blacklisted = append(blacklisted, data.Position{X: blX, Y: blY})
+	}
+
+	planner := teleportPlanner{grid: collisionGrid, moves: pf.teleportMovesFor(), goal: data.Position{X: toX, Y: toY}, blacklisted: blacklisted}
+	relativeHops, found := planner.plan(data.Position{X: fromX, Y: fromY})
+	if !found {
+		return pf.teleportHopsAlongPath(d, absolutePosition(d, planner.goal, offset), blacklistedCoords...)
+	}
+
+	hops = make([]data.Position, 0, len(relativeHops))
+	for _, h := range relativeHops {
+		hops = append(hops, absolutePosition(d, h, offset))
+	}
+
+	return hops, true
+}
+
+// teleportHopsAlongPath returns the farthest positions of the walkable path reachable with every teleport, it's used
+// when the planner gives up, walls thicker than the teleport range make it explore big parts of the level
+func (pf *PathFinder) teleportHopsAlongPath(d game.Data, to data.Position, blacklistedCoords ...[2]int) ([]data.Position, bool) {
+	path, _, found := pf.GetPath(d, to, blacklistedCoords...)
+	if !found || len(path) == 0 {
+		return nil, false
+	}
+
+	moves := pf.teleportMovesFor()
+	hops := make([]data.Position, 0)
+	current := d.PlayerUnit.Position
+	// Path goes from the destination to the player, the last position is the player one
+	for i := len(path) - 2; i >= 0; {
+		next := i
+		for j := i; j >= 0; j-- {
+			if moves.inRange[data.Position{X: path[j].X - current.X, Y: path[j].Y - current.Y}] {
+				next = j
+			}
+		}
+
+		current = path[next]
+		hops = append(hops, current)
+		i = next - 1
+	}
+
+	return hops, true
+}
+
+// teleportMovesFor returns the moves that can be done with a single teleport, they only depend on the game window
+// size so they are cached
+func (pf *PathFinder) teleportMovesFor() *teleportMoves {
+	sizeX, sizeY := pf.gr.GameAreaSize()
+	if pf.teleportMoves == nil || pf.teleportMovesSize != [2]int{sizeX, sizeY} {
+		pf.teleportMoves = newTeleportMoves(pf.teleportOffsets(sizeX, sizeY))
+		pf.teleportMovesSize = [2]int{sizeX, sizeY}
+	}
+
+	return pf.teleportMoves
+}
+
+// teleportOffsets returns the relative positions that can be reached with a single teleport from any position
+func (pf *PathFinder) teleportOffsets(sizeX, sizeY int) []data.Position {
+	maxY := int(float32(sizeY)/1.21) - teleportScreenMargin
+
+	offsets := make([]data.Position, 0)
+	for dx := -TeleportRange; dx <= TeleportRange; dx++ {
+		for dy := -TeleportRange; dy <= TeleportRange; dy++ {
+			if dx == 0 && dy == 0 || dx*dx+dy*dy > TeleportRange*TeleportRange {
+				continue
+			}
+			x, y := pf.GameCoordsToScreenCords(0, 0, dx, dy)
+			if x < teleportScreenMargin || x > sizeX-teleportScreenMargin || y < teleportScreenMargin || y > maxY {
+				continue
+			}
+			offsets = append(offsets, data.Position{X: dx, Y: dy})
+		}
+	}
+
+	return offsets
+}
+
+type teleportMoves struct {
+	// Offsets landing on the sampled grid, grouped by the position of the origin inside its grid cell, so only
+	// the useful ones are checked for every expanded node
+	offsets [teleportGridStep * teleportGridStep][]data.Position
+	inRange map[data.Position]bool
+	reach   [teleportDirections]float64
+}
+
+func newTeleportMoves(offsets []data.Position) *teleportMoves {
+	m := &teleportMoves{inRange: make(map[data.Position]bool, len(offsets))}
+	for _, o := range offsets {
+		m.inRange[o] = true
+		for sector := range m.reach {
+			m.reach[sector] = math.Max(m.reach[sector], maxProjection(o, sector))
+		}
+		for cellX := 0; cellX < teleportGridStep; cellX++ {
+			for cellY := 0; cellY < teleportGridStep; cellY++ {
+				if gridMod(cellX+o.X) == 0 && gridMod(cellY+o.Y) == 0 {
+					m.offsets[cellX*teleportGridStep+cellY] = append(m.offsets[cellX*teleportGridStep+cellY], o)
+				}
+			}
+		}
+	}
+
+	return m
+}
+
+type teleportPlanner struct {
+	grid        [][]bool
+	moves       *teleportMoves
+	goal        data.Position
+	blacklisted []data.Position
+}
+
+type teleportNode struct {
+	pos      data.Position
+	hops     int
+	distance float64
+	// remaining is the straight distance left to the goal
+	remaining float64
+	priority  float64
+	previous  *teleportNode
+	index     int
+}
+
+// plan is a weighted A* where every edge is a teleport, the heuristic is the number of teleports needed to cover the
+// straight distance to the goal. Ties are broken by the distance left to the goal, so the search goes straight to
+// the goal instead of expanding every position needing the same number of hops.
+func (p teleportPlanner) plan(from data.Position) ([]data.Position, bool) {
+	start := &teleportNode{pos: from, priority: p.estimate(from)}
+	visited := map[data.Position]*teleportNode{from: start}
+	open := &teleportQueue{start}
+
+	for expanded := 0; open.Len() > 0 && expanded < teleportMaxExpanded; expanded++ {
+		current := heap.Pop(open).(*teleportNode)
+		if current.pos == p.goal {
+			return current.hopsFromStart(), true
+		}
+
+		for _, next := range p.neighbours(current.pos) {
+			hops := current.hops + 1
+			distance := current.distance + euclidean(current.pos, next)
+			if known, found := visited[next]; found {
+				if known.hops < hops || known.hops == hops && known.distance <= distance || known.index < 0 {
+					continue
+				}
+				known.hops, known.distance, known.previous = hops, distance, current
+				known.priority = float64(hops) + p.estimate(next)
+				heap.Fix(open, known.index)
+				continue
+			}
+
+			n := &teleportNode{
+				pos:       next,
+				hops:      hops,
+				distance:  distance,
+				remaining: euclidean(next, p.goal),
+				previous:  current,
+				priority:  float64(hops) + p.estimate(next),
+			}
+			visited[next] = n
+			heap.Push(open, n)
+		}
+	}
+
+	return nil, false
+}
+
+// neighbours returns the sampled landing positions reachable from pos, the goal is always included when in range
+func (p teleportPlanner) neighbours(pos data.Position) []data.Position {
+	neighbours := make([]data.Position, 0)
+	if p.moves.inRange[data.Position{X: p.goal.X - pos.X, Y: p.goal.Y - pos.Y}] {
+		neighbours = append(neighbours, p.goal)
+	}
+
+	for _, o := range p.moves.offsets[gridMod(pos.X)*teleportGridStep+gridMod(pos.Y)] {
+		next := data.Position{X: pos.X + o.X, Y: pos.Y + o.Y}
+		if next != p.goal && insideGrid(p.grid, next.X, next.Y) && p.grid[next.Y][next.X] && !p.isBlacklisted(next) {
+			neighbours = append(neighbours, next)
+		}
+	}
+
+	return neighbours
+}
+
+func (p teleportPlanner) isBlacklisted(pos data.Position) bool {
+	for _, b := range p.blacklisted {
+		if euclidean(pos, b) <= teleportBlacklistRadius {
+			return true
+		}
+	}
+
+	return false
+}
+
+// gridMod returns the position of the coordinate inside its sampling grid cell, also for negative offsets
+func gridMod(v int) int {
+	return (v%teleportGridStep + teleportGridStep) % teleportGridStep
+}
+
+func (p teleportPlanner) estimate(pos data.Position) float64 {
+	distance := euclidean(pos, p.goal)
+	if distance == 0 {
+		return 0
+	}
+
+	reach := p.moves.reach[directionSector(float64(p.goal.X-pos.X), float64(p.goal.Y-pos.Y))]
+	if reach <= 0 {
+		return teleportEstimateWeight * distance / TeleportRange
+	}
+
+	return teleportEstimateWeight * distance / reach
+}
+
+// maxProjection returns the max distance the offset covers towards any direction inside the sector, so the estimate
+// never exceeds the real number of teleports
+func maxProjection(o data.Position, sector int) float64 {
+	sectorSize := 2 * math.Pi / teleportDirections
+	from := float64(sector)*sectorSize - math.Pi
+	to := from + sectorSize
+	angle := math.Atan2(float64(o.Y), float64(o.X))
+
+	diff := 0.0
+	if angle < from || angle > to {
+		diff = math.Min(angularDistance(angle, from), angularDistance(angle, to))
+	}
+	if diff >= math.Pi/2 {
+		return 0
+	}
+
+	return math.Hypot(float64(o.X), float64(o.Y)) * math.Cos(diff)
+}
+
+func directionSector(dx, dy float64) int {
+	sector := int((math.Atan2(dy, dx) + math.Pi) / (2 * math.Pi / teleportDirections))
+
+	return min(sector, teleportDirections-1)
+}
+
+func angularDistance(a, b float64) float64 {
+	diff := math.Mod(math.Abs(a-b), 2*math.Pi)
+
+	return math.Min(diff, 2*math.Pi-diff)
+}
+
+func (n *teleportNode) hopsFromStart() []data.Position {
+	hops := make([]data.Position, n.hops)
+	for current := n; current.previous != nil; current = current.previous {
+		hops[current.hops-1] = current.pos
+	}
+
+	return hops
+}
+
+type teleportQueue []*teleportNode
+
+func (q teleportQueue) Len() int { return len(q) }
+
+func (q teleportQueue) Less(i, j int) bool {
+	if q[i].priority == q[j].priority {
+		return q[i].remaining < q[j].remaining
+	}
+
+	return q[i].priority < q[j].priority
+}
+
+func (q teleportQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *teleportQueue) Push(x any) {
+	n := x.(*teleportNode)
+	n.index = len(*q)
+	*q = append(*q, n)
+}
+
+func (q *teleportQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	n.index = -1
+	*q = old[:len(old)-1]
+
+	return n
+}
+
+func insideGrid(grid [][]bool, x, y int) bool {
+	return y >= 0 && y < len(grid) && x >= 0 && x < len(grid[y])
+}
+
+func closestWalkable(grid [][]bool, x, y, radius int) (int, int, bool) {
+	for r := 1; r <= radius; r++ {
+		for dx := -r; dx <= r; dx++ {
+			for dy := -r; dy <= r; dy++ {
+				if insideGrid(grid, x+dx, y+dy) && grid[y+dy][x+dx] {
+					return x + dx, y + dy, true
+				}
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+func euclidean(from, to data.Position) float64 {
+	return math.Hypot(float64(to.X-from.X), float64(to.Y-from.Y))
+}
+
+// absolutePosition reverts relativePosition
+func absolutePosition(d game.Data, p data.Position, cgOffset data.Position) data.Position {
+	x, y := p.X+d.AreaOrigin.X, p.Y+d.AreaOrigin.Y
+	if cgOffset.X < 0 {
+		x -= int(math.Abs(float64(cgOffset.X)))
+	}
+	if cgOffset.Y < 0 {
+		y -= int(math.Abs(float64(cgOffset.Y)))
+	}
+
+	return data.Position{X: x, Y: y}
+}
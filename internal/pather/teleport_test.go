@@ -0,0 +1,141 @@
+package pather_test
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/pather"
+	"github.com/hectorgimenez/koolo/internal/simulator"
+)
+
+var origin = data.Position{X: 5000, Y: 5000}
+
+// newPathFinder returns a path finder for a level with the given collision grid, the player is placed at start
+// (relative to the level origin)
+func newPathFinder(cfg *config.CharacterCfg, grid [][]bool, start data.Position) (*pather.PathFinder, game.Data) {
+	if config.Koolo == nil {
+		config.Koolo = &config.KooloCfg{}
+	}
+
+	sim := simulator.New(cfg, data.Data{
+		AreaOrigin:    origin,
+		CollisionGrid: grid,
+		PlayerUnit: data.PlayerUnit{
+			Area:     area.BloodMoor,
+			Position: data.Position{X: origin.X + start.X, Y: origin.Y + start.Y},
+		},
+	})
+	c := sim.Container("test", slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	return c.PathFinder, sim.GetData(false)
+}
+
+func openGrid(width, height int) [][]bool {
+	grid := make([][]bool, height)
+	for y := range grid {
+		grid[y] = make([]bool, width)
+		for x := range grid[y] {
+			grid[y][x] = true
+		}
+	}
+
+	return grid
+}
+
+func TestGetTeleportPathReachesDestination(t *testing.T) {
+	pf, d := newPathFinder(&config.CharacterCfg{}, openGrid(200, 200), data.Position{X: 10, Y: 100})
+	to := data.Position{X: origin.X + 190, Y: origin.Y + 100}
+
+	hops, found := pf.GetTeleportPath(d, to)
+	if !found {
+		t.Fatal("teleport path not found")
+	}
+	if hops[len(hops)-1] != to {
+		t.Errorf("last hop is %v, expected the destination %v", hops[len(hops)-1], to)
+	}
+
+	previous := d.PlayerUnit.Position
+	for _, h := range hops {
+		if pather.DistanceFromPoint(previous, h) > pather.TeleportRange {
+			t.Errorf("hop from %v to %v is out of teleport range", previous, h)
+		}
+		previous = h
+	}
+}
+
+func TestGetTeleportPathAvoidsBlacklistedCoords(t *testing.T) {
+	pf, d := newPathFinder(&config.CharacterCfg{}, openGrid(200, 200), data.Position{X: 10, Y: 100})
+	to := data.Position{X: origin.X + 190, Y: origin.Y + 100}
+
+	hops, found := pf.GetTeleportPath(d, to)
+	if !found || len(hops) < 2 {
+		t.Fatalf("expected a path with several hops, got %v", hops)
+	}
+
+	blacklisted := hops[0]
+	hops, found = pf.GetTeleportPath(d, to, [2]int{blacklisted.X, blacklisted.Y})
+	if !found {
+		t.Fatal("teleport path not found with blacklisted coords")
+	}
+	for _, h := range hops {
+		if pather.DistanceFromPoint(h, blacklisted) <= 3 {
+			t.Errorf("hop %v is next to the blacklisted coord %v", h, blacklisted)
+		}
+	}
+}
+
+func TestGetTeleportPathGivesUpOnUnreachableDestination(t *testing.T) {
+	grid := openGrid(1000, 1000)
+	// Destination is enclosed by a wall thicker than the teleport range
+	for y := 400; y < 600; y++ {
+		for x := 400; x < 600; x++ {
+			grid[y][x] = x > 490 && x < 510 && y > 490 && y < 510
+		}
+	}
+	pf, d := newPathFinder(&config.CharacterCfg{}, grid, data.Position{X: 10, Y: 10})
+
+	startedAt := time.Now()
+	if _, found := pf.GetTeleportPath(d, data.Position{X: origin.X + 500, Y: origin.Y + 500}); found {
+		t.Fatal("destination should not be reachable")
+	}
+	if elapsed := time.Since(startedAt); elapsed > time.Second {
+		t.Errorf("planning took %s, it should give up earlier", elapsed)
+	}
+}
+
+func TestGetTeleportPathFollowsWalkablePathWhenPlannerGivesUp(t *testing.T) {
+	grid := openGrid(100, 1000)
+	// Wall thicker than the teleport range, the only way is walking around it through the far end of the level
+	for y := 0; y < 990; y++ {
+		for x := 30; x < 60; x++ {
+			grid[y][x] = false
+		}
+	}
+	pf, d := newPathFinder(&config.CharacterCfg{}, grid, data.Position{X: 10, Y: 500})
+	to := data.Position{X: origin.X + 80, Y: origin.Y + 500}
+
+	hops, found := pf.GetTeleportPath(d, to)
+	if !found {
+		t.Fatal("teleport path not found")
+	}
+	if hops[len(hops)-1] != to {
+		t.Errorf("last hop is %v, expected the destination %v", hops[len(hops)-1], to)
+	}
+
+	previous := d.PlayerUnit.Position
+	for _, h := range hops {
+		if pather.DistanceFromPoint(previous, h) > pather.TeleportRange {
+			t.Errorf("hop from %v to %v is out of teleport range", previous, h)
+		}
+		if !grid[h.Y-origin.Y][h.X-origin.X] {
+			t.Errorf("hop %v is not walkable", h)
+		}
+		previous = h
+	}
+}
@@ -1,64 +1,93 @@
 package pather
 
 import (
-	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
 	"image/png"
 	"os"
 
-	"github.com/beefsack/go-astar"
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/koolo/internal/game"
 )
 
+// World is the walkable representation of a collision grid, tile kinds are stored in a flat slice indexed by
+// y*width+x. Temporary changes (blacklisted coords, closed doors, etc.) are applied as overrides on top of the parsed
+// kinds, only the tiles that changed from the previous path are updated, without parsing the whole grid again.
 type World struct {
-	World [][]*Tile
-	from  data.Position
-	to    data.Position
+	width     int
+	height    int
+	kinds     []uint8
+	overrides map[int]uint8 // Original kind of the overridden tiles
+	pending   map[int]uint8 // Overrides for the next path, applied by ApplyOverrides
+	costs     [KindDoor + 1]float64
+	from      data.Position
+	to        data.Position
+	danger    []float64 // Extra cost of every tile, indexed like kinds, nil when there is no danger
+	// Zones used to calculate danger, in collision grid coordinates
+	dangerZones []DangerZone
+	search      searchBuffers
 }
 
-// Tile gets the tile at the given coordinates in the World.
-func (w *World) Tile(x, y int) *Tile {
-	if x < 0 || x > len(w.World)-1 || w.World[x] == nil || y > len(w.World[x])-1 || y < 0 {
-		return nil
+func newWorld(width, height int) World {
+	w := World{
+		width:     width,
+		height:    height,
+		kinds:     make([]uint8, width*height),
+		overrides: make(map[int]uint8),
+		pending:   make(map[int]uint8),
+	}
+	for kind, cost := range KindCosts {
+		if int(kind) < len(w.costs) {
+			w.costs[kind] = cost
+		}
 	}
 
-	return w.World[x][y]
+	return w
 }
 
-// SetTile sets a tile at the given coordinates in the World, if the World is big enough.
-func (w *World) SetTile(t *Tile) {
-	if len(w.World) >= t.X + 1 && len(w.World[t.X]) >= t.Y + 1 {
-		w.World[t.X][t.Y] = t
+// Kind returns the kind the tile at the given coordinates will have for the next path: the one set by SetKind or the
+// one parsed from the collision grid. Tiles outside the World are blockers.
+func (w *World) Kind(x, y int) uint8 {
+	if !w.inside(x, y) {
+		return KindBlocker
 	}
-}
 
-func (w *World) NewTile(kind uint8, x, y int) *Tile {
-	walkable := false
-	cost := 0.0
-	if kind != KindBlocker {
-		walkable = true
-		cost = KindCosts[kind]
+	idx := w.index(x, y)
+	if kind, found := w.pending[idx]; found {
+		return kind
 	}
-
-	return &Tile{
-		Kind:     kind,
-		Walkable: walkable,
-		Cost:     cost,
-		X:        x,
-		Y:        y,
-		W:        w,
+	if kind, found := w.overrides[idx]; found {
+		return kind
 	}
+
+	return w.kinds[idx]
 }
 
-func (w *World) From() *Tile {
-	return w.World[w.from.X][w.from.Y]
+// SetKind overrides the kind of the tile at the given coordinates for the next path, it's applied by ApplyOverrides.
+func (w *World) SetKind(kind uint8, x, y int) {
+	if w.inside(x, y) {
+		w.pending[w.index(x, y)] = kind
+	}
 }
 
-func (w *World) To() *Tile {
-	return w.World[w.to.X][w.to.Y]
+// ApplyOverrides applies the kinds set by SetKind since the previous call. Tiles overridden for the previous path and
+// not set again are reverted to the kind parsed from the collision grid, the rest of the World is not touched.
+func (w *World) ApplyOverrides() {
+	for idx, kind := range w.overrides {
+		if _, found := w.pending[idx]; !found {
+			w.kinds[idx] = kind
+			delete(w.overrides, idx)
+		}
+	}
+
+	for idx, kind := range w.pending {
+		if _, found := w.overrides[idx]; !found {
+			w.overrides[idx] = w.kinds[idx]
+		}
+		w.kinds[idx] = kind
+		delete(w.pending, idx)
+	}
 }
 
 func (w *World) SetFrom(position data.Position) {
@@ -68,41 +97,68 @@ func (w *World) SetTo(position data.Position) {
 	w.to = position
 }
 
+func (w *World) inside(x, y int) bool {
+	return x >= 0 && y >= 0 && x < w.width && y < w.height
+}
+
+func (w *World) index(x, y int) int {
+	return y*w.width + x
+}
+
+// cost returns the cost of walking into the tile, negative if it's not walkable
+func (w *World) cost(idx int) float64 {
+	kind := w.kinds[idx]
+	if kind == KindBlocker {
+		return -1
+	}
+
+	cost := w.costs[kind]
+	if w.danger != nil {
+		cost += w.danger[idx]
+	}
+
+	return cost
+}
+
 // RenderPathImg renders a path on top of a World.
-func (w *World) renderPathImg(d game.Data, path []astar.Pather, cgOffset data.Position) {
-	width := len(w.World)
-	if width == 0 {
+func (w *World) renderPathImg(d game.Data, path []data.Position, cgOffset data.Position) {
+	if w.width == 0 {
 		return
 	}
-	height := len(w.World[0])
 
-	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	img := image.NewRGBA(image.Rect(0, 0, w.width, w.height))
 	draw.Draw(img, img.Bounds(), img, image.Point{}, draw.Over)
 
-	pathLocs := map[string]bool{}
+	pathLocs := map[data.Position]bool{}
 	for _, p := range path {
-		pT := p.(*Tile)
-		pathLocs[fmt.Sprintf("%d,%d", pT.X, pT.Y)] = true
+		pX, pY := relativePosition(d, p, cgOffset)
+		pathLocs[data.Position{X: pX, Y: pY}] = true
 	}
-	for x := 0; x < width; x++ {
-		for y := 0; y < height; y++ {
-			t := w.Tile(x, y)
-			if pathLocs[fmt.Sprintf("%d,%d", x, y)] {
+	for x := 0; x < w.width; x++ {
+		for y := 0; y < w.height; y++ {
+			if pathLocs[data.Position{X: x, Y: y}] {
 				img.Set(x, y, color.RGBA{
 					R: 36,
 					G: 255,
 					B: 0,
 					A: 255,
 				})
-			} else if t != nil {
-				switch t.Kind {
-				case KindPlain:
-					img.Set(x, y, color.White)
-				case KindBlocker:
-					img.Set(x, y, color.Black)
-				case KindSoftBlocker:
-					img.Set(x, y, color.RGBA{238, 238, 238, 255})
-				}
+				continue
+			}
+
+			kind := w.kinds[w.index(x, y)]
+			switch kind {
+			case KindPlain:
+				img.Set(x, y, color.White)
+			case KindBlocker:
+				img.Set(x, y, color.Black)
+			case KindSoftBlocker:
+				img.Set(x, y, color.RGBA{238, 238, 238, 255})
+			case KindDoor:
+				img.Set(x, y, color.RGBA{101, 67, 33, 255})
+			}
+			if w.danger != nil && w.danger[w.index(x, y)] > 0 && kind != KindBlocker {
+				img.Set(x, y, color.RGBA{255, 200, 200, 255})
 			}
 		}
 	}
@@ -126,11 +182,11 @@ func (w *World) renderPathImg(d game.Data, path []astar.Pather, cgOffset data.Po
 		img.Set(mPosX, mPosY, color.RGBA{255, 0, 255, 255})
 	}
 
-	img.Set(w.From().X, w.From().Y, color.RGBA{
+	img.Set(w.from.X, w.from.Y, color.RGBA{
 		R: 255, G: 0, B: 0, A: 255,
 	})
 
-	img.Set(w.To().X, w.To().Y, color.RGBA{
+	img.Set(w.to.X, w.to.Y, color.RGBA{
 		R: 0, G: 0, B: 255, A: 255,
 	})
 
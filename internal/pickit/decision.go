@@ -0,0 +1,124 @@
+package pickit
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+	"github.com/hectorgimenez/koolo/internal/config"
+)
+
+// bookOfSkillID is used because the Book of Skill doesn't work by name
+const bookOfSkillID = 552
+
+// State is what the pickup and stash decisions need to know about the character, besides the rules. Zero values
+// skip the checks depending on them, like the gold thresholds or the quotas.
+type State struct {
+	// Gold is carried by the character, MaxGold is the maximum it can carry
+	Gold    int
+	MaxGold int
+	// TotalGold includes the gold in the stash
+	TotalGold              int
+	MinGoldPickupThreshold int
+	Leveling               bool
+	// QuestRuns is set when the quests or leveling runs are enabled, quest items are picked up
+	QuestRuns bool
+	// QuotaReached reports if the stash already has enough items like this one, rule is empty for partial matches
+	QuotaReached func(it data.Item, rule nip.Rule) bool
+}
+
+// Decision is the result of ShouldPickUp or StashOverride, Rule is only set when the item was decided by a rule
+type Decision struct {
+	Keep   bool
+	Reason string
+	Rule   *nip.Rule
+}
+
+// ShouldPickUp decides if an item on the ground has to be picked up, items with fixed behaviour (runewords, quest
+// items, gold...) are decided before the rules
+func ShouldPickUp(rules nip.Rules, it data.Item, s State) Decision {
+	if it.IsRuneword {
+		return Decision{Keep: true, Reason: "runewords are always picked up"}
+	}
+
+	if it.Name == "Gold" && s.MaxGold > 0 && s.Gold >= s.MaxGold {
+		return Decision{Reason: "can't carry more gold"}
+	}
+
+	if it.Name == "WirtsLeg" {
+		return Decision{Keep: true, Reason: "WirtsLeg is always picked up"}
+	}
+
+	switch it.Name {
+	case "Scrollofinifuss", "LamEsensTome", "HoradricCube", "AmuletoftheViper", "StaffofKings", "HoradricStaff", "AJadeFigurine", "KhalimsEye", "KhalimsBrain", "KhalimsHeart", "KhalimsFlail":
+		if s.QuestRuns {
+			return Decision{Keep: true, Reason: "quest item, quests or leveling runs are enabled"}
+		}
+	}
+
+	if it.ID == bookOfSkillID {
+		return Decision{Keep: true, Reason: "Book of Skill is always picked up"}
+	}
+
+	// Skip picking up gold, usually early game there are small amounts of gold in many places full of enemies, better
+	// stay away of that
+	if s.Leveling && s.TotalGold < 50000 && it.Name != "Gold" {
+		return Decision{Keep: true, Reason: "leveling with less than 50000 gold, it will be sold"}
+	}
+
+	// Pickup all magic or superior items if total gold is low, filter will not pass and items will be sold to vendor
+	if s.TotalGold < s.MinGoldPickupThreshold && it.Quality >= item.QualityMagic {
+		return Decision{Keep: true, Reason: fmt.Sprintf("gold is below %d, it will be sold", s.MinGoldPickupThreshold)}
+	}
+
+	rule, result := rules.EvaluateAll(it)
+	if result == nip.RuleResultNoMatch {
+		return Decision{Reason: "no rule matches"}
+	}
+
+	// Stats are unknown until the item is identified, only quotas are checked, maxquantity needs a full match
+	quotaRule, reason := nip.Rule{}, "partial match, stats are checked once identified"
+	if result == nip.RuleResultFullMatch {
+		quotaRule, reason = rule, "full match"
+	}
+	if s.QuotaReached != nil && s.QuotaReached(it, quotaRule) {
+		return Decision{Reason: "quota reached", Rule: &rule}
+	}
+
+	return Decision{Keep: true, Reason: reason, Rule: &rule}
+}
+
+// StashOverride decides the items that are stashed or kept in the inventory regardless of the rules, found is false
+// for the rest of the items
+func StashOverride(it data.Item, s State) (d Decision, found bool) {
+	// Don't stash items from quests during leveling process, it makes things easier to track
+	if s.Leveling && it.IsFromQuest() {
+		return Decision{Reason: "quest items are not stashed while leveling"}, true
+	}
+
+	if it.IsRuneword {
+		return Decision{Keep: true, Reason: "runeword"}, true
+	}
+
+	// Don't stash quest items from A2 (for some reason they are not marked as quest items)
+	if it.Name == "StaffOfKings" || it.Name == "AmuletOfTheViper" {
+		return Decision{Reason: "act 2 quest items are not stashed"}, true
+	}
+
+	// Don't stash the Tomes, keys and WirtsLeg
+	if it.Name == item.TomeOfTownPortal || it.Name == item.TomeOfIdentify || it.Name == item.Key || it.Name == "WirtsLeg" {
+		return Decision{Reason: "tomes, keys and WirtsLeg are kept in the inventory"}, true
+	}
+
+	return Decision{}, false
+}
+
+// OfflineState is the state known from the character settings, used to test items without playing. Gold and quotas
+// depend on the game, they are not checked.
+func OfflineState(cfg *config.CharacterCfg) State {
+	return State{
+		QuestRuns: slices.Contains(cfg.Game.Runs, "quests") || slices.Contains(cfg.Game.Runs, "leveling"),
+	}
+}
@@ -0,0 +1,124 @@
+package pickit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/ledger"
+)
+
+// ParseItems reads item fixtures, a single item or a list. Every item can be written as data.Item or as an entry
+// of the drop ledger, ledger entries are told apart because their quality is a text, like "Unique".
+func ParseItems(raw []byte) ([]data.Item, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var fixtures []json.RawMessage
+	if raw[0] == '[' {
+		if err := json.Unmarshal(raw, &fixtures); err != nil {
+			return nil, fmt.Errorf("error reading items: %w", err)
+		}
+	} else {
+		fixtures = []json.RawMessage{raw}
+	}
+
+	items := make([]data.Item, 0, len(fixtures))
+	for i, f := range fixtures {
+		it, err := parseItem(f)
+		if err != nil {
+			return nil, fmt.Errorf("error reading item %d: %w", i+1, err)
+		}
+		items = append(items, it)
+	}
+
+	return items, nil
+}
+
+func parseItem(raw json.RawMessage) (data.Item, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return data.Item{}, err
+	}
+
+	if quality, found := fields["quality"]; found && len(quality) > 0 && quality[0] == '"' {
+		var e ledger.Entry
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return data.Item{}, err
+		}
+
+		return FromLedger(e)
+	}
+
+	var it data.Item
+	if err := json.Unmarshal(raw, &it); err != nil {
+		return data.Item{}, err
+	}
+
+	// ID is what rules use to know the item type, name is easier to write by hand
+	if it.Name != "" && !strings.EqualFold(string(item.GetNameByEnum(uint(it.ID))), string(it.Name)) {
+		id := item.GetIDByName(string(it.Name))
+		if id < 0 {
+			return data.Item{}, fmt.Errorf("unknown item name %s", it.Name)
+		}
+		it.ID = id
+	}
+	it.Name = item.GetNameByEnum(uint(it.ID))
+
+	return it, nil
+}
+
+// FromLedger rebuilds the item stored in the drop ledger
+func FromLedger(e ledger.Entry) (data.Item, error) {
+	id := item.GetIDByName(e.Name)
+	if id < 0 {
+		return data.Item{}, fmt.Errorf("unknown item name %s", e.Name)
+	}
+
+	quality, found := qualities[e.Quality]
+	if !found {
+		return data.Item{}, fmt.Errorf("unknown item quality %s", e.Quality)
+	}
+
+	it := data.Item{
+		ID:         id,
+		Name:       item.GetNameByEnum(uint(id)),
+		Quality:    quality,
+		Ethereal:   e.Ethereal,
+		Identified: e.Identified,
+		Stats:      make(stat.Stats, 0, len(e.Stats)),
+	}
+	for _, s := range e.Stats {
+		statID, found := statIDs[s.Name]
+		if !found {
+			return data.Item{}, fmt.Errorf("unknown stat %s", s.Name)
+		}
+		it.Stats = append(it.Stats, stat.Data{ID: statID, Value: s.Value, Layer: s.Layer})
+	}
+
+	return it, nil
+}
+
+var qualities = func() map[string]item.Quality {
+	q := make(map[string]item.Quality)
+	for quality := item.QualityLowQuality; quality <= item.QualityCrafted; quality++ {
+		q[ledger.QualityName(quality)] = quality
+	}
+
+	return q
+}()
+
+var statIDs = func() map[string]stat.ID {
+	ids := make(map[string]stat.ID, len(stat.StringStats))
+	for id, name := range stat.StringStats {
+		ids[name] = stat.ID(id)
+	}
+
+	return ids
+}()
@@ -0,0 +1,161 @@
+package pickit
+
+import (
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+	"github.com/hectorgimenez/koolo/internal/ledger"
+)
+
+// RuleEvaluation explains the result of a single rule for the item, only rules matching the item type, quality or
+// name (first part of the rule) are reported, the rest are just counted
+type RuleEvaluation struct {
+	Rule     string `json:"rule"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Pickup   string `json:"pickup"`
+	Stash    string `json:"stash"`
+	Reason   string `json:"reason"`
+	Selected bool   `json:"selected"`
+}
+
+// Report is the result of testing an item against a rule set, at the two stages where rules are evaluated: before
+// identifying the item (pickup) and after identifying it (stash)
+type Report struct {
+	Item         string           `json:"item"`
+	Quality      string           `json:"quality"`
+	Ethereal     bool             `json:"ethereal"`
+	PickedUp     bool             `json:"pickedUp"`
+	PickupRule   string           `json:"pickupRule,omitempty"`
+	PickupReason string           `json:"pickupReason"`
+	Stashed      bool             `json:"stashed"`
+	StashRule    string           `json:"stashRule,omitempty"`
+	StashReason  string           `json:"stashReason"`
+	Rules        []RuleEvaluation `json:"rules"`
+	// NotMatching is the number of rules with a different item type, quality or name
+	NotMatching int `json:"notMatching"`
+	// Unchecked lists what the bot checks but the test can't, because it depends on the game state
+	Unchecked []string `json:"unchecked"`
+}
+
+// Test evaluates the item with the same decisions used by the bot. Items on the ground are not identified, except
+// normal and superior ones, so pickup is decided by the first part of the rules and stash by the full rule once
+// identified. Checks depending on state the test doesn't know are listed in Report.Unchecked.
+func Test(rules nip.Rules, it data.Item, s State) Report {
+	onGround := it
+	if it.Quality >= item.QualityMagic {
+		onGround.Identified = false
+	}
+	identified := it
+	identified.Identified = true
+
+	r := Report{
+		Item:      string(it.Name),
+		Quality:   ledger.QualityName(it.Quality),
+		Ethereal:  it.Ethereal,
+		Rules:     make([]RuleEvaluation, 0),
+		Unchecked: unchecked(s),
+	}
+
+	pickup := ShouldPickUp(rules, onGround, s)
+	r.PickedUp, r.PickupReason = pickup.Keep, pickup.Reason
+	if pickup.Rule != nil {
+		r.PickupRule = ruleLocation(*pickup.Rule)
+	}
+
+	stash := shouldStash(rules, identified, s)
+	r.Stashed, r.StashReason = stash.Keep, stash.Reason
+	if stash.Rule != nil {
+		r.StashRule = ruleLocation(*stash.Rule)
+	}
+
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+
+		pickup, pickupErr := rule.Evaluate(onGround)
+		stash, stashErr := rule.Evaluate(identified)
+		if pickupErr == nil && stashErr == nil && pickup == nip.RuleResultNoMatch && stash == nip.RuleResultNoMatch {
+			r.NotMatching++
+			continue
+		}
+
+		r.Rules = append(r.Rules, RuleEvaluation{
+			Rule:     rule.RawLine,
+			File:     rule.Filename,
+			Line:     rule.LineNumber,
+			Pickup:   resultText(pickup, pickupErr),
+			Stash:    resultText(stash, stashErr),
+			Reason:   reason(rule, stash, pickupErr, stashErr),
+			Selected: r.StashRule != "" && ruleLocation(rule) == r.StashRule,
+		})
+	}
+
+	return r
+}
+
+// shouldStash decides if the identified item is kept, items not matching any rule are sold by the bot before stashing
+func shouldStash(rules nip.Rules, it data.Item, s State) Decision {
+	if d, found := StashOverride(it, s); found {
+		return d
+	}
+
+	rule, result := rules.EvaluateAll(it)
+	if result != nip.RuleResultFullMatch {
+		return Decision{Reason: "no rule fully matches, it will be sold"}
+	}
+	if s.QuotaReached != nil && s.QuotaReached(it, rule) {
+		return Decision{Reason: "quota reached", Rule: &rule}
+	}
+
+	return Decision{Keep: true, Reason: "full match", Rule: &rule}
+}
+
+func unchecked(s State) []string {
+	u := []string{"inventory lock, potions and items found during the first run, they depend on the inventory"}
+	if s.QuotaReached == nil {
+		u = append(u, "quotas and max quantity, they depend on the items in the stash")
+	}
+	if s.MaxGold == 0 {
+		u = append(u, "gold thresholds, they depend on the gold of the character")
+	}
+
+	return u
+}
+
+func reason(rule nip.Rule, stash nip.RuleResult, pickupErr, stashErr error) string {
+	switch {
+	case pickupErr != nil:
+		return pickupErr.Error()
+	case stashErr != nil:
+		return stashErr.Error()
+	case stash != nip.RuleResultFullMatch:
+		return "item type matches, but the stats don't once identified"
+	case rule.MaxQuantity() > 0:
+		return fmt.Sprintf("full match, limited to %d items in the stash", rule.MaxQuantity())
+	}
+
+	return "full match"
+}
+
+func resultText(result nip.RuleResult, err error) string {
+	if err != nil {
+		return "error"
+	}
+
+	switch result {
+	case nip.RuleResultFullMatch:
+		return "full match"
+	case nip.RuleResultPartial:
+		return "partial match"
+	}
+
+	return "no match"
+}
+
+func ruleLocation(rule nip.Rule) string {
+	return fmt.Sprintf("%s:%d", rule.Filename, rule.LineNumber)
+}
@@ -0,0 +1,79 @@
+package pickit_test
+
+import (
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+	"github.com/hectorgimenez/koolo/internal/ledger"
+	"github.com/hectorgimenez/koolo/internal/pickit"
+)
+
+func testRules(t *testing.T, lines ...string) nip.Rules {
+	rules := make(nip.Rules, 0, len(lines))
+	for i, line := range lines {
+		rule, err := nip.NewRule(line, "test.nip", i+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func TestTestAppliesTheBotDecisions(t *testing.T) {
+	rules := testRules(t, "[type] == ring && [quality] == unique")
+
+	wirtsLeg := data.Item{ID: item.GetIDByName("WirtsLeg"), Name: "WirtsLeg", Quality: item.QualityNormal}
+	r := pickit.Test(rules, wirtsLeg, pickit.State{})
+	if !r.PickedUp || r.Stashed {
+		t.Errorf("WirtsLeg should be picked up and kept in the inventory, got %+v", r)
+	}
+
+	runeword := data.Item{ID: item.GetIDByName("Crystalsword"), Name: "Crystalsword", Quality: item.QualityNormal, IsRuneword: true}
+	r = pickit.Test(rules, runeword, pickit.State{})
+	if !r.PickedUp || !r.Stashed {
+		t.Errorf("runewords should be picked up and stashed, got %+v", r)
+	}
+
+	ring := data.Item{ID: item.GetIDByName("Ring"), Name: "Ring", Quality: item.QualityUnique}
+	r = pickit.Test(rules, ring, pickit.State{})
+	if !r.PickedUp || !r.Stashed || r.StashRule != "test.nip:1" {
+		t.Errorf("unique ring should be kept by the rule, got %+v", r)
+	}
+
+	full := pickit.State{QuotaReached: func(data.Item, nip.Rule) bool { return true }}
+	r = pickit.Test(rules, ring, full)
+	if r.PickedUp || r.Stashed || r.PickupReason != "quota reached" {
+		t.Errorf("unique ring should be skipped when the quota is reached, got %+v", r)
+	}
+}
+
+func TestShouldPickUpGoldThresholds(t *testing.T) {
+	rules := testRules(t, "[type] == ring && [quality] == unique")
+	magic := data.Item{ID: item.GetIDByName("Ring"), Name: "Ring", Quality: item.QualityMagic}
+
+	if pickit.ShouldPickUp(rules, magic, pickit.State{TotalGold: 100000, MinGoldPickupThreshold: 5000}).Keep {
+		t.Error("magic ring shouldn't be picked up with enough gold")
+	}
+	if !pickit.ShouldPickUp(rules, magic, pickit.State{TotalGold: 1000, MinGoldPickupThreshold: 5000}).Keep {
+		t.Error("magic ring should be picked up to sell when gold is low")
+	}
+
+	gold := data.Item{Name: "Gold"}
+	if pickit.ShouldPickUp(rules, gold, pickit.State{Gold: 10000, MaxGold: 10000}).Keep {
+		t.Error("gold shouldn't be picked up when the character can't carry more")
+	}
+}
+
+func TestFromLedgerCraftedItem(t *testing.T) {
+	it, err := pickit.FromLedger(ledger.Entry{Name: "Amulet", Quality: ledger.QualityName(item.QualityCrafted)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if it.Quality != item.QualityCrafted {
+		t.Errorf("expected crafted quality, got %d", it.Quality)
+	}
+}
@@ -0,0 +1,196 @@
+package quota
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+	"github.com/hectorgimenez/koolo/internal/config"
+)
+
+// Quota limits how many items matching it are kept by the character
+type Quota struct {
+	Description string
+	Max         int
+	matches     func(data.Item) bool
+}
+
+func (q Quota) String() string {
+	return q.Description
+}
+
+func (q Quota) Matches(i data.Item) bool {
+	return q.matches(i)
+}
+
+// Count returns how many items match the quota
+func (q Quota) Count(items []data.Item) int {
+	count := 0
+	for _, i := range items {
+		if q.matches(i) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// FromConfig builds the quotas configured for the character, rules are needed for the quotas by NIP rule
+func FromConfig(quotas []config.ItemQuota, rules nip.Rules) []Quota {
+	built := make([]Quota, 0, len(quotas))
+	for _, q := range quotas {
+		built = append(built, fromItemQuota(q, rules))
+	}
+
+	return built
+}
+
+func fromItemQuota(q config.ItemQuota, rules nip.Rules) Quota {
+	conditions := make([]func(data.Item) bool, 0)
+	desc := make([]string, 0)
+
+	if q.Name != "" {
+		desc = append(desc, "name="+string(q.Name))
+		conditions = append(conditions, func(i data.Item) bool {
+			return strings.EqualFold(string(i.Name), string(q.Name))
+		})
+	}
+	if q.Type != "" {
+		desc = append(desc, "type="+q.Type)
+		code := nip.TypeAliases[strings.ToLower(q.Type)]
+		conditions = append(conditions, func(i data.Item) bool {
+			return i.Type().IsType(code)
+		})
+	}
+	if q.Quality != "" {
+		desc = append(desc, "quality="+q.Quality)
+		quality, _ := config.NIPQuality(q.Quality)
+		conditions = append(conditions, func(i data.Item) bool {
+			return i.Quality == quality
+		})
+	}
+	if q.Rule != "" {
+		desc = append(desc, "rule="+q.Rule)
+		matching := rulesAt(rules, q.Rule)
+		conditions = append(conditions, func(i data.Item) bool {
+			return slices.ContainsFunc(matching, func(r nip.Rule) bool {
+				return matchesRule(r, i)
+			})
+		})
+	}
+
+	return Quota{
+		Description: strings.Join(desc, " "),
+		Max:         q.Max,
+		matches: func(i data.Item) bool {
+			for _, c := range conditions {
+				if !c(i) {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// FromRule is the NIP maxquantity of the rule, the items matching the rule are counted
+func FromRule(r nip.Rule) Quota {
+	return Quota{
+		Description: fmt.Sprintf("maxquantity of %s:%d", filepath.Base(r.Filename), r.LineNumber),
+		Max:         r.MaxQuantity(),
+		matches: func(i data.Item) bool {
+			return matchesRule(r, i)
+		},
+	}
+}
+
+// matchesRule accepts partial matches of items not identified yet, stats can't be checked until then
+func matchesRule(r nip.Rule, i data.Item) bool {
+	res, err := r.Evaluate(i)
+	if err != nil {
+		return false
+	}
+
+	return res == nip.RuleResultFullMatch || (res == nip.RuleResultPartial && !i.Identified)
+}
+
+// rulesAt returns the rules in the file, or only the one in the given line for file:line locations
+func rulesAt(rules nip.Rules, location string) nip.Rules {
+	file, line := location, 0
+	if idx := strings.LastIndex(location, ":"); idx > 0 {
+		if n, err := strconv.Atoi(location[idx+1:]); err == nil {
+			file, line = location[:idx], n
+		}
+	}
+
+	found := make(nip.Rules, 0)
+	for _, r := range rules {
+		if !strings.EqualFold(filepath.Base(r.Filename), filepath.Base(file)) {
+			continue
+		}
+		if line == 0 || r.LineNumber == line {
+			found = append(found, r)
+		}
+	}
+
+	return found
+}
+
+// Remaining returns how many more items like the given one can be kept, and the quota limiting it. It's -1 if no
+// quota applies to the item. The item itself is not counted even if it's already owned, like an item waiting in
+// the inventory to be stashed.
+func Remaining(quotas []Quota, owned []data.Item, i data.Item) (Quota, int) {
+	others := slices.DeleteFunc(slices.Clone(owned), func(o data.Item) bool {
+		return i.UnitID != 0 && o.UnitID == i.UnitID
+	})
+
+	limiting, remaining := Quota{}, -1
+	for _, q := range quotas {
+		if !q.matches(i) {
+			continue
+		}
+
+		left := max(q.Max-q.Count(others), 0)
+		if remaining < 0 || left < remaining {
+			limiting, remaining = q, left
+		}
+	}
+
+	return limiting, remaining
+}
+
+// Items kept by each supervisor out of the shared stash, last time they were seen in game
+var personal = struct {
+	sync.Mutex
+	items map[string][]data.Item
+}{items: make(map[string][]data.Item)}
+
+// Owned returns the items counted for the quotas of the supervisor: its stash, shared stash, inventory and cube,
+// plus the personal items of the supervisors sharing the stash with it. Personal items are remembered, so other
+// supervisors can count them while this one is not playing.
+func Owned(supervisor string, inventory data.Inventory, sharedWith []string) []data.Item {
+	return slices.Concat(inventory.ByLocation(item.LocationInventory), Stashed(supervisor, inventory, sharedWith))
+}
+
+// Stashed is like Owned without the inventory of the supervisor, used while stashing so the items waiting in the
+// inventory don't count against each other. The items accepted to be stashed have to be added by the caller.
+func Stashed(supervisor string, inventory data.Inventory, sharedWith []string) []data.Item {
+	personal.Lock()
+	defer personal.Unlock()
+
+	personal.items[supervisor] = inventory.ByLocation(item.LocationStash, item.LocationInventory, item.LocationCube)
+	stashed := inventory.ByLocation(item.LocationStash, item.LocationCube, item.LocationSharedStash)
+	for _, other := range sharedWith {
+		if other != supervisor {
+			stashed = append(stashed, personal.items[other]...)
+		}
+	}
+
+	return stashed
+}
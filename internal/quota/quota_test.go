@@ -0,0 +1,134 @@
+package quota_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/nip"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/quota"
+)
+
+func TestQuotaQualityUsesNIPNames(t *testing.T) {
+	quotas := quota.FromConfig([]config.ItemQuota{
+		{Type: "amulet", Quality: "crafted", Max: 2},
+		{Type: "ring", Quality: "LowQuality", Max: 1},
+	}, nil)
+
+	crafted := data.Item{ID: item.GetIDByName("Amulet"), Name: "Amulet", Quality: item.QualityCrafted}
+	rare := data.Item{ID: item.GetIDByName("Amulet"), Name: "Amulet", Quality: item.QualityRare}
+	if !quotas[0].Matches(crafted) || quotas[0].Matches(rare) {
+		t.Error("crafted quota should only match crafted amulets")
+	}
+
+	low := data.Item{ID: item.GetIDByName("Ring"), Name: "Ring", Quality: item.QualityLowQuality}
+	if !quotas[1].Matches(low) {
+		t.Error("quality should be case-insensitive")
+	}
+}
+
+// skulls returns n perfect skulls in the location, with unit IDs starting at firstID
+func skulls(n int, location item.LocationType, firstID data.UnitID) []data.Item {
+	items := make([]data.Item, 0, n)
+	for i := 0; i < n; i++ {
+		items = append(items, data.Item{
+			UnitID:     firstID + data.UnitID(i),
+			ID:         item.GetIDByName("PerfectSkull"),
+			Name:       "PerfectSkull",
+			Quality:    item.QualityNormal,
+			Identified: true,
+			Location:   item.Location{LocationType: location},
+		})
+	}
+
+	return items
+}
+
+// stashPass decides the inventory items to stash like the stash action: stashed items and the ones accepted before
+// are counted, the rest of the inventory is not
+func stashPass(quotas []quota.Quota, supervisor string, inv data.Inventory) []data.Item {
+	accepted := make([]data.Item, 0)
+	for _, i := range inv.ByLocation(item.LocationInventory) {
+		owned := slices.Concat(quota.Stashed(supervisor, inv, nil), accepted)
+		if _, remaining := quota.Remaining(quotas, owned, i); remaining != 0 {
+			accepted = append(accepted, i)
+		}
+	}
+
+	return accepted
+}
+
+func TestRemainingWithIdenticalItemsInTheInventory(t *testing.T) {
+	rule, err := nip.NewRule("[name] == perfectskull # # [maxquantity] == 10", "gems.nip", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		quotas []quota.Quota
+	}{
+		{name: "configured quota", quotas: quota.FromConfig([]config.ItemQuota{{Name: "PerfectSkull", Max: 10}}, nil)},
+		{name: "maxquantity", quotas: []quota.Quota{quota.FromRule(rule)}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tests := []struct {
+				stashed, inventory, want int
+			}{
+				{stashed: 9, inventory: 2, want: 1},
+				{stashed: 7, inventory: 2, want: 2},
+				{stashed: 5, inventory: 8, want: 5},
+				{stashed: 10, inventory: 3, want: 0},
+				{stashed: 0, inventory: 12, want: 10},
+			}
+
+			for _, p := range tests {
+				inv := data.Inventory{AllItems: append(skulls(p.stashed, item.LocationStash, 1), skulls(p.inventory, item.LocationInventory, 100)...)}
+				if got := stashPass(tc.quotas, "stash-pass", inv); len(got) != p.want {
+					t.Errorf("%d stashed and %d in the inventory: expected %d stashed, got %d", p.stashed, p.inventory, p.want, len(got))
+				}
+			}
+		})
+	}
+}
+
+func TestRemainingCountsTheInventoryForNewItems(t *testing.T) {
+	quotas := quota.FromConfig([]config.ItemQuota{{Name: "PerfectSkull", Max: 10}}, nil)
+	inv := data.Inventory{AllItems: append(skulls(9, item.LocationStash, 1), skulls(1, item.LocationInventory, 100)...)}
+	ground := skulls(1, item.LocationGround, 200)[0]
+
+	// Picking up another one would exceed the quota once both are stashed
+	if q, remaining := quota.Remaining(quotas, quota.Owned("pickup", inv, nil), ground); remaining != 0 || q.Max != 10 {
+		t.Errorf("expected the quota reached for a new item, %d remaining", remaining)
+	}
+	// The item itself is not counted
+	if _, remaining := quota.Remaining(quotas, quota.Owned("pickup", inv, nil), inv.ByLocation(item.LocationInventory)[0]); remaining != 1 {
+		t.Errorf("expected 1 remaining for the item in the inventory, got %d", remaining)
+	}
+	// No quota for other items
+	rune1 := data.Item{UnitID: 300, ID: item.GetIDByName("BerRune"), Name: "BerRune"}
+	if _, remaining := quota.Remaining(quotas, quota.Owned("pickup", inv, nil), rune1); remaining != -1 {
+		t.Errorf("expected no limit for items without quota, got %d", remaining)
+	}
+}
+
+func TestOwnedCountsSupervisorsSharingTheStash(t *testing.T) {
+	sorc := data.Inventory{AllItems: slices.Concat(skulls(2, item.LocationStash, 1), skulls(1, item.LocationInventory, 10), skulls(3, item.LocationSharedStash, 20))}
+	pala := data.Inventory{AllItems: slices.Concat(skulls(4, item.LocationStash, 30), skulls(2, item.LocationInventory, 40), skulls(1, item.LocationCube, 50), skulls(3, item.LocationSharedStash, 20))}
+
+	quota.Owned("owned-sorc", sorc, nil)
+	sharedWith := []string{"owned-sorc", "owned-pala"}
+
+	// Pala's items plus the personal items of sorc, the shared stash is counted once
+	if got := len(quota.Owned("owned-pala", pala, sharedWith)); got != 4+2+1+3+2+1 {
+		t.Errorf("expected %d owned items, got %d", 4+2+1+3+2+1, got)
+	}
+	// Own inventory is not counted while stashing, sorc's inventory is counted as it's not being stashed now
+	if got := len(quota.Stashed("owned-pala", pala, sharedWith)); got != 4+1+3+2+1 {
+		t.Errorf("expected %d stashed items, got %d", 4+1+3+2+1, got)
+	}
+}
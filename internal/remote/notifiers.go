@@ -0,0 +1,66 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/remote/discord"
+	"github.com/hectorgimenez/koolo/internal/remote/routing"
+	"github.com/hectorgimenez/koolo/internal/remote/telegram"
+	"github.com/hectorgimenez/koolo/internal/remote/webhook"
+	"golang.org/x/sync/errgroup"
+)
+
+// StartNotifiers initializes the enabled remote notifiers, register is called with the routed handler of each one
+func StartNotifiers(ctx context.Context, g *errgroup.Group, logger *slog.Logger, register func(h event.Handler)) error {
+	// Discord Bot initialization
+	if config.Koolo.Discord.Enabled {
+		discordBot, err := discord.NewBot(config.Koolo.Discord.Token, config.Koolo.Discord.ChannelID)
+		if err != nil {
+			return fmt.Errorf("discord could not been initialized: %w", err)
+		}
+
+		register(routing.NewRouter(config.Koolo.Discord.Routing, routing.DiscordLegacyRules()).Wrap(discordBot.Handle))
+		g.Go(func() error {
+			return discordBot.Start(ctx)
+		})
+	}
+
+	// Telegram Bot initialization
+	if config.Koolo.Telegram.Enabled {
+		telegramBot, err := telegram.NewBot(config.Koolo.Telegram.Token, config.Koolo.Telegram.ChatID, logger)
+		if err != nil {
+			return fmt.Errorf("telegram could not been initialized: %w", err)
+		}
+
+		register(routing.NewRouter(config.Koolo.Telegram.Routing, routing.DefaultRules()).Wrap(telegramBot.Handle))
+		g.Go(func() error {
+			return telegramBot.Start(ctx)
+		})
+	}
+
+	// Webhook initialization
+	if config.Koolo.Webhook.Enabled {
+		webhookNotifier, err := webhook.NewNotifier(
+			config.Koolo.Webhook.URLs,
+			config.Koolo.Webhook.Events,
+			config.Koolo.Webhook.Secret,
+			config.Koolo.Webhook.IncludeScreenshot,
+			config.Koolo.Webhook.MaxRetries,
+			logger,
+		)
+		if err != nil {
+			return fmt.Errorf("webhook could not been initialized: %w", err)
+		}
+
+		register(routing.NewRouter(config.Koolo.Webhook.Routing, routing.MatchAll()).Wrap(webhookNotifier.Handle))
+		g.Go(func() error {
+			return webhookNotifier.Start(ctx)
+		})
+	}
+
+	return nil
+}
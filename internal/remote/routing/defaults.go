@@ -0,0 +1,62 @@
+package routing
+
+import (
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+var (
+	gameCreatedRule = config.RoutingRule{Events: []string{"GameCreatedEvent"}}
+	runStartedRule  = config.RoutingRule{Events: []string{"RunStartedEvent"}}
+	runFinishedRule = config.RoutingRule{Events: []string{"RunFinishedEvent"}, Reasons: []string{string(event.FinishedOK)}}
+	chickenRule     = config.RoutingRule{
+		Events:  []string{"GameFinishedEvent"},
+		Reasons: []string{string(event.FinishedChicken), string(event.FinishedMercChicken), string(event.FinishedDied)},
+	}
+)
+
+// alwaysSent are the events notified regardless of the Discord message toggles
+func alwaysSent() []config.RoutingRule {
+	return []config.RoutingRule{
+		{Events: []string{"ItemStashedEvent"}},
+		{Events: []string{"SessionBreakEvent"}},
+		{Events: []string{"StashFullEvent"}},
+		// "Game paused/resumed" and "TP Requested" messages were always sent
+		{Events: []string{"GamePausedEvent"}},
+		{Events: []string{"CompanionRequestedTPEvent"}},
+		{Events: []string{"GameFinishedEvent", "RunFinishedEvent"}, Reasons: []string{string(event.FinishedError)}},
+	}
+}
+
+// DefaultRules are used by notifiers without explicit rules nor message toggles, every event meant for the user
+// is sent
+func DefaultRules() []config.RoutingRule {
+	return append(alwaysSent(), gameCreatedRule, runStartedRule, runFinishedRule, chickenRule)
+}
+
+// DiscordLegacyRules translates the old Discord message toggles into routing rules, used when Discord has no
+// explicit rules so existing configurations keep working the same way
+func DiscordLegacyRules() []config.RoutingRule {
+	rules := alwaysSent()
+
+	if config.Koolo.Discord.EnableGameCreatedMessages {
+		rules = append(rules, gameCreatedRule)
+	}
+	if config.Koolo.Discord.EnableNewRunMessages {
+		rules = append(rules, runStartedRule)
+	}
+	if config.Koolo.Discord.EnableRunFinishMessages {
+		rules = append(rules, runFinishedRule)
+	}
+	if config.Koolo.Discord.EnableDiscordChickenMessages {
+		rules = append(rules, chickenRule)
+	}
+
+	return rules
+}
+
+// MatchAll is used by notifiers that already have their own event filter, when routing rules are set as well both
+// filters apply and the event must pass the two of them
+func MatchAll() []config.RoutingRule {
+	return []config.RoutingRule{{}}
+}
@@ -0,0 +1,202 @@
+package routing
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/ledger"
+)
+
+const maxDedupKeys = 256
+
+// Router filters the events sent to a single remote notifier based on its routing config
+type Router struct {
+	rules       []config.RoutingRule
+	maxEvents   int
+	window      time.Duration
+	dedupWindow time.Duration
+
+	mu       sync.Mutex
+	sentAt   []time.Time
+	lastSeen map[string]time.Time
+}
+
+// NewRouter builds a router from the notifier config, defaultRules are used when the config has no rules
+func NewRouter(cfg config.NotifierRouting, defaultRules []config.RoutingRule) *Router {
+	rules := cfg.Rules
+	if len(rules) == 0 {
+		rules = defaultRules
+	}
+
+	return &Router{
+		rules:       rules,
+		maxEvents:   cfg.RateLimit.MaxEvents,
+		window:      cfg.RateLimit.Window,
+		dedupWindow: cfg.DedupWindow,
+		lastSeen:    make(map[string]time.Time),
+	}
+}
+
+// Wrap returns a handler that only calls h for the events allowed by the router
+func (r *Router) Wrap(h event.Handler) event.Handler {
+	return func(ctx context.Context, e event.Event) error {
+		if !r.Allow(e) {
+			return nil
+		}
+
+		return h(ctx, e)
+	}
+}
+
+func (r *Router) Allow(e event.Event) bool {
+	matched := false
+	for _, rule := range r.rules {
+		if Matches(rule, e) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if r.dedupWindow > 0 {
+		key := dedupKey(e)
+		if last, found := r.lastSeen[key]; found && now.Sub(last) < r.dedupWindow {
+			return false
+		}
+		r.lastSeen[key] = now
+
+		// Keep the map small, expired keys are not needed anymore
+		if len(r.lastSeen) > maxDedupKeys {
+			for k, t := range r.lastSeen {
+				if now.Sub(t) >= r.dedupWindow {
+					delete(r.lastSeen, k)
+				}
+			}
+		}
+	}
+
+	if r.maxEvents > 0 && r.window > 0 {
+		recent := r.sentAt[:0]
+		for _, t := range r.sentAt {
+			if now.Sub(t) < r.window {
+				recent = append(recent, t)
+			}
+		}
+		r.sentAt = recent
+		if len(r.sentAt) >= r.maxEvents {
+			return false
+		}
+		r.sentAt = append(r.sentAt, now)
+	}
+
+	return true
+}
+
+// Matches checks every non-empty condition of the rule, conditions about a field the event doesn't have
+// (like a finish reason for an ItemStashedEvent) never match
+func Matches(rule config.RoutingRule, e event.Event) bool {
+	if len(rule.Events) > 0 && !containsFold(rule.Events, reflect.TypeOf(e).Name()) {
+		return false
+	}
+	if len(rule.Supervisors) > 0 && !containsFold(rule.Supervisors, e.Supervisor()) {
+		return false
+	}
+
+	if len(rule.Reasons) > 0 {
+		reason, found := eventReason(e)
+		if !found || !containsFold(rule.Reasons, reason) {
+			return false
+		}
+	}
+
+	if len(rule.ItemQualities) > 0 || len(rule.RuleFiles) > 0 {
+		drop, found := itemDrop(e)
+		if !found {
+			return false
+		}
+		if len(rule.ItemQualities) > 0 && !containsFold(rule.ItemQualities, ledger.QualityName(drop.Item.Quality)) {
+			return false
+		}
+		if len(rule.RuleFiles) > 0 && !containsFold(rule.RuleFiles, drop.RuleFile) && !containsFold(rule.RuleFiles, filepath.Base(drop.RuleFile)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dedupKey identifies repeated events, the message alone is not enough because fixed messages like "Starting run"
+// are shared by different runs
+func dedupKey(e event.Event) string {
+	key := reflect.TypeOf(e).Name() + "|" + e.Supervisor() + "|" + e.Message()
+	if reason, found := eventReason(e); found {
+		key += "|" + reason
+	}
+
+	switch evt := e.(type) {
+	case event.GameCreatedEvent:
+		key += "|" + evt.Name
+	case event.RunStartedEvent:
+		key += "|" + evt.RunName
+	case event.RunFinishedEvent:
+		key += "|" + evt.RunName
+	case event.StuckEvent:
+		key += "|" + strconv.Itoa(int(evt.Area))
+	}
+	if drop, found := itemDrop(e); found {
+		key += "|" + string(drop.Item.Name) + "|" + strconv.Itoa(int(drop.Item.UnitID))
+	}
+
+	return key
+}
+
+// eventReason returns the finish reason of game and run events, or the stuck reason of stuck events
+func eventReason(e event.Event) (string, bool) {
+	switch evt := e.(type) {
+	case event.GameFinishedEvent:
+		return string(evt.Reason), true
+	case event.RunFinishedEvent:
+		return string(evt.Reason), true
+	case event.StuckEvent:
+		return string(evt.Reason), true
+	}
+
+	return "", false
+}
+
+func itemDrop(e event.Event) (data.Drop, bool) {
+	switch evt := e.(type) {
+	case event.ItemStashedEvent:
+		return evt.Item, true
+	case event.AboutToStashItemEvent:
+		return evt.Item, true
+	case event.IdentifiedItemEvent:
+		return evt.Item, true
+	}
+
+	return data.Drop{}, false
+}
+
+func containsFold(values []string, v string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, v) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,111 @@
+package routing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/remote/routing"
+)
+
+func stashed(supervisor string, quality item.Quality, ruleFile string, unitID data.UnitID) event.ItemStashedEvent {
+	drop := data.Drop{Item: data.Item{UnitID: unitID, Name: "Ring", Quality: quality}, RuleFile: ruleFile}
+	return event.ItemStashed(event.Text(supervisor, "Item stashed"), drop, "baal")
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  config.RoutingRule
+		event event.Event
+		want  bool
+	}{
+		{"empty rule matches everything", config.RoutingRule{}, event.RunStarted(event.Text("sorc", "Starting run"), "baal"), true},
+		{"event type is case-insensitive", config.RoutingRule{Events: []string{"runstartedevent"}}, event.RunStarted(event.Text("sorc", ""), "baal"), true},
+		{"other event type", config.RoutingRule{Events: []string{"GameCreatedEvent"}}, event.RunStarted(event.Text("sorc", ""), "baal"), false},
+		{"supervisor", config.RoutingRule{Supervisors: []string{"pala"}}, event.RunStarted(event.Text("sorc", ""), "baal"), false},
+		{"finish reason", config.RoutingRule{Reasons: []string{"death"}}, event.GameFinished(event.Text("sorc", ""), event.FinishedDied), true},
+		{"other finish reason", config.RoutingRule{Reasons: []string{"death"}}, event.GameFinished(event.Text("sorc", ""), event.FinishedChicken), false},
+		{"stuck reason", config.RoutingRule{Reasons: []string{"door"}}, event.Stuck(event.Text("sorc", ""), event.StuckBlockedByDoor, "", 1, 0, data.Position{}), true},
+		{"reason on an event without reason", config.RoutingRule{Reasons: []string{"ok"}}, stashed("sorc", item.QualityUnique, "", 1), false},
+		{"item quality", config.RoutingRule{ItemQualities: []string{"unique"}}, stashed("sorc", item.QualityUnique, "", 1), true},
+		{"crafted item quality", config.RoutingRule{ItemQualities: []string{"Crafted"}}, stashed("sorc", item.QualityCrafted, "", 1), true},
+		{"other item quality", config.RoutingRule{ItemQualities: []string{"Set"}}, stashed("sorc", item.QualityUnique, "", 1), false},
+		{"rule file base name", config.RoutingRule{RuleFiles: []string{"uniques.nip"}}, stashed("sorc", item.QualityUnique, "config/sorc/pickit/uniques.nip", 1), true},
+		{"item condition on an event without item", config.RoutingRule{ItemQualities: []string{"Unique"}}, event.RunStarted(event.Text("sorc", ""), "baal"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := routing.Matches(tt.rule, tt.event); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDedupUsesTheEventPayload(t *testing.T) {
+	r := routing.NewRouter(config.NotifierRouting{DedupWindow: time.Minute}, routing.MatchAll())
+
+	if !r.Allow(event.RunStarted(event.Text("sorc", "Starting run"), "andariel")) {
+		t.Fatal("first event should be sent")
+	}
+	if r.Allow(event.RunStarted(event.Text("sorc", "Starting run"), "andariel")) {
+		t.Error("repeated event should be suppressed")
+	}
+	if !r.Allow(event.RunStarted(event.Text("sorc", "Starting run"), "mephisto")) {
+		t.Error("same message for a different run should be sent")
+	}
+	if !r.Allow(event.RunStarted(event.Text("pala", "Starting run"), "andariel")) {
+		t.Error("same event for a different supervisor should be sent")
+	}
+	if !r.Allow(stashed("sorc", item.QualityUnique, "", 1)) || !r.Allow(stashed("sorc", item.QualityUnique, "", 2)) {
+		t.Error("different items with the same message should be sent")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	cfg := config.NotifierRouting{}
+	cfg.RateLimit.MaxEvents = 2
+	cfg.RateLimit.Window = time.Minute
+	r := routing.NewRouter(cfg, routing.MatchAll())
+
+	for i, want := range []bool{true, true, false} {
+		if got := r.Allow(event.RunStarted(event.Text("sorc", ""), "baal")); got != want {
+			t.Errorf("event %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+func TestLegacyRules(t *testing.T) {
+	config.Koolo = &config.KooloCfg{}
+	config.Koolo.Discord.EnableNewRunMessages = false
+	config.Koolo.Discord.EnableDiscordChickenMessages = true
+
+	discord := routing.NewRouter(config.NotifierRouting{}, routing.DiscordLegacyRules())
+	telegram := routing.NewRouter(config.NotifierRouting{}, routing.DefaultRules())
+	runStarted := event.RunStarted(event.Text("sorc", "Starting run"), "baal")
+	chicken := event.GameFinished(event.Text("sorc", "chicken"), event.FinishedChicken)
+	potion := event.UsedPotion(event.Text("sorc", ""), data.HealingPotion, false)
+
+	if discord.Allow(runStarted) {
+		t.Error("discord shouldn't send run started messages when they are disabled")
+	}
+	if !discord.Allow(chicken) {
+		t.Error("discord should send chicken messages when they are enabled")
+	}
+	if !telegram.Allow(runStarted) || !telegram.Allow(chicken) {
+		t.Error("telegram shouldn't depend on the discord toggles")
+	}
+	if discord.Allow(potion) || telegram.Allow(potion) {
+		t.Error("internal events shouldn't be sent")
+	}
+
+	custom := routing.NewRouter(config.NotifierRouting{Rules: []config.RoutingRule{{Events: []string{"RunStartedEvent"}}}}, routing.DiscordLegacyRules())
+	if !custom.Allow(runStarted) || custom.Allow(chicken) {
+		t.Error("explicit rules should replace the legacy ones")
+	}
+}
@@ -0,0 +1,212 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image/jpeg"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+const (
+	SignatureHeader = "X-Koolo-Signature"
+	EventTypeHeader = "X-Koolo-Event"
+
+	queueSize      = 100
+	requestTimeout = 10 * time.Second
+	initialBackoff = time.Second
+)
+
+var defaultEvents = []string{"GameCreatedEvent", "GameFinishedEvent", "RunStartedEvent", "RunFinishedEvent", "ItemStashedEvent"}
+
+type Envelope struct {
+	Type       string    `json:"type"`
+	Supervisor string    `json:"supervisor"`
+	Timestamp  time.Time `json:"timestamp"`
+	Message    string    `json:"message"`
+	Screenshot string    `json:"screenshot,omitempty"`
+}
+
+type Notifier struct {
+	endpoints         []*endpoint
+	events            []string
+	secret            []byte
+	includeScreenshot bool
+	maxRetries        int
+	client            *http.Client
+	logger            *slog.Logger
+}
+
+// endpoint has its own queue and worker, so a slow or dead URL doesn't delay the deliveries to the other ones
+type endpoint struct {
+	url   string
+	queue chan delivery
+}
+
+type delivery struct {
+	eventType string
+	body      []byte
+}
+
+func NewNotifier(urls, events []string, secret string, includeScreenshot bool, maxRetries int, logger *slog.Logger) (*Notifier, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("at least one webhook url is required")
+	}
+	if maxRetries < 0 {
+		return nil, fmt.Errorf("webhook max retries can not be negative")
+	}
+	if len(events) == 0 {
+		events = defaultEvents
+	}
+
+	endpoints := make([]*endpoint, 0, len(urls))
+	for _, url := range urls {
+		endpoints = append(endpoints, &endpoint{url: url, queue: make(chan delivery, queueSize)})
+	}
+
+	return &Notifier{
+		endpoints:         endpoints,
+		events:            events,
+		secret:            []byte(secret),
+		includeScreenshot: includeScreenshot,
+		maxRetries:        maxRetries,
+		client:            &http.Client{Timeout: requestTimeout},
+		logger:            logger,
+	}, nil
+}
+
+// Handle builds the envelope and enqueues it for every URL, deliveries are done by Start in the background, so slow
+// endpoints or retries don't block the event listener
+func (n *Notifier) Handle(_ context.Context, e event.Event) error {
+	eventType := reflect.TypeOf(e).Name()
+	if !slices.Contains(n.events, eventType) {
+		return nil
+	}
+
+	env := Envelope{
+		Type:       eventType,
+		Supervisor: e.Supervisor(),
+		Timestamp:  e.OccurredAt(),
+		Message:    e.Message(),
+	}
+
+	if n.includeScreenshot && e.Image() != nil {
+		buf := new(bytes.Buffer)
+		if err := jpeg.Encode(buf, e.Image(), &jpeg.Options{Quality: 80}); err != nil {
+			return err
+		}
+		env.Screenshot = base64.StdEncoding.EncodeToString(buf.Bytes())
+	}
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("error encoding webhook payload: %w", err)
+	}
+
+	for _, ep := range n.endpoints {
+		select {
+		case ep.queue <- delivery{eventType: eventType, body: body}:
+		default:
+			n.logger.Warn("Webhook queue is full, event discarded", slog.String("url", ep.url), slog.String("type", eventType))
+		}
+	}
+
+	return nil
+}
+
+// Start runs a delivery worker for every URL until the context is done
+func (n *Notifier) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, ep := range n.endpoints {
+		wg.Add(1)
+		go func(ep *endpoint) {
+			defer wg.Done()
+			n.work(ctx, ep)
+		}(ep)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (n *Notifier) work(ctx context.Context, ep *endpoint) {
+	for {
+		select {
+		case d := <-ep.queue:
+			if err := n.deliver(ctx, ep.url, d.eventType, d.body); err != nil {
+				n.logger.Error("Error delivering webhook", slog.String("url", ep.url), slog.Any("error", err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// deliver POSTs the body retrying with exponential backoff on network errors and 5xx responses, other responses
+// would fail again so they are not retried
+func (n *Notifier) deliver(ctx context.Context, url, eventType string, body []byte) error {
+	var err error
+	backoff := initialBackoff
+	for attempt := 0; attempt <= n.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+				backoff *= 2
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		var retry bool
+		if retry, err = n.post(ctx, url, eventType, body); err == nil || !retry {
+			return err
+		}
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %w", n.maxRetries+1, err)
+}
+
+// post sends the request, it returns if the request can be retried when it fails
+func (n *Notifier) post(ctx context.Context, url, eventType string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, eventType)
+	if len(n.secret) > 0 {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(n.secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode >= 500, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return false, nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the body, receivers should compute the same to verify the payload
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDeliverRetriesOnlyServerErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		attempts int32
+		wantErr  bool
+	}{
+		{name: "ok", status: http.StatusOK, attempts: 1},
+		{name: "client error is not retried", status: http.StatusBadRequest, attempts: 1, wantErr: true},
+		{name: "server error is retried", status: http.StatusBadGateway, attempts: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			n, err := NewNotifier([]string{srv.URL}, nil, "", false, 1, slog.Default())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = n.deliver(context.Background(), srv.URL, "GameCreatedEvent", []byte("{}"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("deliver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := attempts.Load(); got != tt.attempts {
+				t.Errorf("got %d attempts, want %d", got, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestNewNotifierRejectsNegativeRetries(t *testing.T) {
+	if _, err := NewNotifier([]string{"http://localhost"}, nil, "", false, -1, slog.Default()); err == nil {
+		t.Error("expected an error for negative max retries")
+	}
+}
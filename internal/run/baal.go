@@ -14,11 +14,6 @@ import (
 	"github.com/hectorgimenez/koolo/internal/pather"
 )
 
-var baalThronePosition = data.Position{
-	X: 15095,
-	Y: 5042,
-}
-
 type Baal struct {
 	baseRun
 }
@@ -42,7 +37,7 @@ func (s Baal) BuildActions() (actions []action.Action) {
 			s.builder.MoveToArea(area.TheWorldStoneKeepLevel3),
 			s.builder.ClearArea(false, filter),
 			s.builder.MoveToArea(area.ThroneOfDestruction),
-			s.builder.MoveToCoords(baalThronePosition),
+			s.builder.MoveToCoords(pather.BaalThronePosition),
 			// Kill monsters inside Baal throne
 			s.checkForSoulsOrDolls(),
 		)
@@ -68,7 +63,7 @@ func (s Baal) BuildActions() (actions []action.Action) {
 			// Travel to boss position
 			s.builder.MoveToArea(area.TheWorldStoneKeepLevel3),
 			s.builder.MoveToArea(area.ThroneOfDestruction),
-			s.builder.MoveToCoords(baalThronePosition),
+			s.builder.MoveToCoords(pather.BaalThronePosition),
 			// Kill monsters inside Baal throne
 			s.checkForSoulsOrDolls(),
 		)
@@ -90,7 +85,7 @@ func (s Baal) BuildActions() (actions []action.Action) {
 	}
 
 	// Come back to previous position
-	actions = append(actions, s.builder.MoveToCoords(baalThronePosition))
+	actions = append(actions, s.builder.MoveToCoords(pather.BaalThronePosition))
 
 	lastWave := false
 	actions = append(actions, action.NewChain(func(d game.Data) []action.Action {
@@ -101,7 +96,7 @@ func (s Baal) BuildActions() (actions []action.Action) {
 
 			enemies := false
 			for _, e := range d.Monsters.Enemies() {
-				dist := pather.DistanceFromPoint(baalThronePosition, e.Position)
+				dist := pather.DistanceFromPoint(pather.BaalThronePosition, e.Position)
 				if dist < 50 {
 					enemies = true
 				}
@@ -109,7 +104,7 @@ func (s Baal) BuildActions() (actions []action.Action) {
 			if !enemies {
 				return []action.Action{
 					s.builder.ItemPickup(false, 50),
-					s.builder.MoveToCoords(baalThronePosition),
+					s.builder.MoveToCoords(pather.BaalThronePosition),
 				}
 			}
 
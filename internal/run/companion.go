@@ -3,6 +3,7 @@ package run
 import (
 	"context"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
@@ -18,7 +19,11 @@ import (
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/pather"
 	"github.com/hectorgimenez/koolo/internal/town"
-	"github.com/lxn/win"
+)
+
+var (
+	companionSubscriptions   = make(map[string]event.SubscriptionID)
+	companionSubscriptionsMu sync.Mutex
 )
 
 type Companion struct {
@@ -35,8 +40,12 @@ func (s Companion) BuildActions() []action.Action {
 	tpRequested := false
 	var portalUsedToGoCity data.UnitID
 
-	// TODO: Deregister this listener or will leak
-	s.EventListener.Register(func(ctx context.Context, e event.Event) error {
+	// Runs are built again on every game, replace the listener registered on the previous game
+	companionSubscriptionsMu.Lock()
+	if id, found := companionSubscriptions[s.Supervisor]; found {
+		s.EventListener.Unregister(id)
+	}
+	companionSubscriptions[s.Supervisor] = s.EventListener.Register(func(ctx context.Context, e event.Event) error {
 		if strings.EqualFold(config.Characters[e.Supervisor()].CharacterName, s.CharacterCfg.Companion.LeaderName) {
 			if evt, ok := e.(event.CompanionLeaderAttackEvent); ok {
 				leaderUnitIDTarget = evt.TargetUnitID
@@ -49,6 +58,7 @@ func (s Companion) BuildActions() []action.Action {
 
 		return nil
 	})
+	companionSubscriptionsMu.Unlock()
 
 	return []action.Action{
 		action.NewChain(func(d game.Data) []action.Action {
@@ -115,7 +125,7 @@ func (s Companion) BuildActions() []action.Action {
 					case npc.Warriv, npc.Meshif:
 						return []action.Action{
 							s.builder.ReturnTown(),
-							s.builder.InteractNPC(npcID, step.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)),
+							s.builder.InteractNPC(npcID, step.KeySequence(game.VKHome, game.VKDown, game.VKReturn)),
 						}
 					}
 				}
@@ -0,0 +1,138 @@
+package run
+
+import (
+	"log/slog"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/pather"
+)
+
+const (
+	defaultChestsRadius = 20
+	defaultPickupRadius = 20
+)
+
+// Defined is a run declared in config/runs/*.yaml, steps are translated to actions in the same order
+type Defined struct {
+	baseRun
+	def config.RunDefinition
+}
+
+func (r Defined) Name() string {
+	return string(r.def.Name)
+}
+
+func (r Defined) BuildActions() []action.Action {
+	actions := make([]action.Action, 0, len(r.def.Steps))
+	for _, s := range r.def.Steps {
+		actions = append(actions, r.stepActions(s)...)
+	}
+
+	return actions
+}
+
+func (r Defined) stepActions(s config.RunStep) []action.Action {
+	switch {
+	case s.Waypoint != nil:
+		return []action.Action{r.builder.WayPoint(s.Waypoint.ID())}
+	case s.MoveToArea != nil:
+		return []action.Action{r.builder.MoveToArea(s.MoveToArea.ID())}
+	case s.TravelTo != nil:
+		return []action.Action{r.builder.TravelTo(s.TravelTo.ID())}
+	case s.MoveToObject != nil:
+		return []action.Action{r.builder.MoveTo(func(d game.Data) (data.Position, bool) {
+			o, found := d.Objects.FindOne(s.MoveToObject.Name())
+
+			return o.Position, found
+		})}
+	case s.MoveToNPC != nil:
+		return []action.Action{r.builder.MoveTo(func(d game.Data) (data.Position, bool) {
+			n, found := d.NPCs.FindOne(s.MoveToNPC.ID())
+			if !found || len(n.Positions) == 0 {
+				return data.Position{}, false
+			}
+
+			return n.Positions[0], true
+		})}
+	case s.MoveToCoords != nil:
+		return []action.Action{r.builder.MoveToCoords(*s.MoveToCoords)}
+	case s.ClearArea != nil:
+		filter := data.MonsterAnyFilter()
+		if s.ClearArea.OnlyElites {
+			filter = data.MonsterEliteFilter()
+		}
+		if s.ClearArea.Radius > 0 {
+			return []action.Action{r.builder.ClearAreaAroundPlayer(s.ClearArea.Radius, filter)}
+		}
+
+		return []action.Action{r.builder.ClearArea(s.ClearArea.OpenChests, filter)}
+	case s.KillUnique != nil:
+		return []action.Action{r.killUnique(*s.KillUnique)}
+	case s.OpenChests != nil:
+		return []action.Action{r.openChests(s.OpenChests.Radius)}
+	case s.PickupItems != nil:
+		radius := s.PickupItems.Radius
+		if radius == 0 {
+			radius = defaultPickupRadius
+		}
+
+		return []action.Action{r.builder.ItemPickup(true, radius)}
+	}
+
+	r.logger.Warn("Run definition step without primitive, skipping", slog.String("run", r.Name()))
+
+	return nil
+}
+
+func (r Defined) killUnique(s config.KillUniqueStep) action.Action {
+	monsterType := s.Type
+	if monsterType == "" {
+		monsterType = data.MonsterTypeSuperUnique
+	}
+
+	return r.char.KillMonsterSequence(func(d game.Data) (data.UnitID, bool) {
+		m, found := d.Monsters.FindOne(s.NPC.ID(), monsterType)
+		if !found || m.Stats[stat.Life] <= 0 {
+			return 0, false
+		}
+
+		return m.UnitID, true
+	}, s.SkipOnImmunities)
+}
+
+func (r Defined) openChests(radius int) action.Action {
+	if radius == 0 {
+		radius = defaultChestsRadius
+	}
+
+	return action.NewChain(func(d game.Data) []action.Action {
+		actions := make([]action.Action, 0)
+		for _, o := range d.Objects {
+			if !o.IsChest() || !o.Selectable || pather.DistanceFromMe(d, o.Position) > radius {
+				continue
+			}
+
+			chest := o
+			actions = append(actions,
+				r.builder.MoveToCoords(chest.Position, step.StopAtDistance(2)),
+				r.builder.InteractObjectByID(chest.ID, func(d game.Data) bool {
+					for _, obj := range d.Objects {
+						if obj.ID == chest.ID && !obj.Selectable {
+							return true
+						}
+					}
+
+					return false
+				}),
+				r.builder.ItemPickup(false, 15),
+			)
+		}
+
+		return actions
+	})
+}
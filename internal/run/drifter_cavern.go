@@ -25,8 +25,7 @@ func (a DrifterCavern) BuildActions() (actions []action.Action) {
 	}
 
 	actions = []action.Action{
-		a.builder.WayPoint(area.GlacialTrail),
-		a.builder.MoveToArea(area.DrifterCavern),
+		a.builder.TravelTo(area.DrifterCavern),
 	}
 
 	/*actions = append(actions,
@@ -0,0 +1,8 @@
+package run
+
+import "time"
+
+// SetClock replaces the time used by the scheduler to check the windows and the history
+func (s *Scheduler) SetClock(now func() time.Time) {
+	s.now = now
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/helper"
 	"github.com/hectorgimenez/koolo/internal/ui"
-	"github.com/lxn/win"
 )
 
 const scrollOfInifuss = "ScrollOfInifuss"
@@ -92,7 +91,7 @@ func (a Leveling) denOfEvil() []action.Action {
 		a.builder.ReturnTown(),
 		a.builder.InteractNPC(
 			npc.Akara,
-			step.KeySequence(win.VK_ESCAPE),
+			step.KeySequence(game.VKEscape),
 		),
 	}
 }
@@ -139,7 +138,7 @@ func (a Leveling) deckardCain() []action.Action {
 		a.builder.ReturnTown(),
 		a.builder.InteractNPC(
 			npc.Akara,
-			step.KeySequence(win.VK_ESCAPE),
+			step.KeySequence(game.VKEscape),
 		),
 	)
 	// Reuse Tristram Run actions
@@ -209,7 +208,7 @@ func (a Leveling) andariel(d game.Data) []action.Action {
 						x++
 					}
 
-					a.HID.PressKey(win.VK_ESCAPE)
+					a.HID.PressKey(game.VKEscape)
 					return nil
 				}),
 			}
@@ -224,7 +223,7 @@ func (a Leveling) andariel(d game.Data) []action.Action {
 		}),
 		a.char.KillAndariel(),
 		a.builder.ReturnTown(),
-		a.builder.InteractNPC(npc.Warriv, step.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)),
+		a.builder.InteractNPC(npc.Warriv, step.KeySequence(game.VKHome, game.VKDown, game.VKReturn)),
 	)
 
 	return actions
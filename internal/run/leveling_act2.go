@@ -14,7 +14,6 @@ import (
 	"github.com/hectorgimenez/koolo/internal/helper"
 	"github.com/hectorgimenez/koolo/internal/pather"
 	"github.com/hectorgimenez/koolo/internal/ui"
-	"github.com/lxn/win"
 )
 
 func (a Leveling) act2() action.Action {
@@ -156,7 +155,7 @@ func (a Leveling) summoner() []action.Action {
 		}),
 		a.builder.DiscoverWaypoint(),
 		a.builder.ReturnTown(),
-		a.builder.InteractNPC(npc.Atma, step.KeySequence(win.VK_ESCAPE)),
+		a.builder.InteractNPC(npc.Atma, step.KeySequence(game.VKEscape)),
 	)
 
 	return actions
@@ -179,7 +178,7 @@ func (a Leveling) prepareStaff() action.Action {
 
 							a.HID.ClickWithModifier(game.LeftButton, screenPos.X, screenPos.Y, game.CtrlKey)
 							helper.Sleep(300)
-							a.HID.PressKey(win.VK_ESCAPE)
+							a.HID.PressKey(game.VKEscape)
 							return nil
 						}),
 					),
@@ -310,7 +309,7 @@ func (a Leveling) duriel(staffAlreadyUsed bool, d game.Data) (actions []action.A
 						x++
 					}
 
-					a.HID.PressKey(win.VK_ESCAPE)
+					a.HID.PressKey(game.VKEscape)
 					return nil
 				}),
 			}
@@ -348,11 +347,11 @@ func (a Leveling) duriel(staffAlreadyUsed bool, d game.Data) (actions []action.A
 			X: 5092,
 			Y: 5144,
 		}),
-		a.builder.InteractNPC(npc.Jerhyn, step.KeySequence(win.VK_ESCAPE)),
+		a.builder.InteractNPC(npc.Jerhyn, step.KeySequence(game.VKEscape)),
 		a.builder.MoveToCoords(data.Position{
 			X: 5195,
 			Y: 5060,
 		}),
-		a.builder.InteractNPC(npc.Meshif, step.KeySequence(win.VK_HOME, win.VK_DOWN, win.VK_RETURN)),
+		a.builder.InteractNPC(npc.Meshif, step.KeySequence(game.VKHome, game.VKDown, game.VKReturn)),
 	)
 }
@@ -14,7 +14,6 @@ import (
 	"github.com/hectorgimenez/koolo/internal/action/step"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/helper"
-	"github.com/lxn/win"
 )
 
 func (a Leveling) act5() action.Action {
@@ -86,13 +85,13 @@ func (a Leveling) anya() []action.Action {
 		a.builder.Wait(time.Second * 8),
 		a.builder.InteractNPC(npc.Malah,
 			step.SyncStep(func(d game.Data) error {
-				a.HID.PressKey(win.VK_ESCAPE)
+				a.HID.PressKey(game.VKEscape)
 				a.HID.PressKeyBinding(d.KeyBindings.Inventory)
 				itm, _ := d.Inventory.Find("ScrollOfResistance")
 				screenPos := a.UIManager.GetScreenCoordsForItem(itm)
 				helper.Sleep(200)
 				a.HID.Click(game.RightButton, screenPos.X, screenPos.Y)
-				a.HID.PressKey(win.VK_ESCAPE)
+				a.HID.PressKey(game.VKEscape)
 
 				return nil
 			}),
@@ -14,7 +14,6 @@ import (
 	"github.com/hectorgimenez/koolo/internal/config"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/helper"
-	"github.com/lxn/win"
 )
 
 type Quests struct {
@@ -113,7 +112,7 @@ func (a Quests) openQuestLog() action.Action {
 			}),
 			step.Wait(time.Second * 1),
 			step.SyncStep(func(g game.Data) error {
-				a.HID.PressKey(win.VK_ESCAPE)
+				a.HID.PressKey(game.VKEscape)
 				return nil
 			}),
 		}
@@ -131,7 +130,7 @@ func (a Quests) clearDenQuest() action.Action {
 			a.builder.ReturnTown(),
 			a.builder.InteractNPC(
 				npc.Akara,
-				step.KeySequence(win.VK_ESCAPE),
+				step.KeySequence(game.VKEscape),
 			),
 		}
 	})
@@ -161,7 +160,7 @@ func (a Quests) rescueCainQuest() action.Action {
 			a.builder.ReturnTown(),
 			a.builder.InteractNPC(
 				npc.Akara,
-				step.KeySequence(win.VK_ESCAPE),
+				step.KeySequence(game.VKEscape),
 			),
 		)
 		// Reuse Tristram Run actions
@@ -192,7 +191,7 @@ func (a Quests) retrieveHammerQuest() action.Action {
 			a.builder.ReturnTown(),
 			a.builder.InteractNPC(
 				npc.Charsi,
-				step.KeySequence(win.VK_ESCAPE),
+				step.KeySequence(game.VKEscape),
 			),
 		}
 	})
@@ -236,13 +235,13 @@ func (a Quests) killRadamentQuest() action.Action {
 			a.builder.MoveToCoords(startingPositionAtma),
 			a.builder.InteractNPC(npc.Atma,
 				step.SyncStep(func(d game.Data) error {
-					a.HID.PressKey(win.VK_ESCAPE)
+					a.HID.PressKey(game.VKEscape)
 					a.HID.PressKeyBinding(d.KeyBindings.Inventory)
 					itm, _ := d.Inventory.Find("BookofSkill")
 					screenPos := a.UIManager.GetScreenCoordsForItem(itm)
 					helper.Sleep(200)
 					a.HID.Click(game.RightButton, screenPos.X, screenPos.Y)
-					a.HID.PressKey(win.VK_ESCAPE)
+					a.HID.PressKey(game.VKEscape)
 
 					return nil
 				}),
@@ -296,7 +295,7 @@ func (a Quests) retrieveBookQuest() action.Action {
 			a.builder.ReturnTown(),
 			a.builder.InteractNPC(
 				npc.Alkor,
-				step.KeySequence(win.VK_ESCAPE),
+				step.KeySequence(game.VKEscape),
 			),
 		}
 	})
@@ -321,7 +320,7 @@ func (a Quests) killIzualQuest() action.Action {
 			a.builder.ReturnTown(),
 			a.builder.InteractNPC(
 				npc.Tyrael2,
-				step.KeySequence(win.VK_ESCAPE),
+				step.KeySequence(game.VKEscape),
 			),
 		}
 	})
@@ -343,7 +342,7 @@ func (a Quests) killShenkQuest() action.Action {
 			a.builder.ReturnTown(),
 			a.builder.InteractNPC(
 				npc.Larzuk,
-				step.KeySequence(win.VK_ESCAPE),
+				step.KeySequence(game.VKEscape),
 			),
 		}
 	})
@@ -373,13 +372,13 @@ func (a Quests) rescueAnyaQuest() action.Action {
 			a.builder.Wait(time.Second * 4),
 			a.builder.InteractNPC(npc.Malah,
 				step.SyncStep(func(d game.Data) error {
-					a.HID.PressKey(win.VK_ESCAPE)
+					a.HID.PressKey(game.VKEscape)
 					a.HID.PressKeyBinding(d.KeyBindings.Inventory)
 					itm, _ := d.Inventory.Find("ScrollOfResistance")
 					screenPos := a.UIManager.GetScreenCoordsForItem(itm)
 					helper.Sleep(200)
 					a.HID.Click(game.RightButton, screenPos.X, screenPos.Y)
-					a.HID.PressKey(win.VK_ESCAPE)
+					a.HID.PressKey(game.VKEscape)
 
 					return nil
 				}),
@@ -409,7 +408,7 @@ func (a Quests) killAncientsQuest() action.Action {
 						helper.Sleep(1000)
 						a.HID.Click(game.LeftButton, 720, 260)
 						helper.Sleep(1000)
-						a.HID.PressKey(win.VK_RETURN)
+						a.HID.PressKey(game.VKReturn)
 						helper.Sleep(2000)
 						return nil
 					}),
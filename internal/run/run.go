@@ -131,6 +131,10 @@ func (f *Factory) BuildRuns() (runs []Run) {
 			runs = append(runs, DrifterCavern{baseRun})
 		case config.EnduguRun:
 			runs = append(runs, Endugu{baseRun})
+		default:
+			if def, found := config.RunDefinitions[run]; found {
+				runs = append(runs, Defined{baseRun: baseRun, def: def})
+			}
 		}
 	}
 
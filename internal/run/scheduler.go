@@ -0,0 +1,203 @@
+package run
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/stats"
+)
+
+const (
+	defaultFailureCooldown = 30 * time.Minute
+	// Only the latest history is used to schedule the runs, older records don't change the schedule
+	schedulerHistory = 24 * time.Hour
+)
+
+// Scheduler decides which of the configured runs are executed every game, and in which order, based on the run
+// schedules and the stats history of previous games.
+type Scheduler struct {
+	logger  *slog.Logger
+	history *stats.Store
+	now     func() time.Time
+}
+
+// NewScheduler creates a scheduler, history can be nil if it could not be opened, restrictions based on previous
+// runs are not applied in that case.
+func NewScheduler(logger *slog.Logger, history *stats.Store) *Scheduler {
+	return &Scheduler{
+		logger:  logger,
+		history: history,
+		now:     time.Now,
+	}
+}
+
+// Schedule filters the runs not allowed right now and sorts the rest, it must be called before the game is created
+// so the current game is not counted as a previous one.
+func (s *Scheduler) Schedule(cfg *config.CharacterCfg, runs []Run) []Run {
+	now := s.now()
+	h := s.runHistory(now)
+
+	scheduled := make([]Run, 0, len(runs))
+	for _, r := range runs {
+		schedule, found := cfg.Game.Scheduler.Runs[config.Run(r.Name())]
+		if !found {
+			scheduled = append(scheduled, r)
+			continue
+		}
+
+		if reason, skip := s.skipReason(schedule, h, r.Name(), now); skip {
+			s.logger.Info(fmt.Sprintf("Scheduler: skipping %s, %s", r.Name(), reason))
+			continue
+		}
+		scheduled = append(scheduled, r)
+	}
+
+	runsPerGame := cfg.Game.Scheduler.RunsPerGame
+	if !cfg.Game.RandomizeRuns && (runsPerGame == 0 || runsPerGame >= len(scheduled)) {
+		return scheduled
+	}
+
+	order := weightedOrder(scheduled, cfg.Game.Scheduler.Runs)
+	if runsPerGame > 0 && runsPerGame < len(order) {
+		for _, i := range order[runsPerGame:] {
+			s.logger.Info(fmt.Sprintf("Scheduler: skipping %s, only %d runs per game are allowed and it was not picked", scheduled[i].Name(), runsPerGame))
+		}
+		order = order[:runsPerGame]
+
+		// Keep the configured order if runs are not randomized, weights are only used to pick them
+		if !cfg.Game.RandomizeRuns {
+			slices.Sort(order)
+		}
+	}
+
+	picked := make([]Run, 0, len(order))
+	for _, i := range order {
+		picked = append(picked, scheduled[i])
+	}
+
+	return picked
+}
+
+func (s *Scheduler) skipReason(schedule config.RunSchedule, h runHistory, name string, now time.Time) (string, bool) {
+	if len(schedule.Windows) > 0 && !config.InsideTimeWindows(schedule.Windows, now) {
+		return fmt.Sprintf("current time %s is outside of the allowed windows %v", now.Format("15:04"), schedule.Windows), true
+	}
+
+	if schedule.EveryGames > 1 {
+		if games, found := h.gamesSince(name); found && games+1 < schedule.EveryGames {
+			return fmt.Sprintf("it was executed %d games ago and it runs every %d games", games+1, schedule.EveryGames), true
+		}
+	}
+
+	if schedule.MaxPerHour > 0 {
+		if executions := h.startedSince(name, now.Add(-time.Hour)); executions >= schedule.MaxPerHour {
+			return fmt.Sprintf("it was executed %d times during the last hour, max allowed is %d", executions, schedule.MaxPerHour), true
+		}
+	}
+
+	if schedule.MaxFailures > 0 {
+		cooldown := schedule.FailureCooldown
+		if cooldown == 0 {
+			cooldown = defaultFailureCooldown
+		}
+
+		failures, lastFailure := h.consecutiveFailures(name)
+		if failures >= schedule.MaxFailures && now.Sub(lastFailure) < cooldown {
+			return fmt.Sprintf("it failed %d consecutive times, cooldown until %s", failures, lastFailure.Add(cooldown).Format("15:04:05")), true
+		}
+	}
+
+	return "", false
+}
+
+func (s *Scheduler) runHistory(now time.Time) runHistory {
+	if s.history == nil {
+		return runHistory{}
+	}
+
+	records := s.history.Records(now.Add(-schedulerHistory), time.Time{})
+
+	return runHistory{records: records, results: stats.RunResults(records)}
+}
+
+type runHistory struct {
+	records []stats.Record
+	results []stats.RunResult
+}
+
+// gamesSince returns the number of games created after the last game the run was executed in, false if the run
+// was never executed
+func (h runHistory) gamesSince(name string) (int, bool) {
+	games := 0
+	for i := len(h.records) - 1; i >= 0; i-- {
+		switch r := h.records[i]; {
+		case r.Type == stats.RecordRunStarted && r.RunName == name:
+			return games, true
+		case r.Type == stats.RecordGameCreated:
+			games++
+		}
+	}
+
+	return 0, false
+}
+
+func (h runHistory) startedSince(name string, since time.Time) int {
+	started := 0
+	for i := len(h.records) - 1; i >= 0 && !h.records[i].OccurredAt.Before(since); i-- {
+		if h.records[i].Type == stats.RecordRunStarted && h.records[i].RunName == name {
+			started++
+		}
+	}
+
+	return started
+}
+
+// consecutiveFailures returns the number of failures since the last successful execution and when the last one
+// happened
+func (h runHistory) consecutiveFailures(name string) (int, time.Time) {
+	failures := 0
+	lastFailure := time.Time{}
+	for i := len(h.results) - 1; i >= 0; i-- {
+		r := h.results[i]
+		if r.Name != name {
+			continue
+		}
+		if r.Reason == event.FinishedOK {
+			break
+		}
+		if failures == 0 {
+			lastFailure = r.FinishedAt
+		}
+		failures++
+	}
+
+	return failures, lastFailure
+}
+
+// weightedOrder returns the indexes of the runs shuffled, runs with higher weight are more likely to be first
+// (weighted sampling without replacement, every run gets a random key u^(1/weight) and they are sorted by it)
+func weightedOrder(runs []Run, schedules map[config.Run]config.RunSchedule) []int {
+	keys := make([]float64, len(runs))
+	order := make([]int, len(runs))
+	for i, r := range runs {
+		weight := 1.0
+		if schedule, found := schedules[config.Run(r.Name())]; found && schedule.Weight > 0 {
+			weight = schedule.Weight
+		}
+		keys[i] = math.Pow(rand.Float64(), 1/weight)
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return keys[order[i]] > keys[order[j]]
+	})
+
+	return order
+}
@@ -0,0 +1,282 @@
+package run_test
+
+import (
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/run"
+	"github.com/hectorgimenez/koolo/internal/stats"
+)
+
+type namedRun string
+
+func (r namedRun) Name() string {
+	return string(r)
+}
+
+func (r namedRun) BuildActions() []action.Action {
+	return nil
+}
+
+var configuredRuns = []run.Run{namedRun("countess"), namedRun("andariel"), namedRun("mephisto")}
+
+func names(runs []run.Run) []string {
+	n := make([]string, 0, len(runs))
+	for _, r := range runs {
+		n = append(n, r.Name())
+	}
+
+	return n
+}
+
+// newScheduler returns a scheduler at the given time, with a history made of the records
+func newScheduler(t *testing.T, now time.Time, records ...stats.Record) *run.Scheduler {
+	t.Helper()
+
+	history, err := stats.Open(t.TempDir(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { history.Close() })
+	for _, r := range records {
+		if err = history.Append(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := run.NewScheduler(slog.New(slog.NewTextHandler(io.Discard, nil)), history)
+	s.SetClock(func() time.Time { return now })
+
+	return s
+}
+
+func scheduleFor(name string, schedule config.RunSchedule) *config.CharacterCfg {
+	cfg := &config.CharacterCfg{}
+	cfg.Game.Scheduler.Runs = map[config.Run]config.RunSchedule{config.Run(name): schedule}
+
+	return cfg
+}
+
+func gameCreated(at time.Time) stats.Record {
+	return stats.Record{Type: stats.RecordGameCreated, OccurredAt: at}
+}
+
+func runStarted(name string, at time.Time) stats.Record {
+	return stats.Record{Type: stats.RecordRunStarted, RunName: name, OccurredAt: at}
+}
+
+func runFinished(name string, reason event.FinishReason, at time.Time) stats.Record {
+	return stats.Record{Type: stats.RecordRunFinished, RunName: name, Reason: reason, OccurredAt: at}
+}
+
+func TestScheduleKeepsRunsWithoutSchedule(t *testing.T) {
+	s := newScheduler(t, time.Now())
+
+	got := names(s.Schedule(&config.CharacterCfg{}, configuredRuns))
+	if !slices.Equal(got, []string{"countess", "andariel", "mephisto"}) {
+		t.Errorf("expected all the runs in the configured order, got %v", got)
+	}
+
+	// Without history every restriction based on previous runs is ignored
+	cfg := scheduleFor("andariel", config.RunSchedule{EveryGames: 3, MaxPerHour: 1, MaxFailures: 1})
+	got = names(run.NewScheduler(slog.New(slog.NewTextHandler(io.Discard, nil)), nil).Schedule(cfg, configuredRuns))
+	if len(got) != 3 {
+		t.Errorf("expected all the runs without history, got %v", got)
+	}
+}
+
+func TestScheduleWindows(t *testing.T) {
+	night, err := config.ParseTimeWindow("22:00-06:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := scheduleFor("andariel", config.RunSchedule{Windows: []config.TimeWindow{night}})
+	today := time.Now()
+
+	tests := []struct {
+		hour int
+		want []string
+	}{
+		{hour: 23, want: []string{"countess", "andariel", "mephisto"}},
+		// After midnight, still inside the window started the day before
+		{hour: 1, want: []string{"countess", "andariel", "mephisto"}},
+		{hour: 6, want: []string{"countess", "mephisto"}},
+		{hour: 12, want: []string{"countess", "mephisto"}},
+	}
+
+	for _, tc := range tests {
+		at := time.Date(today.Year(), today.Month(), today.Day(), tc.hour, 0, 0, 0, time.Local)
+		got := names(newScheduler(t, at).Schedule(cfg, configuredRuns))
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("at %02d:00: expected %v, got %v", tc.hour, tc.want, got)
+		}
+	}
+}
+
+func TestScheduleEveryGames(t *testing.T) {
+	now := time.Now()
+	cfg := scheduleFor("mephisto", config.RunSchedule{EveryGames: 3})
+
+	tests := []struct {
+		name    string
+		history []stats.Record
+		want    bool
+	}{
+		{
+			name: "never executed",
+			want: true,
+		},
+		{
+			name:    "executed in the previous game",
+			history: []stats.Record{gameCreated(now.Add(-2 * time.Minute)), runStarted("mephisto", now.Add(-time.Minute))},
+			want:    false,
+		},
+		{
+			name: "executed two games ago",
+			history: []stats.Record{
+				gameCreated(now.Add(-3 * time.Minute)), runStarted("mephisto", now.Add(-3*time.Minute)),
+				gameCreated(now.Add(-2 * time.Minute)),
+			},
+			want: false,
+		},
+		{
+			name: "executed three games ago",
+			history: []stats.Record{
+				gameCreated(now.Add(-3 * time.Minute)), runStarted("mephisto", now.Add(-3*time.Minute)),
+				gameCreated(now.Add(-2 * time.Minute)),
+				gameCreated(now.Add(-time.Minute)),
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range tests {
+		got := names(newScheduler(t, now, tc.history...).Schedule(cfg, configuredRuns))
+		if slices.Contains(got, "mephisto") != tc.want {
+			t.Errorf("%s: expected mephisto scheduled %t, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestScheduleMaxPerHour(t *testing.T) {
+	now := time.Now()
+	cfg := scheduleFor("countess", config.RunSchedule{MaxPerHour: 2})
+
+	recent := newScheduler(t, now, runStarted("countess", now.Add(-50*time.Minute)), runStarted("countess", now.Add(-10*time.Minute)))
+	if got := names(recent.Schedule(cfg, configuredRuns)); slices.Contains(got, "countess") {
+		t.Errorf("countess was executed twice during the last hour, got %v", got)
+	}
+
+	older := newScheduler(t, now, runStarted("countess", now.Add(-70*time.Minute)), runStarted("countess", now.Add(-10*time.Minute)))
+	if got := names(older.Schedule(cfg, configuredRuns)); !slices.Contains(got, "countess") {
+		t.Errorf("only one execution during the last hour, countess should be scheduled, got %v", got)
+	}
+}
+
+func TestScheduleFailureCooldown(t *testing.T) {
+	now := time.Now()
+	cfg := scheduleFor("andariel", config.RunSchedule{MaxFailures: 2, FailureCooldown: 10 * time.Minute})
+	failedAt := func(minutesAgo int, reason event.FinishReason) []stats.Record {
+		at := now.Add(-time.Duration(minutesAgo) * time.Minute)
+		return []stats.Record{gameCreated(at.Add(-time.Minute)), runStarted("andariel", at.Add(-time.Minute)), runFinished("andariel", reason, at)}
+	}
+
+	tests := []struct {
+		name    string
+		history [][]stats.Record
+		want    bool
+	}{
+		{
+			name:    "single failure",
+			history: [][]stats.Record{failedAt(5, event.FinishedChicken)},
+			want:    true,
+		},
+		{
+			name:    "consecutive failures during the cooldown",
+			history: [][]stats.Record{failedAt(8, event.FinishedDied), failedAt(5, event.FinishedChicken)},
+			want:    false,
+		},
+		{
+			name:    "consecutive failures after the cooldown",
+			history: [][]stats.Record{failedAt(20, event.FinishedDied), failedAt(15, event.FinishedChicken)},
+			want:    true,
+		},
+		{
+			name:    "successful execution between the failures",
+			history: [][]stats.Record{failedAt(8, event.FinishedDied), failedAt(6, event.FinishedOK), failedAt(5, event.FinishedChicken)},
+			want:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		got := names(newScheduler(t, now, slices.Concat(tc.history...)...).Schedule(cfg, configuredRuns))
+		if slices.Contains(got, "andariel") != tc.want {
+			t.Errorf("%s: expected andariel scheduled %t, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestScheduleWeightedOrder(t *testing.T) {
+	cfg := &config.CharacterCfg{}
+	cfg.Game.RandomizeRuns = true
+	cfg.Game.Scheduler.Runs = map[config.Run]config.RunSchedule{
+		"mephisto": {Weight: 1000},
+		"countess": {Weight: 0.001},
+	}
+	s := newScheduler(t, time.Now())
+
+	mephistoFirst, countessLast := 0, 0
+	for i := 0; i < 100; i++ {
+		got := names(s.Schedule(cfg, configuredRuns))
+		if len(got) != 3 {
+			t.Fatalf("expected the 3 runs, got %v", got)
+		}
+		if got[0] == "mephisto" {
+			mephistoFirst++
+		}
+		if got[2] == "countess" {
+			countessLast++
+		}
+	}
+
+	if mephistoFirst < 95 || countessLast < 95 {
+		t.Errorf("weights are not applied, mephisto first %d times and countess last %d times out of 100", mephistoFirst, countessLast)
+	}
+}
+
+func TestScheduleRunsPerGame(t *testing.T) {
+	cfg := &config.CharacterCfg{}
+	cfg.Game.Scheduler.RunsPerGame = 2
+	cfg.Game.Scheduler.Runs = map[config.Run]config.RunSchedule{
+		"mephisto": {Weight: 1000},
+		"andariel": {Weight: 1000},
+		"countess": {Weight: 0.001},
+	}
+	s := newScheduler(t, time.Now())
+
+	for i := 0; i < 20; i++ {
+		// Runs are picked by weight but the configured order is kept when they are not randomized
+		if got := names(s.Schedule(cfg, configuredRuns)); !slices.Equal(got, []string{"andariel", "mephisto"}) {
+			t.Fatalf("expected andariel and mephisto in the configured order, got %v", got)
+		}
+	}
+
+	cfg.Game.RandomizeRuns = true
+	cfg.Game.Scheduler.RunsPerGame = 1
+	if got := names(s.Schedule(cfg, configuredRuns)); len(got) != 1 || got[0] == "countess" {
+		t.Errorf("expected a single run picked by weight, got %v", got)
+	}
+
+	// Fewer eligible runs than allowed, all of them are executed
+	cfg.Game.RandomizeRuns = false
+	cfg.Game.Scheduler.RunsPerGame = 5
+	if got := names(s.Schedule(cfg, configuredRuns)); !slices.Equal(got, []string{"countess", "andariel", "mephisto"}) {
+		t.Errorf("expected all the runs in the configured order, got %v", got)
+	}
+}
@@ -13,17 +13,21 @@ import (
 	"net/http"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/websocket"
-	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/d2go/pkg/data/area"
 	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
 	"github.com/hectorgimenez/d2go/pkg/data/stat"
 	koolo "github.com/hectorgimenez/koolo/internal"
 	"github.com/hectorgimenez/koolo/internal/config"
 	"github.com/hectorgimenez/koolo/internal/helper"
+	"github.com/hectorgimenez/koolo/internal/ledger"
+	"github.com/hectorgimenez/koolo/internal/metrics"
 	"github.com/hectorgimenez/koolo/internal/overseer"
+	"github.com/hectorgimenez/koolo/internal/pickit"
+	"github.com/hectorgimenez/koolo/internal/stats"
 )
 
 type HttpServer struct {
@@ -34,6 +38,7 @@ type HttpServer struct {
 	wsServer   *WebSocketServer
 	wsServerOs *WebSocketServer
 	wsGameData *WebSocketServer
+	ledger     *ledger.Ledger
 }
 
 var (
@@ -210,7 +215,7 @@ func (s *HttpServer) BroadcastStatus() {
 	}
 }
 
-func New(logger *slog.Logger, manager *koolo.SupervisorManager) (*HttpServer, error) {
+func New(logger *slog.Logger, manager *koolo.SupervisorManager, drops *ledger.Ledger) (*HttpServer, error) {
 	var templates *template.Template
 	helperFuncs := template.FuncMap{
 		"isInSlice": func(slice []stat.Resist, value string) bool {
@@ -232,6 +237,9 @@ func New(logger *slog.Logger, manager *koolo.SupervisorManager) (*HttpServer, er
 		"qualityClass": qualityClass,
 		"statIDToText": statIDToText,
 		"contains":     containss,
+		"percent":      percent,
+		"join":         strings.Join,
+		"duration":     formatDuration,
 	}
 	templates, err := template.New("").Funcs(helperFuncs).ParseFS(templatesFS, "templates/*.gohtml")
 	if err != nil {
@@ -242,6 +250,7 @@ func New(logger *slog.Logger, manager *koolo.SupervisorManager) (*HttpServer, er
 		logger:    logger,
 		manager:   manager,
 		templates: templates,
+		ledger:    drops,
 	}, nil
 }
 
@@ -261,15 +270,36 @@ func qualityClass(quality string) string {
 		return "rare-quality"
 	case "Unique":
 		return "unique-quality"
+	case "Crafted":
+		return "crafted-quality"
 	default:
 		return "unknown-quality"
 	}
 }
 
+func percent(ratio float64) string {
+	return fmt.Sprintf("%.1f%%", ratio*100)
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
+
 func statIDToText(id stat.ID) string {
 	return stat.StringStats[id]
 }
 
+func splitAndTrim(value, sep string) []string {
+	values := make([]string, 0)
+	for _, v := range strings.Split(value, sep) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
 func containss(slice []string, item string) bool {
 	for _, v := range slice {
 		if v == item {
@@ -312,9 +342,10 @@ func (s *HttpServer) getStatusData() IndexData {
 	}
 
 	return IndexData{
-		Version:   config.Version,
-		Status:    status,
-		DropCount: drops,
+		Version:      config.Version,
+		Status:       status,
+		DropCount:    drops,
+		PickitErrors: pickitErrors(),
 	}
 }
 
@@ -343,6 +374,11 @@ func (s *HttpServer) Listen(port int) error {
 	http.HandleFunc("/debug", s.debugHandler)
 	http.HandleFunc("/debug-data", s.debugData)
 	http.HandleFunc("/drops", s.drops)
+	http.HandleFunc("/drops/export", s.exportDrops)
+	http.HandleFunc("/drops/screenshot", s.dropScreenshot)
+	http.HandleFunc("/pickit", s.pickitTest)
+	http.HandleFunc("/stats", s.stats)
+	http.HandleFunc("/metrics", s.metrics)
 	http.HandleFunc("/ws", s.wsServer.HandleWebSocket) // Web socket
 	http.HandleFunc("/initial-data", s.initialData)    // Web socket data
 
@@ -488,12 +524,25 @@ func (s *HttpServer) index(w http.ResponseWriter) {
 	}
 
 	s.templates.ExecuteTemplate(w, "index.gohtml", IndexData{
-		Version:   config.Version,
-		Status:    status,
-		DropCount: drops,
+		Version:      config.Version,
+		Status:       status,
+		DropCount:    drops,
+		PickitErrors: pickitErrors(),
 	})
 }
 
+// pickitErrors returns the number of broken pickit rules or quotas of the characters that have any
+func pickitErrors() map[string]int {
+	errs := make(map[string]int)
+	for name, cfg := range config.Characters {
+		if n := cfg.Runtime.PickitProblems.Errors(); n > 0 {
+			errs[name] = n
+		}
+	}
+
+	return errs
+}
+
 func (s *HttpServer) drops(w http.ResponseWriter, r *http.Request) {
 	sup := r.URL.Query().Get("supervisor")
 	cfg, found := config.Characters[sup]
@@ -502,21 +551,210 @@ func (s *HttpServer) drops(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var Drops []data.Drop
-
-	if s.manager.GetSupervisorStats(sup).Drops == nil {
-		Drops = make([]data.Drop, 0)
-	} else {
-		Drops = s.manager.GetSupervisorStats(sup).Drops
-	}
+	filter := dropFilter(r)
+	drops := s.ledger.Entries(filter)
 
 	s.templates.ExecuteTemplate(w, "drops.gohtml", DropData{
-		NumberOfDrops: len(Drops),
+		NumberOfDrops: len(drops),
+		Supervisor:    sup,
 		Character:     cfg.CharacterName,
-		Drops:         Drops,
+		Filter:        filter,
+		Hours:         r.URL.Query().Get("hours"),
+		Drops:         drops,
 	})
 }
 
+// exportDrops returns the drop ledger as CSV or JSON, same filters as the drops page but supervisor is optional
+func (s *HttpServer) exportDrops(w http.ResponseWriter, r *http.Request) {
+	drops := s.ledger.Entries(dropFilter(r))
+
+	switch r.URL.Query().Get("format") {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="drops.csv"`)
+		if err := ledger.WriteCSV(w, drops); err != nil {
+			s.logger.Error("Error exporting drops", slog.Any("error", err))
+		}
+	case "json", "":
+		if config.Koolo.Overseer.Enabled {
+			enableCors(&w)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := ledger.WriteJSON(w, drops); err != nil {
+			s.logger.Error("Error exporting drops", slog.Any("error", err))
+		}
+	default:
+		http.Error(w, "format must be csv or json", http.StatusBadRequest)
+	}
+}
+
+func (s *HttpServer) dropScreenshot(w http.ResponseWriter, r *http.Request) {
+	path, valid := s.ledger.ScreenshotPath(r.URL.Query().Get("name"))
+	if !valid {
+		http.Error(w, "invalid screenshot name", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/jpeg")
+	http.ServeFile(w, r, path)
+}
+
+func dropFilter(r *http.Request) ledger.Filter {
+	q := r.URL.Query()
+	filter := ledger.Filter{
+		Supervisor: q.Get("supervisor"),
+		Quality:    q.Get("quality"),
+		RunName:    q.Get("run"),
+		Name:       q.Get("name"),
+	}
+	filter.Game, _ = strconv.Atoi(q.Get("game"))
+
+	// Optional time window, in hours from now, all the history is used by default
+	if hours, _ := strconv.Atoi(q.Get("hours")); hours > 0 {
+		filter.From = time.Now().Add(-time.Duration(hours) * time.Hour)
+	}
+
+	return filter
+}
+
+// pickitTest evaluates the pasted items, or the latest stashed items of the drop ledger, against the pickit rules
+// of the character, rules are read again from disk so changes can be tested without restarting
+func (s *HttpServer) pickitTest(w http.ResponseWriter, r *http.Request) {
+	sup := r.URL.Query().Get("supervisor")
+	cfg, found := config.Characters[sup]
+	if !found {
+		http.Error(w, "Can't test pickit rules because the configuration "+sup+" wasn't found", http.StatusNotFound)
+		return
+	}
+
+	data := PickitData{
+		Supervisor: sup,
+		Character:  cfg.CharacterName,
+	}
+	if r.Method != http.MethodPost {
+		s.templates.ExecuteTemplate(w, "pickit.gohtml", data)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data.Items = r.Form.Get("items")
+	data.Ledger, _ = strconv.Atoi(r.Form.Get("ledger"))
+
+	rules, problems := config.ReadPickitRules(sup, cfg)
+	data.RulesCount = len(rules)
+	data.Problems = problems
+
+	items, err := pickit.ParseItems([]byte(data.Items))
+	if err != nil {
+		data.ErrorMessage = err.Error()
+		s.templates.ExecuteTemplate(w, "pickit.gohtml", data)
+		return
+	}
+	for i, e := range s.ledger.Entries(ledger.Filter{Supervisor: sup}) {
+		if i >= data.Ledger {
+			break
+		}
+		it, err := pickit.FromLedger(e)
+		if err != nil {
+			data.ErrorMessage = fmt.Sprintf("error reading ledger item %s: %s", e.Name, err.Error())
+			s.templates.ExecuteTemplate(w, "pickit.gohtml", data)
+			return
+		}
+		items = append(items, it)
+	}
+
+	for _, it := range items {
+		data.Reports = append(data.Reports, pickit.Test(rules, it, pickit.OfflineState(cfg)))
+	}
+
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(data.Reports)
+		return
+	}
+
+	s.templates.ExecuteTemplate(w, "pickit.gohtml", data)
+}
+
+func (s *HttpServer) stats(w http.ResponseWriter, r *http.Request) {
+	sup := r.URL.Query().Get("supervisor")
+	cfg, found := config.Characters[sup]
+	if !found {
+		http.Error(w, "Can't fetch stats because the configuration "+sup+" wasn't found", http.StatusNotFound)
+		return
+	}
+
+	// Optional time window, in hours from now, all the history is used by default
+	var from time.Time
+	hours, _ := strconv.Atoi(r.URL.Query().Get("hours"))
+	if hours > 0 {
+		from = time.Now().Add(-time.Duration(hours) * time.Hour)
+	}
+
+	var records []stats.Record
+	if history := s.manager.GetSupervisorHistory(sup); history != nil {
+		records = history.Records(from, time.Time{})
+	} else {
+		all, err := stats.ReadHistory(stats.DefaultDirectory, sup)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, rec := range all {
+			if from.IsZero() || !rec.OccurredAt.Before(from) {
+				records = append(records, rec)
+			}
+		}
+	}
+
+	statsData := StatsData{
+		Supervisor: sup,
+		Character:  cfg.CharacterName,
+		Hours:      hours,
+		Summary:    stats.Summarize(records, from, time.Time{}),
+		Runs:       stats.AnalyzeRuns(records),
+	}
+
+	if r.URL.Query().Get("format") == "json" {
+		if config.Koolo.Overseer.Enabled {
+			enableCors(&w)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(statsData)
+		return
+	}
+
+	s.templates.ExecuteTemplate(w, "stats.gohtml", statsData)
+}
+
+func (s *HttpServer) metrics(w http.ResponseWriter, r *http.Request) {
+	statuses := []koolo.SupervisorStatus{koolo.NotStarted, koolo.Starting, koolo.InGame, koolo.Paused, koolo.Crashed, koolo.OnBreak}
+	supervisorStatus := metrics.NewGaugeFunc("koolo_supervisor_status", "Current supervisor status, 1 for the active one.", func() []metrics.GaugeValue {
+		values := make([]metrics.GaugeValue, 0)
+		for _, supervisorName := range s.manager.AvailableSupervisors() {
+			current := s.manager.Status(supervisorName).SupervisorStatus
+			if current == "" {
+				current = koolo.NotStarted
+			}
+			for _, st := range statuses {
+				v := 0.0
+				if st == current {
+					v = 1
+				}
+				values = append(values, metrics.GaugeValue{LabelValues: []string{supervisorName, string(st)}, Value: v})
+			}
+		}
+
+		return values
+	}, "supervisor", "status")
+
+	w.Header().Set("Content-Type", metrics.ContentType)
+	metrics.Write(w, supervisorStatus)
+}
+
 func (s *HttpServer) config(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodPost {
 		err := r.ParseForm()
@@ -534,6 +772,7 @@ func (s *HttpServer) config(w http.ResponseWriter, r *http.Request) {
 		// Debug
 		newConfig.Debug.Log = r.Form.Get("debug_log") == "true"
 		newConfig.Debug.Screenshots = r.Form.Get("debug_screenshots") == "true"
+		newConfig.Debug.Snapshots = r.Form.Get("debug_snapshots") == "true"
 		// Discord
 		newConfig.Discord.Enabled = r.Form.Get("discord_enabled") == "true"
 		newConfig.Discord.EnableGameCreatedMessages = r.Form.Has("enable_game_created_messages")
@@ -554,6 +793,12 @@ func (s *HttpServer) config(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		newConfig.Telegram.ChatID = telegramChatId
+		// Webhook
+		newConfig.Webhook.Enabled = r.Form.Get("webhook_enabled") == "true"
+		newConfig.Webhook.URLs = splitAndTrim(r.Form.Get("webhook_urls"), "\n")
+		newConfig.Webhook.Events = splitAndTrim(r.Form.Get("webhook_events"), ",")
+		newConfig.Webhook.Secret = r.Form.Get("webhook_secret")
+		newConfig.Webhook.IncludeScreenshot = r.Form.Has("webhook_include_screenshot")
 
 		err = config.ValidateAndSaveConfig(newConfig)
 		if err != nil {
@@ -1,9 +1,11 @@
 package server
 
 import (
-	"github.com/hectorgimenez/d2go/pkg/data"
 	koolo "github.com/hectorgimenez/koolo/internal"
 	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/ledger"
+	"github.com/hectorgimenez/koolo/internal/pickit"
+	"github.com/hectorgimenez/koolo/internal/stats"
 )
 
 type IndexData struct {
@@ -11,12 +13,35 @@ type IndexData struct {
 	Version      string
 	Status       map[string]koolo.Stats
 	DropCount    map[string]int
+	PickitErrors map[string]int
 }
 
 type DropData struct {
 	NumberOfDrops int
+	Supervisor    string
 	Character     string
-	Drops         []data.Drop
+	Filter        ledger.Filter
+	Hours         string
+	Drops         []ledger.Entry
+}
+
+type PickitData struct {
+	ErrorMessage string
+	Supervisor   string
+	Character    string
+	Items        string
+	Ledger       int
+	RulesCount   int
+	Problems     config.PickitProblems
+	Reports      []pickit.Report
+}
+
+type StatsData struct {
+	Supervisor string
+	Character  string
+	Hours      int
+	Summary    stats.Summary
+	Runs       []stats.RunAnalytics
 }
 
 type CharacterSettings struct {
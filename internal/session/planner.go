@@ -0,0 +1,85 @@
+package session
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/stats"
+)
+
+// Planner decides when the character plays and when it takes a break, based on the session settings and the
+// games played today according to the stats history
+type Planner struct {
+	history   *stats.Store
+	startedAt time.Time
+}
+
+// NewPlanner creates a planner, history can be nil if it could not be opened, daily caps are not applied in that case
+func NewPlanner(history *stats.Store) *Planner {
+	return &Planner{history: history}
+}
+
+// Break returns true if the character has to take a break before creating a new game, with the time the next
+// session starts and the reason
+func (p *Planner) Break(cfg *config.CharacterCfg, now time.Time) (time.Time, string, bool) {
+	s := cfg.Session
+	if !s.Enabled {
+		return time.Time{}, "", false
+	}
+
+	next, reason := time.Time{}, ""
+	games, played := p.playedOn(now)
+	switch {
+	case s.MaxGamesPerDay > 0 && games >= s.MaxGamesPerDay:
+		next, reason = tomorrow(now), fmt.Sprintf("%d games played today, max is %d", games, s.MaxGamesPerDay)
+	case s.MaxHoursPerDay > 0 && played.Hours() >= s.MaxHoursPerDay:
+		next, reason = tomorrow(now), fmt.Sprintf("%s played today, max is %.1f hours", played.Round(time.Minute), s.MaxHoursPerDay)
+	case s.SessionLength > 0 && !p.startedAt.IsZero() && now.Sub(p.startedAt) >= s.SessionLength:
+		next, reason = now.Add(s.BreakDuration), fmt.Sprintf("session of %s finished", s.SessionLength)
+	case len(s.ActiveHours) > 0 && !config.InsideTimeWindows(s.ActiveHours, now):
+		next, reason = now, fmt.Sprintf("outside of the active hours %v", s.ActiveHours)
+	}
+
+	if next.IsZero() {
+		if p.startedAt.IsZero() {
+			p.startedAt = now
+		}
+
+		return time.Time{}, "", false
+	}
+
+	p.startedAt = time.Time{}
+
+	return config.NextTimeInWindows(s.ActiveHours, next), reason, true
+}
+
+// playedOn returns the number of games created the same day and the time spent in them
+func (p *Planner) playedOn(now time.Time) (int, time.Duration) {
+	if p.history == nil {
+		return 0, 0
+	}
+
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	games := 0
+	played := time.Duration(0)
+	gameStartedAt := time.Time{}
+	for _, r := range p.history.Records(day, time.Time{}) {
+		switch r.Type {
+		case stats.RecordGameCreated:
+			games++
+			gameStartedAt = r.OccurredAt
+		case stats.RecordGameFinished:
+			if !gameStartedAt.IsZero() {
+				played += r.OccurredAt.Sub(gameStartedAt)
+				gameStartedAt = time.Time{}
+			}
+		}
+	}
+
+	return games, played
+}
+
+func tomorrow(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+}
@@ -0,0 +1,219 @@
+package session_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/session"
+	"github.com/hectorgimenez/koolo/internal/stats"
+)
+
+func today(hour, minute int) time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, time.Local)
+}
+
+func newHistory(t *testing.T, records ...stats.Record) *stats.Store {
+	t.Helper()
+
+	history, err := stats.Open(t.TempDir(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { history.Close() })
+	for _, r := range records {
+		if err = history.Append(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return history
+}
+
+// game returns the records of a game created at the given time and lasting the given minutes
+func game(at time.Time, minutes int) []stats.Record {
+	return []stats.Record{
+		{Type: stats.RecordGameCreated, OccurredAt: at},
+		{Type: stats.RecordGameFinished, OccurredAt: at.Add(time.Duration(minutes) * time.Minute)},
+	}
+}
+
+func sessionCfg() *config.CharacterCfg {
+	cfg := &config.CharacterCfg{}
+	cfg.Session.Enabled = true
+
+	return cfg
+}
+
+func TestBreakDisabled(t *testing.T) {
+	cfg := sessionCfg()
+	cfg.Session.Enabled = false
+	cfg.Session.MaxGamesPerDay = 1
+
+	p := session.NewPlanner(newHistory(t, game(today(10, 0), 5)...))
+	if _, _, found := p.Break(cfg, today(12, 0)); found {
+		t.Error("breaks are taken with the sessions disabled")
+	}
+}
+
+func TestBreakDailyCaps(t *testing.T) {
+	yesterday := today(10, 0).AddDate(0, 0, -1)
+	now := today(12, 0)
+	tomorrow := today(0, 0).AddDate(0, 0, 1)
+
+	tests := []struct {
+		name     string
+		games    int
+		hours    float64
+		history  [][]stats.Record
+		want     bool
+		contains string
+	}{
+		{
+			name:    "games below the cap",
+			games:   3,
+			history: [][]stats.Record{game(today(10, 0), 5), game(today(10, 10), 5)},
+			want:    false,
+		},
+		{
+			name:     "games cap reached",
+			games:    2,
+			history:  [][]stats.Record{game(today(10, 0), 5), game(today(10, 10), 5)},
+			want:     true,
+			contains: "2 games played today",
+		},
+		{
+			name:    "games of yesterday are not counted",
+			games:   2,
+			history: [][]stats.Record{game(yesterday, 5), game(yesterday.Add(10*time.Minute), 5), game(today(10, 0), 5)},
+			want:    false,
+		},
+		{
+			name:     "hours cap reached",
+			hours:    1,
+			history:  [][]stats.Record{game(today(9, 0), 40), game(today(10, 0), 20)},
+			want:     true,
+			contains: "1h0m0s played today",
+		},
+		{
+			name:  "hours below the cap",
+			hours: 1,
+			// Last game is still running, the time since it was created is not counted
+			history: [][]stats.Record{game(today(9, 0), 40), {{Type: stats.RecordGameCreated, OccurredAt: today(10, 0)}}},
+			want:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := sessionCfg()
+			cfg.Session.MaxGamesPerDay = tc.games
+			cfg.Session.MaxHoursPerDay = tc.hours
+
+			var records []stats.Record
+			for _, g := range tc.history {
+				records = append(records, g...)
+			}
+
+			next, reason, found := session.NewPlanner(newHistory(t, records...)).Break(cfg, now)
+			if found != tc.want {
+				t.Fatalf("expected break %t, got %t (%s)", tc.want, found, reason)
+			}
+			if !found {
+				return
+			}
+			if !next.Equal(tomorrow) {
+				t.Errorf("expected the next session at midnight, got %s", next)
+			}
+			if !strings.Contains(reason, tc.contains) {
+				t.Errorf("expected the reason to contain %q, got %q", tc.contains, reason)
+			}
+		})
+	}
+}
+
+func TestBreakWithoutHistory(t *testing.T) {
+	cfg := sessionCfg()
+	cfg.Session.MaxGamesPerDay = 1
+	cfg.Session.MaxHoursPerDay = 1
+
+	if _, reason, found := session.NewPlanner(nil).Break(cfg, today(12, 0)); found {
+		t.Errorf("daily caps can't be checked without history, got a break: %s", reason)
+	}
+}
+
+func TestBreakSessionLength(t *testing.T) {
+	cfg := sessionCfg()
+	cfg.Session.SessionLength = 2 * time.Hour
+	cfg.Session.BreakDuration = 30 * time.Minute
+
+	p := session.NewPlanner(nil)
+	start := today(8, 0)
+	if _, _, found := p.Break(cfg, start); found {
+		t.Fatal("break before starting the session")
+	}
+	if _, _, found := p.Break(cfg, start.Add(119*time.Minute)); found {
+		t.Fatal("break before the session length")
+	}
+
+	next, _, found := p.Break(cfg, start.Add(2*time.Hour))
+	if !found {
+		t.Fatal("expected a break after the session length")
+	}
+	if want := start.Add(2*time.Hour + 30*time.Minute); !next.Equal(want) {
+		t.Errorf("expected the next session at %s, got %s", want, next)
+	}
+
+	// Next session starts counting again after the break
+	if _, _, found = p.Break(cfg, next); found {
+		t.Error("break right after the previous one")
+	}
+	if _, _, found = p.Break(cfg, next.Add(time.Hour)); found {
+		t.Error("break before the length of the new session")
+	}
+	if _, _, found = p.Break(cfg, next.Add(2*time.Hour)); !found {
+		t.Error("expected a break after the length of the new session")
+	}
+}
+
+func TestBreakActiveHours(t *testing.T) {
+	night, err := config.ParseTimeWindow("22:00-02:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := sessionCfg()
+	cfg.Session.ActiveHours = []config.TimeWindow{night}
+
+	tests := []struct {
+		at   time.Time
+		want time.Time
+	}{
+		{at: today(23, 0)},
+		{at: today(1, 0)},
+		{at: today(2, 0), want: today(22, 0)},
+		{at: today(12, 0), want: today(22, 0)},
+	}
+
+	for _, tc := range tests {
+		next, _, found := session.NewPlanner(nil).Break(cfg, tc.at)
+		if found != !tc.want.IsZero() {
+			t.Errorf("at %s: expected break %t, got %t", tc.at.Format("15:04"), !tc.want.IsZero(), found)
+			continue
+		}
+		if found && !next.Equal(tc.want) {
+			t.Errorf("at %s: expected the next session at %s, got %s", tc.at.Format("15:04"), tc.want.Format("15:04"), next)
+		}
+	}
+
+	// Breaks ending outside the active hours are extended until they start
+	cfg.Session.SessionLength = time.Hour
+	cfg.Session.BreakDuration = 3 * time.Hour
+	p := session.NewPlanner(nil)
+	p.Break(cfg, today(22, 0))
+	next, _, found := p.Break(cfg, today(23, 0))
+	if !found || !next.Equal(today(22, 0).AddDate(0, 0, 1)) {
+		t.Errorf("expected the next session tomorrow at 22:00, got %s (break %t)", next, found)
+	}
+}
@@ -0,0 +1,98 @@
+package simulator
+
+import (
+	"github.com/hectorgimenez/koolo/internal/game"
+)
+
+const (
+	InputMove            InputKind = "move"
+	InputMouseDown       InputKind = "mouse_down"
+	InputMouseUp         InputKind = "mouse_up"
+	InputKeyDown         InputKind = "key_down"
+	InputKeyUp           InputKind = "key_up"
+	InputModifier        InputKind = "modifier"
+	InputRestoreModifier InputKind = "restore_modifier"
+)
+
+type InputKind string
+
+type Input struct {
+	Kind     InputKind
+	X, Y     int
+	Button   game.MouseButton
+	Key      byte
+	Modifier game.ModifierKey
+}
+
+// Clicks returns the position of every mouse button press
+func Clicks(input []Input, btn game.MouseButton) []Input {
+	clicks := make([]Input, 0)
+	for _, in := range input {
+		if in.Kind == InputMouseDown && in.Button == btn {
+			clicks = append(clicks, in)
+		}
+	}
+
+	return clicks
+}
+
+// KeyPresses returns the keys pressed, in order
+func KeyPresses(input []Input) []byte {
+	keys := make([]byte, 0)
+	for _, in := range input {
+		if in.Kind == InputKeyDown {
+			keys = append(keys, in.Key)
+		}
+	}
+
+	return keys
+}
+
+func (s *Simulator) MovePointer(x, y int) {
+	s.receive(Input{Kind: InputMove, X: x, Y: y})
+}
+
+func (s *Simulator) MouseButton(btn game.MouseButton, x, y int, down bool) {
+	kind := InputMouseUp
+	if down {
+		kind = InputMouseDown
+	}
+	s.receive(Input{Kind: kind, X: x, Y: y, Button: btn})
+}
+
+func (s *Simulator) Key(key byte, down bool) {
+	kind := InputKeyUp
+	if down {
+		kind = InputKeyDown
+	}
+	s.receive(Input{Kind: kind, Key: key})
+}
+
+func (s *Simulator) OverrideModifier(modifier game.ModifierKey) {
+	s.receive(Input{Kind: InputModifier, Modifier: modifier})
+}
+
+func (s *Simulator) RestoreModifier() {
+	s.receive(Input{Kind: InputRestoreModifier})
+}
+
+// Input returns the input received since the previous call
+func (s *Simulator) Input() []Input {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	input := s.input
+	s.input = nil
+
+	return input
+}
+
+func (s *Simulator) receive(in Input) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.input = append(s.input, in)
+	for _, react := range s.reactions {
+		react(&s.data, in)
+	}
+}
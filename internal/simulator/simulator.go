@@ -0,0 +1,168 @@
+package simulator
+
+import (
+	"image"
+	"log/slog"
+	"sync"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/container"
+	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/game/map_client"
+	"github.com/hectorgimenez/koolo/internal/pather"
+	"github.com/hectorgimenez/koolo/internal/ui"
+)
+
+const (
+	defaultGameAreaSizeX = 1280
+	defaultGameAreaSizeY = 720
+)
+
+// Transition changes the simulated game data, it's the way tests script what the game looks like
+type Transition func(d *data.Data)
+
+// Reaction changes the simulated game data in response to the input, like moving the player after a click
+type Reaction func(d *data.Data, in Input)
+
+// Simulator is an in-memory game: it implements game.DataReader, game.Screenshotter and game.InputSender, so it
+// can replace the real game in the container. Game data is scripted and all the received input is recorded.
+type Simulator struct {
+	cfg           *config.CharacterCfg
+	mapSeed       uint
+	mapData       map_client.MapData
+	gameAreaSizeX int
+	gameAreaSizeY int
+
+	mu          sync.Mutex
+	data        data.Data
+	transitions []Transition
+	reactions   []Reaction
+	input       []Input
+}
+
+func New(cfg *config.CharacterCfg, d data.Data) *Simulator {
+	return &Simulator{
+		cfg:           cfg,
+		data:          d,
+		gameAreaSizeX: defaultGameAreaSizeX,
+		gameAreaSizeY: defaultGameAreaSizeY,
+	}
+}
+
+// SetMapData sets the map data returned by GetCachedMapData, it's needed for paths going outside the current level
+func (s *Simulator) SetMapData(seed uint, md map_client.MapData) {
+	s.mapSeed = seed
+	s.mapData = md
+}
+
+func (s *Simulator) SetGameAreaSize(x, y int) {
+	s.gameAreaSizeX = x
+	s.gameAreaSizeY = y
+}
+
+// Set replaces the current game data, pending transitions are kept
+func (s *Simulator) Set(d data.Data) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = d
+}
+
+// Update applies the transition right now
+func (s *Simulator) Update(t Transition) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t(&s.data)
+}
+
+// Script queues transitions, every GetData call applies the next one before returning, so each transition
+// describes the game as seen in the next bot loop iteration
+func (s *Simulator) Script(transitions ...Transition) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.transitions = append(s.transitions, transitions...)
+}
+
+// OnInput registers a reaction executed on every input received
+func (s *Simulator) OnInput(r Reaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reactions = append(s.reactions, r)
+}
+
+// Pending returns the number of scripted transitions not applied yet
+func (s *Simulator) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.transitions)
+}
+
+func (s *Simulator) GetData(_ bool) game.Data {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.transitions) > 0 {
+		s.transitions[0](&s.data)
+		s.transitions = s.transitions[1:]
+	}
+
+	return game.Data{Data: s.data, CharacterCfg: *s.cfg}
+}
+
+func (s *Simulator) GetCachedMapData(_ bool) map_client.MapData {
+	return s.mapData
+}
+
+func (s *Simulator) MapSeed() uint {
+	return s.mapSeed
+}
+
+func (s *Simulator) GameAreaSize() (x, y int) {
+	return s.gameAreaSizeX, s.gameAreaSizeY
+}
+
+func (s *Simulator) LegacyGraphics() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.data.LegacyGraphics
+}
+
+func (s *Simulator) GetKeyBindings() data.KeyBindings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.data.KeyBindings
+}
+
+// Screenshot returns a black image with the size of the game area
+func (s *Simulator) Screenshot() image.Image {
+	return image.NewRGBA(image.Rect(0, 0, s.gameAreaSizeX, s.gameAreaSizeY))
+}
+
+func (s *Simulator) ScreenshotWithRelease() (image.Image, error) {
+	return s.Screenshot(), nil
+}
+
+// Container returns a container backed by the simulator. There is no game process, so Injector and Manager are nil.
+func (s *Simulator) Container(supervisor string, logger *slog.Logger) container.Container {
+	hid := game.NewHIDWithSender(s)
+
+	return container.Container{
+		Supervisor:    supervisor,
+		Logger:        logger,
+		Reader:        s,
+		Screenshotter: s,
+		HID:           hid,
+		PathFinder:    pather.NewPathFinder(s, hid, s.cfg),
+		CharacterCfg:  s.cfg,
+		EventListener: event.NewListener(logger),
+		UIManager:     ui.NewManager(s),
+	}
+}
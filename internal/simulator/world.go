@@ -0,0 +1,446 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/container"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/game/map_client"
+	"github.com/hectorgimenez/koolo/internal/pather"
+)
+
+const (
+	defaultHitsToKill   = 3
+	defaultWalkDistance = 10
+	defaultMonsterLife  = 100
+	// Max distance between the clicked position and the unit, in tiles
+	clickTolerance = 2
+	// Tiles blocked around a closed door
+	doorSize      = 1
+	firstObjectID = 1000
+)
+
+// Report is what the simulated player did in the world
+type Report struct {
+	// Path contains every position the player moved to
+	Path []data.Position
+	// Areas contains every area the player entered, starting area not included
+	Areas      []area.ID
+	Killed     []data.UnitID
+	PickedUp   []data.UnitID
+	Interacted []object.Name
+	// Blocked is the number of movements that ended before reaching the destination due to collisions
+	Blocked int
+	// Skipped is the number of actions skipped after failing with ErrCanBeSkipped
+	Skipped    int
+	Iterations int
+}
+
+// World simulates the levels from koolo-map data: the player walks or teleports to the clicked positions if they are
+// reachable, scripted monsters die after receiving some attacks, ground items are picked up when clicked, doors
+// block walking until they are clicked and walking over an exit (or clicking an entrance) loads the next level.
+// Everything happens instantly, no randomness is involved from the world side.
+type World struct {
+	*Simulator
+	md           map_client.MapData
+	hitsToKill   int
+	walkDistance int
+	pointer      data.Position
+	hits         map[data.UnitID]int
+	report       Report
+}
+
+// LoadMapData reads map data from a map cache file or from the koolo-map output saved to disk (one level per line)
+func LoadMapData(path string) (map_client.MapData, error) {
+	if strings.HasSuffix(path, ".gz") {
+		return map_client.LoadCacheFile(path)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	md := map_client.ParseMapData(raw)
+	if len(md) == 0 {
+		return nil, fmt.Errorf("no levels found in %s", path)
+	}
+
+	return md, nil
+}
+
+// NewWorld places the player in the given level and position, d is used as base game data (character stats,
+// skills, key bindings...) and the level related data is overwritten.
+func NewWorld(cfg *config.CharacterCfg, md map_client.MapData, d data.Data, lvl area.ID, start data.Position) (*World, error) {
+	if _, found := md.GetLevelData(lvl); !found {
+		return nil, fmt.Errorf("level %d not found in map data", lvl)
+	}
+
+	w := &World{
+		Simulator:    New(cfg, d),
+		md:           md,
+		hitsToKill:   defaultHitsToKill,
+		walkDistance: defaultWalkDistance,
+		hits:         make(map[data.UnitID]int),
+	}
+	w.SetMapData(0, md)
+	w.Update(func(d *data.Data) {
+		w.loadLevel(d, lvl, start)
+	})
+	w.OnInput(w.react)
+
+	return w, nil
+}
+
+// SetHitsToKill sets the number of attacks needed to kill any monster
+func (w *World) SetHitsToKill(hits int) {
+	w.hitsToKill = hits
+}
+
+// Spawn adds monsters to the current level, monsters without life get a default one
+func (w *World) Spawn(monsters ...data.Monster) {
+	w.Update(func(d *data.Data) {
+		for _, m := range monsters {
+			if m.Stats == nil {
+				m.Stats = make(map[stat.ID]int)
+			}
+			if m.Stats[stat.Life] <= 0 {
+				m.Stats[stat.Life] = defaultMonsterLife
+			}
+			if m.Type == "" {
+				m.Type = data.MonsterTypeNone
+			}
+			d.Monsters = append(d.Monsters, m)
+		}
+	})
+}
+
+// Drop adds items to the ground
+func (w *World) Drop(items ...data.Item) {
+	w.Update(func(d *data.Data) {
+		for _, i := range items {
+			i.Location = item.Location{LocationType: item.LocationGround}
+			d.Inventory.AllItems = append(d.Inventory.AllItems, i)
+		}
+	})
+}
+
+func (w *World) Report() Report {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.report
+}
+
+// Run calls NextStep the same way the bot loop does, until the action finishes, fails or the timeout is reached.
+// Errors on actions that can be skipped don't stop it, the action is skipped as the bot does.
+// Steps have waits based on real time, so the simulation takes as much time as the real action would.
+func (w *World) Run(ctx context.Context, c container.Container, act action.Action, timeout time.Duration) (Report, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return w.Report(), ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		w.mu.Lock()
+		w.report.Iterations++
+		w.mu.Unlock()
+
+		err := act.NextStep(w.GetData(false), c)
+		switch {
+		case errors.Is(err, action.ErrNoMoreSteps):
+			return w.Report(), nil
+		case errors.Is(err, action.ErrWillBeRetried), errors.Is(err, action.ErrLogAndContinue):
+			continue
+		case errors.Is(err, action.ErrCanBeSkipped):
+			w.mu.Lock()
+			w.report.Skipped++
+			w.mu.Unlock()
+			act.Skip()
+			continue
+		case err != nil:
+			return w.Report(), err
+		}
+	}
+}
+
+func (w *World) loadLevel(d *data.Data, lvl area.ID, pos data.Position) {
+	origin := w.md.Origin(lvl)
+	npcs, exits, objects, rooms := w.md.NPCsExitsAndObjects(origin, lvl)
+
+	// Map data has no unit IDs, objects need one to be interacted and they can be used until they are clicked
+	for i := range objects {
+		objects[i].ID = data.UnitID(firstObjectID + i)
+		objects[i].Selectable = true
+	}
+
+	d.PlayerUnit.Area = lvl
+	d.PlayerUnit.Position = pos
+	d.AreaOrigin = origin
+	d.NPCs = npcs
+	d.AdjacentLevels = exits
+	d.Objects = objects
+	d.Rooms = rooms
+	d.CollisionGrid = w.md.CollisionGrid(lvl)
+	d.Monsters = nil
+	d.Corpses = nil
+}
+
+// react is called with the simulator lock held, it's the only place where the world changes after the setup
+func (w *World) react(d *data.Data, in Input) {
+	switch in.Kind {
+	case InputMove:
+		w.pointer = w.screenToGame(d, in.X, in.Y)
+		w.hover(d)
+	case InputKeyDown:
+		if keyMatches(d.KeyBindings.ForceMove, in.Key) {
+			w.walk(d, w.pointer)
+		}
+	case InputMouseDown:
+		target := w.screenToGame(d, in.X, in.Y)
+		switch {
+		case w.hit(d, target):
+		case in.Button == game.LeftButton && w.pickup(d, target):
+		case in.Button == game.LeftButton && w.interact(d, target):
+		case in.Button == game.LeftButton && w.enter(d, target):
+		case in.Button == game.RightButton && w.canTeleport(d):
+			w.teleport(d, target)
+		case in.Button == game.LeftButton:
+			w.walk(d, target)
+		}
+	}
+}
+
+// screenToGame reverts the isometric transformation done by the path finder
+func (w *World) screenToGame(d *data.Data, x, y int) data.Position {
+	sizeX, sizeY := w.GameAreaSize()
+	a := float64(x-sizeX/2) / 19.8
+	b := float64(y-sizeY/2) / 9.9
+
+	return data.Position{
+		X: d.PlayerUnit.Position.X + int(math.Round((a+b)/2)),
+		Y: d.PlayerUnit.Position.Y + int(math.Round((b-a)/2)),
+	}
+}
+
+func (w *World) hover(d *data.Data) {
+	d.HoverData = data.HoverData{}
+	for i := range d.Monsters {
+		d.Monsters[i].IsHovered = pather.DistanceFromPoint(d.Monsters[i].Position, w.pointer) <= clickTolerance
+		if d.Monsters[i].IsHovered {
+			d.HoverData = data.HoverData{IsHovered: true, UnitID: d.Monsters[i].UnitID, UnitType: 1}
+		}
+	}
+	for i := range d.Objects {
+		d.Objects[i].IsHovered = pather.DistanceFromPoint(d.Objects[i].Position, w.pointer) <= clickTolerance
+		if d.Objects[i].IsHovered {
+			d.HoverData = data.HoverData{IsHovered: true, UnitID: d.Objects[i].ID, UnitType: 2}
+		}
+	}
+	for i := range d.Inventory.AllItems {
+		itm := &d.Inventory.AllItems[i]
+		itm.IsHovered = itm.Location.LocationType == item.LocationGround && pather.DistanceFromPoint(itm.Position, w.pointer) <= clickTolerance
+		if itm.IsHovered {
+			d.HoverData = data.HoverData{IsHovered: true, UnitID: itm.UnitID, UnitType: 4}
+		}
+	}
+}
+
+func (w *World) hit(d *data.Data, target data.Position) bool {
+	for i, m := range d.Monsters {
+		if m.Stats[stat.Life] <= 0 || pather.DistanceFromPoint(m.Position, target) > clickTolerance {
+			continue
+		}
+
+		w.hits[m.UnitID]++
+		if w.hits[m.UnitID] >= w.hitsToKill {
+			d.Monsters[i].Stats[stat.Life] = 0
+			d.Corpses = append(d.Corpses, d.Monsters[i])
+			d.Monsters = append(d.Monsters[:i], d.Monsters[i+1:]...)
+			w.report.Killed = append(w.report.Killed, m.UnitID)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (w *World) pickup(d *data.Data, target data.Position) bool {
+	for i, itm := range d.Inventory.AllItems {
+		if itm.Location.LocationType != item.LocationGround || pather.DistanceFromPoint(itm.Position, target) > clickTolerance {
+			continue
+		}
+
+		d.Inventory.AllItems[i].Location = item.Location{LocationType: item.LocationInventory}
+		d.Inventory.AllItems[i].IsHovered = false
+		w.report.PickedUp = append(w.report.PickedUp, itm.UnitID)
+
+		return true
+	}
+
+	return false
+}
+
+// interact uses the object, doors are opened and stop blocking the way
+func (w *World) interact(d *data.Data, target data.Position) bool {
+	for i, o := range d.Objects {
+		if pather.DistanceFromPoint(o.Position, target) <= clickTolerance {
+			d.Objects[i].Selectable = false
+			w.report.Interacted = append(w.report.Interacted, o.Name)
+			return true
+		}
+	}
+
+	return false
+}
+
+// closedDoor returns true if there is a closed door on the tile
+func (w *World) closedDoor(d *data.Data, pos data.Position) bool {
+	for _, o := range d.Objects {
+		if o.IsDoor() && o.Selectable && pather.DistanceFromPoint(o.Position, pos) <= doorSize {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enter loads the level when an entrance (stairs, doors...) is clicked
+func (w *World) enter(d *data.Data, target data.Position) bool {
+	for _, lvl := range d.AdjacentLevels {
+		if lvl.IsEntrance && pather.DistanceFromPoint(lvl.Position, target) <= clickTolerance {
+			w.changeLevel(d, lvl.Area)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *World) teleport(d *data.Data, target data.Position) {
+	if !pather.IsWalkable(target, d.AreaOrigin, d.CollisionGrid) {
+		w.report.Blocked++
+		return
+	}
+
+	w.moveTo(d, target)
+}
+
+// walk moves the player towards the target one tile at a time, up to the walk distance. When the direct way is
+// blocked by a non-walkable tile or a closed door it slides along the obstacle, like the game does, and stops if
+// it can not get closer.
+func (w *World) walk(d *data.Data, target data.Position) {
+	lvl := d.PlayerUnit.Area
+	for i := 0; i < w.walkDistance && d.PlayerUnit.Position != target; i++ {
+		next, found := w.nextTile(d, d.PlayerUnit.Position, target)
+		if !found {
+			w.report.Blocked++
+			return
+		}
+
+		// Walking over an exit loads the next level, the rest of the movement is lost
+		if w.moveTo(d, next); d.PlayerUnit.Area != lvl {
+			return
+		}
+	}
+}
+
+// nextTile returns the walkable tile next to from that gets closer to the target, diagonal first
+func (w *World) nextTile(d *data.Data, from, target data.Position) (data.Position, bool) {
+	dx, dy := sign(target.X-from.X), sign(target.Y-from.Y)
+	candidates := []data.Position{{X: from.X + dx, Y: from.Y + dy}}
+	if abs(target.X-from.X) >= abs(target.Y-from.Y) {
+		candidates = append(candidates, data.Position{X: from.X + dx, Y: from.Y}, data.Position{X: from.X, Y: from.Y + dy})
+	} else {
+		candidates = append(candidates, data.Position{X: from.X, Y: from.Y + dy}, data.Position{X: from.X + dx, Y: from.Y})
+	}
+
+	for _, c := range candidates {
+		if c != from && pather.IsWalkable(c, d.AreaOrigin, d.CollisionGrid) && !w.closedDoor(d, c) {
+			return c, true
+		}
+	}
+
+	return from, false
+}
+
+func (w *World) moveTo(d *data.Data, pos data.Position) {
+	d.PlayerUnit.Position = pos
+	w.report.Path = append(w.report.Path, pos)
+
+	// Walking over an exit loads the next level
+	for _, lvl := range d.AdjacentLevels {
+		if !lvl.IsEntrance && pather.DistanceFromPoint(lvl.Position, pos) <= clickTolerance {
+			w.changeLevel(d, lvl.Area)
+			return
+		}
+	}
+}
+
+// changeLevel loads the level placing the player next to the exit leading back to the previous one
+func (w *World) changeLevel(d *data.Data, lvl area.ID) {
+	previous := d.PlayerUnit.Area
+	origin := w.md.Origin(lvl)
+	_, exits, _, _ := w.md.NPCsExitsAndObjects(origin, lvl)
+
+	pos := origin
+	for _, exit := range exits {
+		if exit.Area == previous {
+			pos = exit.Position
+			break
+		}
+	}
+
+	w.loadLevel(d, lvl, pos)
+	w.report.Areas = append(w.report.Areas, lvl)
+	w.report.Path = append(w.report.Path, pos)
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+
+	return 0
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
+
+func keyMatches(kb data.KeyBinding, key byte) bool {
+	if kb.Key1[0] == 0 || kb.Key1[0] == 255 {
+		return kb.Key2[0] == key
+	}
+
+	return kb.Key1[0] == key
+}
+
+func (w *World) canTeleport(d *data.Data) bool {
+	return game.Data{Data: *d, CharacterCfg: *w.cfg}.CanTeleport()
+}
@@ -0,0 +1,201 @@
+package simulator_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/object"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/action/step"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/container"
+	"github.com/hectorgimenez/koolo/internal/game"
+	"github.com/hectorgimenez/koolo/internal/health"
+	"github.com/hectorgimenez/koolo/internal/pather"
+	"github.com/hectorgimenez/koolo/internal/simulator"
+	"github.com/hectorgimenez/koolo/internal/town"
+)
+
+// Fixtures in testdata are hand-written levels in the koolo-map output format, they are not generated by koolo-map
+// because it needs the game installed. Lut Gholein and Durance reproduce the layouts that caused pathing issues, the
+// one tile fake path and a corridor closed by a door, Den of Evil is an open level split in two rooms.
+func newWorld(t *testing.T, fixture string, lvl area.ID, start data.Position) (*simulator.World, container.Container) {
+	t.Helper()
+
+	if config.Koolo == nil {
+		config.Koolo = &config.KooloCfg{}
+	}
+
+	md, err := simulator.LoadMapData(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := data.Data{}
+	d.KeyBindings.ForceMove = data.KeyBinding{Key1: [2]byte{'L', 0}}
+
+	origin := md.Origin(lvl)
+	w, err := simulator.NewWorld(&config.CharacterCfg{}, md, d, lvl, data.Position{X: origin.X + start.X, Y: origin.Y + start.Y})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return w, w.Container("test", slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestLutGholeinFakePathIsNotWalked(t *testing.T) {
+	w, c := newWorld(t, "testdata/lut_gholein.jsonl", area.LutGholein, data.Position{X: 195, Y: 13})
+	origin := w.GetData(false).AreaOrigin
+	to := data.Position{X: origin.X + 225, Y: origin.Y + 13}
+	fakePath := data.Position{X: origin.X + 210, Y: origin.Y + 13}
+
+	b := action.NewBuilder(c, town.ShopManager{}, health.BeltManager{}, nil)
+	report, err := w.Run(context.Background(), c, b.MoveToCoords(to), 30*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if slices.Contains(report.Path, fakePath) {
+		t.Errorf("player walked through the fake path at %v", fakePath)
+	}
+	if pos := w.GetData(false).PlayerUnit.Position; pather.DistanceFromPoint(pos, to) > 5 {
+		t.Errorf("player ended at %v, destination was %v", pos, to)
+	}
+}
+
+func TestDuranceDoorIsOpened(t *testing.T) {
+	w, c := newWorld(t, "testdata/durance_of_hate_2.jsonl", area.DuranceOfHateLevel2, data.Position{X: 20, Y: 20})
+	origin := w.GetData(false).AreaOrigin
+	to := data.Position{X: origin.X + 65, Y: origin.Y + 20}
+
+	b := action.NewBuilder(c, town.ShopManager{}, health.BeltManager{}, nil)
+	report, err := w.Run(context.Background(), c, b.MoveToCoords(to), 30*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Contains(report.Interacted, object.IronGrateDoorLeft) {
+		t.Errorf("door was not opened, interacted with %v", report.Interacted)
+	}
+	if pos := w.GetData(false).PlayerUnit.Position; pather.DistanceFromPoint(pos, to) > 5 {
+		t.Errorf("player ended at %v, destination was %v", pos, to)
+	}
+}
+
+// skippableAction fails once with ErrCanBeSkipped, like a chain built with CanBeSkipped
+type skippableAction struct {
+	skipped bool
+}
+
+func (a *skippableAction) NextStep(_ game.Data, _ container.Container) error {
+	if a.skipped {
+		return action.ErrNoMoreSteps
+	}
+
+	return errors.Join(errors.New("door could not be opened"), action.ErrCanBeSkipped)
+}
+
+func (a *skippableAction) Skip() {
+	a.skipped = true
+}
+
+func (a *skippableAction) IsFinished() bool {
+	return a.skipped
+}
+
+func TestRunSkipsActionsThatCanBeSkipped(t *testing.T) {
+	w, c := newWorld(t, "testdata/durance_of_hate_2.jsonl", area.DuranceOfHateLevel2, data.Position{X: 20, Y: 20})
+
+	act := &skippableAction{}
+	report, err := w.Run(context.Background(), c, act, time.Second)
+	if err != nil {
+		t.Fatalf("expected the action to be skipped, got %v", err)
+	}
+	if !act.skipped || report.Skipped != 1 {
+		t.Errorf("action was not skipped once, skipped: %t, report: %d", act.skipped, report.Skipped)
+	}
+}
+
+func TestTravelToFailsWithoutRoute(t *testing.T) {
+	// Only Durance of Hate is in the map data, there is no way to reach Blood Moor
+	w, c := newWorld(t, "testdata/durance_of_hate_2.jsonl", area.DuranceOfHateLevel2, data.Position{X: 20, Y: 20})
+
+	b := action.NewBuilder(c, town.ShopManager{}, health.BeltManager{}, nil)
+	_, err := w.Run(context.Background(), c, b.TravelTo(area.BloodMoor), 10*time.Second)
+	if !errors.Is(err, action.ErrNoRecover) {
+		t.Errorf("expected an unrecoverable error when there is no route to the destination, got %v", err)
+	}
+}
+
+// barbarian kills monsters with three primary attacks, it's the only thing needed from the character to clear areas
+type barbarian struct {
+	action.Character
+}
+
+func (barbarian) KillMonsterSequence(monsterSelector func(d game.Data) (data.UnitID, bool), _ []stat.Resist, _ ...step.AttackOption) action.Action {
+	return action.NewStepChain(func(d game.Data) []step.Step {
+		id, found := monsterSelector(d)
+		if !found {
+			return []step.Step{}
+		}
+
+		return []step.Step{step.PrimaryAttack(id, 3, false, step.Distance(1, 3))}
+	}, action.RepeatUntilNoSteps())
+}
+
+func newBuilder(c container.Container) *action.Builder {
+	bm := health.NewBeltManager(c.Logger, c.HID, c.CharacterCfg, c.Supervisor)
+
+	return action.NewBuilder(c, town.ShopManager{}, bm, barbarian{})
+}
+
+func TestItemPickupPicksUpItemsMatchingTheRules(t *testing.T) {
+	w, c := newWorld(t, "testdata/den_of_evil.jsonl", area.DenOfEvil, data.Position{X: 10, Y: 15})
+	pos := w.GetData(false).PlayerUnit.Position
+	w.Drop(
+		data.Item{UnitID: 1, ID: 447, Name: "Monarch", Quality: item.QualityNormal, IsRuneword: true, Position: data.Position{X: pos.X + 4, Y: pos.Y}},
+		// No rule matches it
+		data.Item{UnitID: 2, ID: 447, Name: "Monarch", Quality: item.QualityNormal, Position: data.Position{X: pos.X, Y: pos.Y + 4}},
+	)
+
+	report, err := w.Run(context.Background(), c, newBuilder(c).ItemPickup(false, -1), 30*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(report.PickedUp, []data.UnitID{1}) {
+		t.Errorf("expected only the runeword to be picked up, got %v", report.PickedUp)
+	}
+}
+
+func TestClearAreaKillsMonstersInEveryRoom(t *testing.T) {
+	w, c := newWorld(t, "testdata/den_of_evil.jsonl", area.DenOfEvil, data.Position{X: 10, Y: 15})
+	origin := w.GetData(false).AreaOrigin
+	w.Spawn(
+		data.Monster{UnitID: 1, Position: data.Position{X: origin.X + 15, Y: origin.Y + 15}},
+		// Far from the player, it's found once the second room is visited
+		data.Monster{UnitID: 2, Position: data.Position{X: origin.X + 50, Y: origin.Y + 8}},
+	)
+	w.Drop(data.Item{UnitID: 3, ID: 447, Name: "Monarch", Quality: item.QualityNormal, IsRuneword: true, Position: data.Position{X: origin.X + 40, Y: origin.Y + 25}})
+
+	report, err := w.Run(context.Background(), c, newBuilder(c).ClearArea(false, data.MonsterAnyFilter()), 30*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if slices.Sort(report.Killed); !slices.Equal(report.Killed, []data.UnitID{1, 2}) {
+		t.Errorf("expected both monsters to be killed, got %v", report.Killed)
+	}
+	if !slices.Contains(report.PickedUp, 3) {
+		t.Errorf("item dropped in the second room was not picked up, got %v", report.PickedUp)
+	}
+}
@@ -5,12 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"math/rand"
 	"time"
 
 	"github.com/hectorgimenez/koolo/internal/action"
 
 	"github.com/hectorgimenez/koolo/internal/container"
+	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/health"
 	"github.com/hectorgimenez/koolo/internal/run"
 
@@ -22,8 +22,8 @@ type SinglePlayerSupervisor struct {
 	*baseSupervisor
 }
 
-func NewSinglePlayerSupervisor(name string, bot *Bot, runFactory *run.Factory, statsHandler *StatsHandler, c container.Container, pid uint32, hwnd uintptr) (*SinglePlayerSupervisor, error) {
-	bs, err := newBaseSupervisor(bot, runFactory, name, statsHandler, c)
+func NewSinglePlayerSupervisor(name string, bot *Bot, runFactory *run.Factory, statsHandler *StatsHandler, c container.Container, gr *game.MemoryReader, pid uint32, hwnd uintptr) (*SinglePlayerSupervisor, error) {
+	bs, err := newBaseSupervisor(bot, runFactory, name, statsHandler, c, gr)
 	if err != nil {
 		return nil, err
 	}
@@ -55,6 +55,12 @@ func (s *SinglePlayerSupervisor) Start() error {
 					return fmt.Errorf("error waiting for character selection screen: %w", err)
 				}
 			}
+			if nextStart, reason, onBreak := s.session.Break(config.Characters[s.name], time.Now()); onBreak {
+				if err = s.takeBreak(ctx, nextStart, reason); err != nil {
+					return err
+				}
+				continue
+			}
 			if !s.c.Manager.InGame() {
 				if err = s.c.Manager.NewGame(); err != nil {
 					s.c.Logger.Error(fmt.Sprintf("Error creating new game: %s", err.Error()))
@@ -62,16 +68,9 @@ func (s *SinglePlayerSupervisor) Start() error {
 				}
 			}
 
-			runs := s.runFactory.BuildRuns()
+			runs := s.scheduler.Schedule(config.Characters[s.name], s.runFactory.BuildRuns())
 			gameStart := time.Now()
-			if config.Characters[s.name].Game.RandomizeRuns {
-				rand.Shuffle(len(runs), func(i, j int) { runs[i], runs[j] = runs[j], runs[i] })
-			}
-			if config.Koolo.Discord.EnableGameCreatedMessages {
-				event.Send(event.GameCreated(event.Text(s.name, "New game created"), "", ""))
-			} else {
-				event.Send(event.GameCreated(event.Text(s.name, ""), "", ""))
-			}
+			event.Send(event.GameCreated(event.Text(s.name, "New game created"), "", ""))
 			action.ResetBuffTime(s.Name())
 			s.logGameStart(runs)
 			err = s.bot.Run(ctx, firstRun, runs)
@@ -82,38 +81,26 @@ func (s *SinglePlayerSupervisor) Start() error {
 
 				switch {
 				case errors.Is(err, health.ErrChicken):
-					if config.Koolo.Discord.EnableDiscordChickenMessages {
-						event.Send(event.GameFinished(event.WithScreenshot(s.name, err.Error(), s.c.Reader.Screenshot()), event.FinishedChicken))
-					} else {
-						event.Send(event.GameFinished(event.Text(s.name, ""), event.FinishedChicken))
-					}
+					event.Send(event.GameFinished(event.WithScreenshot(s.name, err.Error(), s.c.Screenshotter.Screenshot()), event.FinishedChicken))
 					s.c.Logger.Warn(err.Error(), slog.Float64("gameLength", time.Since(gameStart).Seconds()))
 				case errors.Is(err, health.ErrMercChicken):
-					if config.Koolo.Discord.EnableDiscordChickenMessages {
-						event.Send(event.GameFinished(event.WithScreenshot(s.name, err.Error(), s.c.Reader.Screenshot()), event.FinishedMercChicken))
-					} else {
-						event.Send(event.GameFinished(event.Text(s.name, ""), event.FinishedMercChicken))
-					}
+					event.Send(event.GameFinished(event.WithScreenshot(s.name, err.Error(), s.c.Screenshotter.Screenshot()), event.FinishedMercChicken))
 					s.c.Logger.Warn(err.Error(), slog.Float64("gameLength", time.Since(gameStart).Seconds()))
 				case errors.Is(err, health.ErrDied):
-					if config.Koolo.Discord.EnableDiscordChickenMessages {
-						event.Send(event.GameFinished(event.WithScreenshot(s.name, err.Error(), s.c.Reader.Screenshot()), event.FinishedDied))
-					} else {
-						event.Send(event.GameFinished(event.Text(s.name, ""), event.FinishedDied))
-					}
+					event.Send(event.GameFinished(event.WithScreenshot(s.name, err.Error(), s.c.Screenshotter.Screenshot()), event.FinishedDied))
 					s.c.Logger.Warn(err.Error(), slog.Float64("gameLength", time.Since(gameStart).Seconds()))
 				default:
-					event.Send(event.GameFinished(event.WithScreenshot(s.name, err.Error(), s.c.Reader.Screenshot()), event.FinishedError))
+					event.Send(event.GameFinished(event.WithScreenshot(s.name, err.Error(), s.c.Screenshotter.Screenshot()), event.FinishedError))
 					s.c.Logger.Warn(
 						fmt.Sprintf("Game finished with errors, reason: %s. Game total time: %0.2fs", err.Error(), time.Since(gameStart).Seconds()),
 						slog.String("supervisor", s.name),
-						slog.Uint64("mapSeed", uint64(s.c.Reader.CachedMapSeed)),
+						slog.Uint64("mapSeed", uint64(s.c.Reader.MapSeed())),
 					)
 				}
 			}
 			if exitErr := s.c.Manager.ExitGame(); exitErr != nil {
 				errMsg := fmt.Sprintf("Error exiting game %s", err.Error())
-				event.Send(event.GameFinished(event.WithScreenshot(s.name, errMsg, s.c.Reader.Screenshot()), event.FinishedError))
+				event.Send(event.GameFinished(event.WithScreenshot(s.name, errMsg, s.c.Screenshotter.Screenshot()), event.FinishedError))
 
 				return errors.New(errMsg)
 			}
@@ -0,0 +1,115 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/container"
+	"github.com/hectorgimenez/koolo/internal/simulator"
+)
+
+// Harness drives actions against the frames of a recording instead of a real game, frames are fed through a
+// simulator so input is captured instead of being sent. There is no game process behind, actions using the
+// container Injector or Manager can not be replayed.
+type Harness struct {
+	rec Recording
+	sim *simulator.Simulator
+	c   container.Container
+}
+
+// StepResult is the outcome of calling NextStep with a single frame
+type StepResult struct {
+	Frame int
+	Err   error
+	Input []simulator.Input
+}
+
+type Result struct {
+	Steps []StepResult
+	// Finished is true when the action completed before running out of frames
+	Finished bool
+}
+
+// Input returns all the input sent by the action during the replay
+func (r Result) Input() []simulator.Input {
+	input := make([]simulator.Input, 0)
+	for _, s := range r.Steps {
+		input = append(input, s.Input...)
+	}
+
+	return input
+}
+
+func NewHarness(rec Recording, cfg *config.CharacterCfg, logger *slog.Logger) *Harness {
+	sim := simulator.New(cfg, data.Data{})
+	sim.SetMapData(rec.Header.MapSeed, rec.Header.MapData)
+	sim.SetGameAreaSize(rec.Header.GameAreaSizeX, rec.Header.GameAreaSizeY)
+
+	return &Harness{
+		rec: rec,
+		sim: sim,
+		c:   sim.Container(rec.Header.Supervisor, logger),
+	}
+}
+
+// Container returns the container used by the harness, it should be used to build the actions to replay
+func (h *Harness) Container() container.Container {
+	return h.c
+}
+
+// Run calls NextStep once per frame, the same way the bot loop does, until the action finishes, fails or there
+// are no more frames. Speed is relative to the original timing (2 is twice as fast), zero or negative feeds the
+// frames as fast as possible, but keep in mind most of the steps have some waits based on real time.
+func (h *Harness) Run(ctx context.Context, act action.Action, speed float64) (res Result, err error) {
+	// Events sent by the actions must be consumed, otherwise the event channel will be full at some point
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go h.c.EventListener.Listen(listenCtx)
+
+	h.sim.Input()
+	for i, frame := range h.rec.Frames {
+		if speed > 0 && i > 0 {
+			wait := time.Duration(float64(frame.At.Sub(h.rec.Frames[i-1].At)) / speed)
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return res, ctx.Err()
+			}
+		}
+
+		h.sim.Set(frame.Data)
+		stepErr := h.nextStep(act, i)
+		res.Steps = append(res.Steps, StepResult{Frame: i, Err: stepErr, Input: h.sim.Input()})
+
+		switch {
+		case errors.Is(stepErr, action.ErrNoMoreSteps):
+			res.Finished = true
+			return res, nil
+		case errors.Is(stepErr, action.ErrCanBeSkipped):
+			act.Skip()
+			return res, nil
+		case errors.Is(stepErr, action.ErrWillBeRetried), errors.Is(stepErr, action.ErrLogAndContinue):
+			continue
+		case stepErr != nil:
+			return res, stepErr
+		}
+	}
+
+	return res, nil
+}
+
+func (h *Harness) nextStep(act action.Action, i int) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("action panicked on frame %d: %v", i, r)
+		}
+	}()
+
+	return act.NextStep(h.sim.GetData(false), h.c)
+}
@@ -0,0 +1,66 @@
+package snapshot
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+)
+
+// Recording is a full run loaded in memory, frames are in the same order they were recorded
+type Recording struct {
+	Header Header
+	Frames []Frame
+}
+
+func Load(path string) (Recording, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Recording{}, err
+	}
+	defer f.Close()
+
+	return Read(f)
+}
+
+// Read decodes a gzip compressed recording, collision grids are rebuilt from the map data stored in the header
+func Read(r io.Reader) (Recording, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return Recording{}, fmt.Errorf("error reading snapshot: %w", err)
+	}
+	defer gz.Close()
+
+	dec := json.NewDecoder(gz)
+	rec := Recording{}
+	if err = dec.Decode(&rec.Header); err != nil {
+		return Recording{}, fmt.Errorf("error reading snapshot header: %w", err)
+	}
+
+	// Frames in the same area share the same grid, it's read only
+	grids := make(map[area.ID][][]bool)
+	for {
+		var frame Frame
+		err = dec.Decode(&frame)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			// Recordings of crashed or killed processes are not properly closed, keep what we have
+			break
+		}
+		if err != nil {
+			return rec, fmt.Errorf("error reading snapshot frame %d: %w", len(rec.Frames)+1, err)
+		}
+
+		lvl := frame.Data.PlayerUnit.Area
+		if _, found := grids[lvl]; !found && rec.Header.MapData != nil {
+			grids[lvl] = rec.Header.MapData.CollisionGrid(lvl)
+		}
+		frame.Data.CollisionGrid = grids[lvl]
+		rec.Frames = append(rec.Frames, frame)
+	}
+
+	return rec, nil
+}
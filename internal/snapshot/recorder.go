@@ -0,0 +1,109 @@
+package snapshot
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+)
+
+// Recorder writes gzip compressed game data frames into a file per run
+type Recorder struct {
+	dir      string
+	interval time.Duration
+
+	mu        sync.Mutex
+	f         *os.File
+	gz        *gzip.Writer
+	enc       *json.Encoder
+	lastFrame time.Time
+}
+
+func NewRecorder(dir string, interval time.Duration) *Recorder {
+	if dir == "" {
+		dir = DefaultDirectory
+	}
+
+	return &Recorder{
+		dir:      dir,
+		interval: interval,
+	}
+}
+
+// Start finishes the current recording, if any, and starts a new one
+func (r *Recorder) Start(h Header) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.finish(); err != nil {
+		return err
+	}
+
+	path := Path(r.dir, h)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating snapshots directory: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating snapshot file: %w", err)
+	}
+
+	r.f = f
+	r.gz = gzip.NewWriter(f)
+	r.enc = json.NewEncoder(r.gz)
+	r.lastFrame = time.Time{}
+	if err = r.enc.Encode(h); err != nil {
+		r.finish()
+		return fmt.Errorf("error writing snapshot header: %w", err)
+	}
+
+	return nil
+}
+
+// Record writes the frame if the interval since the previous one has passed. It does nothing if there is no
+// recording started, recording is stopped after the first write error.
+func (r *Recorder) Record(d data.Data) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.enc == nil || time.Since(r.lastFrame) < r.interval {
+		return nil
+	}
+	r.lastFrame = time.Now()
+
+	// Collision grid is the biggest part of the data and it can be rebuilt from the map data
+	d.CollisionGrid = nil
+	if err := r.enc.Encode(Frame{At: r.lastFrame, Data: d}); err != nil {
+		return errors.Join(fmt.Errorf("error writing snapshot frame: %w", err), r.finish())
+	}
+
+	return nil
+}
+
+// Finish closes the current recording
+func (r *Recorder) Finish() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.finish()
+}
+
+func (r *Recorder) finish() error {
+	if r.f == nil {
+		return nil
+	}
+
+	err := errors.Join(r.gz.Close(), r.f.Close())
+	r.f = nil
+	r.gz = nil
+	r.enc = nil
+
+	return err
+}
@@ -0,0 +1,46 @@
+package snapshot
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/difficulty"
+	"github.com/hectorgimenez/koolo/internal/game/map_client"
+)
+
+const (
+	DefaultDirectory = "snapshots"
+	// DefaultInterval is the minimum time between recorded frames, bot loop reads the game every 10ms but recording
+	// all of them would generate huge files without adding much value
+	DefaultInterval = 100 * time.Millisecond
+)
+
+// Header is the first line of a recording, contains everything needed to rebuild the game data of the frames
+type Header struct {
+	Supervisor    string                `json:"supervisor"`
+	Run           string                `json:"run"`
+	StartedAt     time.Time             `json:"startedAt"`
+	MapSeed       uint                  `json:"mapSeed"`
+	Difficulty    difficulty.Difficulty `json:"difficulty"`
+	GameAreaSizeX int                   `json:"gameAreaSizeX"`
+	GameAreaSizeY int                   `json:"gameAreaSizeY"`
+	MapData       map_client.MapData    `json:"mapData"`
+}
+
+// Frame is the game data read at a given moment. Collision grid is not stored, it's rebuilt from the map data.
+type Frame struct {
+	At   time.Time `json:"at"`
+	Data data.Data `json:"data"`
+}
+
+var unsafeChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// Path returns the file for a recording, one per run: <dir>/<supervisor>/<start time>-<run>.jsonl.gz
+func Path(dir string, h Header) string {
+	name := fmt.Sprintf("%s-%s.jsonl.gz", h.StartedAt.Format("20060102-150405"), unsafeChars.ReplaceAllString(h.Run, "_"))
+
+	return filepath.Join(dir, h.Supervisor, name)
+}
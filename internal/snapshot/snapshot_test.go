@@ -0,0 +1,269 @@
+package snapshot_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/area"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/koolo/internal/action"
+	"github.com/hectorgimenez/koolo/internal/config"
+	"github.com/hectorgimenez/koolo/internal/container"
+	"github.com/hectorgimenez/koolo/internal/health"
+	"github.com/hectorgimenez/koolo/internal/simulator"
+	"github.com/hectorgimenez/koolo/internal/snapshot"
+	"github.com/hectorgimenez/koolo/internal/town"
+)
+
+// update regenerates the checked-in recording: go test ./internal/snapshot -update
+var update = flag.Bool("update", false, "regenerate the recordings in testdata")
+
+const (
+	mapFixture       = "../simulator/testdata/durance_of_hate_2.jsonl"
+	pickupRecording  = "testdata/item_pickup.jsonl.gz"
+	extraFramesAfter = 10
+)
+
+var (
+	startedAt = time.Date(2024, 8, 1, 20, 30, 0, 0, time.UTC)
+	runeword  = data.Item{UnitID: 50, ID: 447, Name: "Monarch", Quality: item.QualityNormal, IsRuneword: true}
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	md, err := simulator.LoadMapData(mapFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	h := snapshot.Header{Supervisor: "sorc", Run: "mephisto/durance", StartedAt: startedAt, MapSeed: 1234, MapData: md}
+	rec := snapshot.NewRecorder(dir, 0)
+	if err = rec.Start(h); err != nil {
+		t.Fatal(err)
+	}
+
+	origin := md.Origin(area.DuranceOfHateLevel2)
+	frames := []data.Data{
+		{PlayerUnit: data.PlayerUnit{Area: area.DuranceOfHateLevel2, Position: data.Position{X: origin.X + 20, Y: origin.Y + 20}}},
+		{PlayerUnit: data.PlayerUnit{Area: area.DuranceOfHateLevel2, Position: data.Position{X: origin.X + 30, Y: origin.Y + 20}}, Inventory: data.Inventory{AllItems: []data.Item{runeword}}},
+	}
+	for _, d := range frames {
+		// Grid is dropped when recording, it must be rebuilt when reading
+		d.CollisionGrid = [][]bool{{true}}
+		if err = rec.Record(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = rec.Finish(); err != nil {
+		t.Fatal(err)
+	}
+	// Frames recorded without a recording started are ignored
+	if err = rec.Record(frames[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	path := snapshot.Path(dir, h)
+	if filepath.Base(path) != "20240801-203000-mephisto_durance.jsonl.gz" {
+		t.Errorf("unexpected recording name %s", filepath.Base(path))
+	}
+
+	got, err := snapshot.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Header.Supervisor != h.Supervisor || got.Header.Run != h.Run || !got.Header.StartedAt.Equal(h.StartedAt) || got.Header.MapSeed != h.MapSeed {
+		t.Errorf("header mismatch, got %+v", got.Header)
+	}
+	if len(got.Frames) != len(frames) {
+		t.Fatalf("expected %d frames, got %d", len(frames), len(got.Frames))
+	}
+
+	grid := md.CollisionGrid(area.DuranceOfHateLevel2)
+	for i, f := range got.Frames {
+		if f.Data.PlayerUnit.Position != frames[i].PlayerUnit.Position || f.Data.PlayerUnit.Area != frames[i].PlayerUnit.Area {
+			t.Errorf("frame %d: expected player at %v, got %v", i, frames[i].PlayerUnit.Position, f.Data.PlayerUnit.Position)
+		}
+		if len(f.Data.CollisionGrid) != len(grid) || len(f.Data.CollisionGrid[0]) != len(grid[0]) {
+			t.Errorf("frame %d: collision grid was not rebuilt from the map data", i)
+		}
+		if i > 0 && f.At.Before(got.Frames[i-1].At) {
+			t.Errorf("frame %d is older than the previous one", i)
+		}
+	}
+	if items := got.Frames[1].Data.Inventory.AllItems; len(items) != 1 || items[0].Name != runeword.Name || !items[0].IsRuneword {
+		t.Errorf("items were not restored, got %v", items)
+	}
+}
+
+func TestReadKeepsFramesOfUnfinishedRecordings(t *testing.T) {
+	dir := t.TempDir()
+	h := snapshot.Header{Supervisor: "sorc", Run: "pindleskin", StartedAt: startedAt}
+	rec := snapshot.NewRecorder(dir, 0)
+	if err := rec.Start(h); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := rec.Record(data.Data{PlayerUnit: data.PlayerUnit{Area: area.NihlathaksTemple}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rec.Finish(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cut the file like a killed process would leave it
+	path := snapshot.Path(dir, h)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(path, raw[:len(raw)-8], 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := snapshot.Load(path)
+	if err != nil {
+		t.Fatalf("expected the frames written so far, got %v", err)
+	}
+	if len(got.Frames) == 0 {
+		t.Error("no frames were read")
+	}
+}
+
+func TestHarnessReplaysItemPickup(t *testing.T) {
+	if config.Koolo == nil {
+		config.Koolo = &config.KooloCfg{}
+	}
+	if *update {
+		recordItemPickup(t)
+	}
+
+	rec, err := snapshot.Load(pickupRecording)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := snapshot.NewHarness(rec, &config.CharacterCfg{}, testLogger())
+	res, err := h.Run(context.Background(), itemPickup(h.Container()), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !res.Finished {
+		t.Fatalf("item pickup did not finish after %d frames", len(res.Steps))
+	}
+
+	// Clicks depend on random waits between attempts, but the pointer is always moved over the item first
+	first := rec.Frames[0].Data
+	ground := first.Inventory.ByLocation(item.LocationGround)
+	if len(ground) != 1 {
+		t.Fatalf("expected the item on the ground in the first frame, found %d items", len(ground))
+	}
+	drop := ground[0]
+	x, y := h.Container().PathFinder.GameCoordsToScreenCords(first.PlayerUnit.Position.X, first.PlayerUnit.Position.Y, drop.Position.X-1, drop.Position.Y-1)
+	moved := slices.ContainsFunc(res.Input(), func(in simulator.Input) bool {
+		return in.Kind == simulator.InputMove && abs(in.X-x) <= 10 && abs(in.Y-y) <= 10
+	})
+	if !moved {
+		t.Errorf("pointer was not moved over the item at %d,%d, input: %v", x, y, res.Input())
+	}
+	if last := rec.Frames[len(rec.Frames)-1].Data; len(last.Inventory.ByLocation(item.LocationGround)) > 0 {
+		t.Error("item is still on the ground at the end of the recording")
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
+
+func itemPickup(c container.Container) action.Action {
+	bm := health.NewBeltManager(c.Logger, c.HID, c.CharacterCfg, c.Supervisor)
+
+	return action.NewBuilder(c, town.ShopManager{}, bm, nil).ItemPickup(false, -1)
+}
+
+// recordItemPickup records a simulated world where a runeword is picked up, the way the bot loop records a run
+func recordItemPickup(t *testing.T) {
+	t.Helper()
+
+	md, err := simulator.LoadMapData(mapFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	origin := md.Origin(area.DuranceOfHateLevel2)
+	start := data.Position{X: origin.X + 20, Y: origin.Y + 20}
+	w, err := simulator.NewWorld(&config.CharacterCfg{}, md, data.Data{}, area.DuranceOfHateLevel2, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	drop := runeword
+	drop.Position = data.Position{X: start.X + 3, Y: start.Y}
+	w.Drop(drop)
+
+	dir := t.TempDir()
+	h := snapshot.Header{Run: "item_pickup", StartedAt: startedAt, MapData: md, GameAreaSizeX: 1280, GameAreaSizeY: 720}
+	rec := snapshot.NewRecorder(dir, 0)
+	if err = rec.Start(h); err != nil {
+		t.Fatal(err)
+	}
+
+	c := w.Container("test", testLogger())
+	act := itemPickup(c)
+	finishedAt := -1
+	for i := 0; finishedAt < 0 || i < finishedAt+extraFramesAfter; i++ {
+		if i > 500 {
+			t.Fatal("item pickup did not finish while recording")
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		d := w.GetData(false)
+		if err = rec.Record(d.Data); err != nil {
+			t.Fatal(err)
+		}
+		if finishedAt >= 0 {
+			continue
+		}
+
+		err = act.NextStep(d, c)
+		switch {
+		case errors.Is(err, action.ErrNoMoreSteps):
+			finishedAt = i
+		case errors.Is(err, action.ErrWillBeRetried), errors.Is(err, action.ErrLogAndContinue):
+		case err != nil:
+			t.Fatal(err)
+		}
+	}
+	if err = rec.Finish(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(pickupRecording), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(snapshot.Path(dir, h))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(pickupRecording, raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,233 @@
+package stash
+
+import (
+	"slices"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+)
+
+const (
+	Width  = 10
+	Height = 10
+
+	// Tabs are numbered like in the game, personal tab is the first one and the rest are shared
+	PersonalTab = 1
+	LastTab     = 4
+)
+
+type Category string
+
+const (
+	CategoryRunes     Category = "runes"
+	CategoryGems      Category = "gems"
+	CategoryCharms    Category = "charms"
+	CategoryJewels    Category = "jewels"
+	CategoryRunewords Category = "runewords"
+	CategoryUniques   Category = "uniques"
+	CategorySets      Category = "sets"
+	CategoryOther     Category = "other"
+)
+
+// CategoryOf returns the category used to route the item to its preferred tabs
+func CategoryOf(i data.Item) Category {
+	t := i.Type()
+	switch {
+	case i.IsRuneword:
+		return CategoryRunewords
+	case t.IsType(item.TypeRune):
+		return CategoryRunes
+	case t.IsType(item.TypeSmallCharm), t.IsType(item.TypeMediumCharm), t.IsType(item.TypeLargeCharm):
+		return CategoryCharms
+	case t.IsType(item.TypeJewel):
+		return CategoryJewels
+	case isGem(t):
+		return CategoryGems
+	case i.Quality == item.QualityUnique:
+		return CategoryUniques
+	case i.Quality == item.QualitySet:
+		return CategorySets
+	}
+
+	return CategoryOther
+}
+
+func isGem(t item.Type) bool {
+	for _, gem := range []string{item.TypeAmethyst, item.TypeDiamond, item.TypeEmerald, item.TypeRuby, item.TypeSapphire, item.TypeTopaz, item.TypeSkull} {
+		if t.IsType(gem) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Tab is the occupancy grid of a single stash tab
+type Tab struct {
+	Number int
+	cells  [Height][Width]bool
+}
+
+func (t *Tab) FreeCells() int {
+	free := 0
+	for y := range t.cells {
+		for x := range t.cells[y] {
+			if !t.cells[y][x] {
+				free++
+			}
+		}
+	}
+
+	return free
+}
+
+// find returns the first free position where an item of the given size fits, scanning like the game does when
+// an item is moved with ctrl+click: top to bottom, then left to right. The game picks the position itself, it's only
+// used to keep the model in sync with the stash.
+func (t *Tab) find(w, h int) (data.Position, bool) {
+	for x := 0; x+w <= Width; x++ {
+		for y := 0; y+h <= Height; y++ {
+			if t.free(x, y, w, h) {
+				return data.Position{X: x, Y: y}, true
+			}
+		}
+	}
+
+	return data.Position{}, false
+}
+
+func (t *Tab) free(x, y, w, h int) bool {
+	for dy := 0; dy < h; dy++ {
+		for dx := 0; dx < w; dx++ {
+			if t.cells[y+dy][x+dx] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (t *Tab) occupy(pos data.Position, w, h int) {
+	for y := pos.Y; y < pos.Y+h && y < Height; y++ {
+		for x := pos.X; x < pos.X+w && x < Width; x++ {
+			t.cells[y][x] = true
+		}
+	}
+}
+
+// Stash models the occupancy of the personal and shared tabs, built from the items read from memory
+type Stash struct {
+	tabs [LastTab]*Tab
+}
+
+func New(items []data.Item) *Stash {
+	s := &Stash{}
+	for i := range s.tabs {
+		s.tabs[i] = &Tab{Number: i + 1}
+	}
+
+	for _, i := range items {
+		tab := TabOf(i)
+		if tab == 0 {
+			continue
+		}
+		desc := i.Desc()
+		s.Tab(tab).occupy(i.Position, desc.InventoryWidth, desc.InventoryHeight)
+	}
+
+	return s
+}
+
+// TabOf returns the tab number where the item is stored, 0 if it's not in the stash
+func TabOf(i data.Item) int {
+	switch i.Location.LocationType {
+	case item.LocationStash:
+		return PersonalTab
+	case item.LocationSharedStash:
+		if i.Location.Page >= 1 && i.Location.Page < LastTab {
+			return i.Location.Page + 1
+		}
+	}
+
+	return 0
+}
+
+func (s *Stash) Tab(number int) *Tab {
+	return s.tabs[number-1]
+}
+
+// FreeCells returns the free cells of every tab, indexed by tab number
+func (s *Stash) FreeCells() map[int]int {
+	free := make(map[int]int, len(s.tabs))
+	for _, t := range s.tabs {
+		free[t.Number] = t.FreeCells()
+	}
+
+	return free
+}
+
+// Placement is the tab an item is moved to, the position inside the tab is decided by the game on ctrl+click
+type Placement struct {
+	Item data.Item
+	Tab  int
+}
+
+// Plan finds a tab for every item using first fit decreasing: biggest items are placed first so the small ones can
+// fill the gaps. Each item tries the tabs preferred for its category first, then the rest of the allowed tabs in
+// order. Placements are sorted by tab to switch tabs as few times as possible, items that don't fit anywhere are
+// returned apart. The stash model is updated with the placements.
+func (s *Stash) Plan(items []data.Item, tabs []int, preferences map[string][]int) ([]Placement, []data.Item) {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b data.Item) int {
+		return size(b) - size(a)
+	})
+
+	placements := make([]Placement, 0, len(items))
+	unplaced := make([]data.Item, 0)
+	for _, i := range sorted {
+		desc := i.Desc()
+		placed := false
+		for _, tab := range tabOrder(tabs, preferences[string(CategoryOf(i))]) {
+			pos, found := s.Tab(tab).find(desc.InventoryWidth, desc.InventoryHeight)
+			if !found {
+				continue
+			}
+			s.Tab(tab).occupy(pos, desc.InventoryWidth, desc.InventoryHeight)
+			placements = append(placements, Placement{Item: i, Tab: tab})
+			placed = true
+			break
+		}
+		if !placed {
+			unplaced = append(unplaced, i)
+		}
+	}
+
+	slices.SortStableFunc(placements, func(a, b Placement) int {
+		return a.Tab - b.Tab
+	})
+
+	return placements, unplaced
+}
+
+// tabOrder puts the preferred tabs first, only the allowed ones
+func tabOrder(allowed, preferred []int) []int {
+	order := make([]int, 0, len(allowed))
+	for _, tab := range preferred {
+		if slices.Contains(allowed, tab) && !slices.Contains(order, tab) {
+			order = append(order, tab)
+		}
+	}
+	for _, tab := range allowed {
+		if !slices.Contains(order, tab) {
+			order = append(order, tab)
+		}
+	}
+
+	return order
+}
+
+func size(i data.Item) int {
+	desc := i.Desc()
+	return desc.InventoryWidth * desc.InventoryHeight
+}
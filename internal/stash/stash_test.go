@@ -0,0 +1,207 @@
+package stash
+
+import (
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+)
+
+// Item IDs used by the tests, with their inventory size
+const (
+	colossusBlade  = 234 // 2x4
+	shako          = 422 // 2x2
+	archonPlate    = 443 // 2x3
+	perfectDiamond = 586 // 1x1
+	smallCharm     = 603 // 1x1
+	grandCharm     = 605 // 1x3
+	berRune        = 639 // 1x1
+	jewel          = 643 // 1x1
+)
+
+var unitIDs data.UnitID
+
+func newItem(id int, quality item.Quality) data.Item {
+	unitIDs++
+	return data.Item{UnitID: unitIDs, ID: id, Name: item.Name(item.Desc[id].Name), Quality: quality}
+}
+
+// stored returns the item placed in the stash tab at the given position
+func stored(i data.Item, tab int, x, y int) data.Item {
+	i.Position = data.Position{X: x, Y: y}
+	i.Location = item.Location{LocationType: item.LocationStash}
+	if tab != PersonalTab {
+		i.Location = item.Location{LocationType: item.LocationSharedStash, Page: tab - 1}
+	}
+
+	return i
+}
+
+func TestCategoryOf(t *testing.T) {
+	runeword := newItem(colossusBlade, item.QualityNormal)
+	runeword.IsRuneword = true
+
+	tests := []struct {
+		item data.Item
+		want Category
+	}{
+		{item: runeword, want: CategoryRunewords},
+		{item: newItem(berRune, item.QualityNormal), want: CategoryRunes},
+		{item: newItem(smallCharm, item.QualityUnique), want: CategoryCharms},
+		{item: newItem(grandCharm, item.QualityMagic), want: CategoryCharms},
+		{item: newItem(jewel, item.QualityRare), want: CategoryJewels},
+		{item: newItem(perfectDiamond, item.QualityNormal), want: CategoryGems},
+		{item: newItem(shako, item.QualityUnique), want: CategoryUniques},
+		{item: newItem(archonPlate, item.QualitySet), want: CategorySets},
+		{item: newItem(archonPlate, item.QualityRare), want: CategoryOther},
+	}
+
+	for _, tc := range tests {
+		if got := CategoryOf(tc.item); got != tc.want {
+			t.Errorf("%s (%s): expected %s, got %s", tc.item.Name, tc.item.Quality.ToString(), tc.want, got)
+		}
+	}
+}
+
+func TestTabFind(t *testing.T) {
+	tab := &Tab{Number: PersonalTab}
+	// First column is full except the last cell, and the second one has a hole of 2 cells at the top
+	tab.occupy(data.Position{X: 0, Y: 0}, 1, Height-1)
+	tab.occupy(data.Position{X: 1, Y: 2}, 1, Height-2)
+
+	tests := []struct {
+		w, h  int
+		want  data.Position
+		found bool
+	}{
+		{w: 1, h: 1, want: data.Position{X: 0, Y: 9}, found: true},
+		{w: 1, h: 2, want: data.Position{X: 1, Y: 0}, found: true},
+		{w: 1, h: 3, want: data.Position{X: 2, Y: 0}, found: true},
+		{w: 2, h: 4, want: data.Position{X: 2, Y: 0}, found: true},
+		{w: Width - 1, h: 1, want: data.Position{X: 1, Y: 0}, found: true},
+		{w: Width, h: 1},
+		{w: 1, h: Height + 1},
+	}
+
+	for _, tc := range tests {
+		got, found := tab.find(tc.w, tc.h)
+		if found != tc.found || got != tc.want {
+			t.Errorf("%dx%d: expected %v (found %t), got %v (found %t)", tc.w, tc.h, tc.want, tc.found, got, found)
+		}
+	}
+
+	full := &Tab{Number: PersonalTab}
+	full.occupy(data.Position{}, Width, Height)
+	if _, found := full.find(1, 1); found {
+		t.Error("found a position in a full tab")
+	}
+	if full.FreeCells() != 0 || tab.FreeCells() != Width*Height-(Height-1)-(Height-2) {
+		t.Errorf("unexpected free cells, full: %d, tab: %d", full.FreeCells(), tab.FreeCells())
+	}
+}
+
+func TestNewReadsEveryTab(t *testing.T) {
+	s := New([]data.Item{
+		stored(newItem(archonPlate, item.QualityRare), PersonalTab, 0, 0),
+		stored(newItem(berRune, item.QualityNormal), 2, 5, 5),
+		stored(newItem(grandCharm, item.QualityMagic), LastTab, 9, 7),
+		// Items outside of the stash are ignored
+		newItem(shako, item.QualityUnique),
+	})
+
+	want := map[int]int{PersonalTab: 94, 2: 99, 3: 100, LastTab: 97}
+	for tab, free := range s.FreeCells() {
+		if free != want[tab] {
+			t.Errorf("tab %d: expected %d free cells, got %d", tab, want[tab], free)
+		}
+	}
+}
+
+func TestPlanPreferredTabs(t *testing.T) {
+	rune1 := newItem(berRune, item.QualityNormal)
+	charm := newItem(grandCharm, item.QualityMagic)
+	unique := newItem(shako, item.QualityUnique)
+	rare := newItem(archonPlate, item.QualityRare)
+
+	preferences := map[string][]int{
+		string(CategoryRunes):  {3},
+		string(CategoryCharms): {4, 2},
+		// Personal tab is not allowed, the rest of the allowed tabs are used in order
+		string(CategoryUniques): {PersonalTab},
+	}
+
+	placements, unplaced := New(nil).Plan([]data.Item{rune1, charm, unique, rare}, []int{2, 3, 4}, preferences)
+	if len(unplaced) != 0 {
+		t.Fatalf("expected every item placed, got %d unplaced", len(unplaced))
+	}
+
+	want := map[data.UnitID]int{rune1.UnitID: 3, charm.UnitID: 4, unique.UnitID: 2, rare.UnitID: 2}
+	for _, p := range placements {
+		if p.Tab != want[p.Item.UnitID] {
+			t.Errorf("%s: expected tab %d, got %d", p.Item.Name, want[p.Item.UnitID], p.Tab)
+		}
+	}
+	// Sorted by tab to switch tabs as few times as possible
+	for i := 1; i < len(placements); i++ {
+		if placements[i].Tab < placements[i-1].Tab {
+			t.Errorf("placements are not sorted by tab: %v", placements)
+		}
+	}
+}
+
+func TestPlanFallsBackWhenPreferredTabIsFull(t *testing.T) {
+	s := New(nil)
+	s.Tab(3).occupy(data.Position{}, Width, Height)
+
+	r := newItem(berRune, item.QualityNormal)
+	placements, _ := s.Plan([]data.Item{r}, []int{PersonalTab, 2, 3, LastTab}, map[string][]int{string(CategoryRunes): {3, LastTab}})
+	if len(placements) != 1 || placements[0].Tab != LastTab {
+		t.Errorf("expected the next preferred tab, got %v", placements)
+	}
+}
+
+func TestPlanFirstFitDecreasing(t *testing.T) {
+	s := New(nil)
+	// A single free area of 2x4 in the last column pair, and a single free cell
+	s.Tab(PersonalTab).occupy(data.Position{}, Width-2, Height)
+	s.Tab(PersonalTab).occupy(data.Position{X: Width - 2, Y: 4}, 2, Height-4)
+	s.Tab(PersonalTab).cells[Height-1][Width-1] = false
+
+	small := newItem(smallCharm, item.QualityMagic)
+	big := newItem(colossusBlade, item.QualityRare)
+	// Placing the charm first would leave no room for the blade
+	placements, unplaced := s.Plan([]data.Item{small, big}, []int{PersonalTab}, nil)
+	if len(placements) != 2 || len(unplaced) != 0 {
+		t.Fatalf("expected both items placed, got %d placed and %d unplaced", len(placements), len(unplaced))
+	}
+	if s.Tab(PersonalTab).FreeCells() != 0 {
+		t.Errorf("expected the tab to be full, %d free cells", s.Tab(PersonalTab).FreeCells())
+	}
+}
+
+func TestPlanReturnsUnplacedItems(t *testing.T) {
+	s := New(nil)
+	for tab := PersonalTab; tab <= LastTab; tab++ {
+		s.Tab(tab).occupy(data.Position{}, Width, Height-2)
+	}
+
+	// Two rows left in each allowed tab, the plate needs three
+	plate := newItem(archonPlate, item.QualityRare)
+	runes := []data.Item{newItem(berRune, item.QualityNormal), newItem(berRune, item.QualityNormal)}
+	placements, unplaced := s.Plan(append([]data.Item{plate}, runes...), []int{2, 3}, nil)
+	if len(unplaced) != 1 || unplaced[0].UnitID != plate.UnitID {
+		t.Errorf("expected the plate unplaced, got %v", unplaced)
+	}
+	if len(placements) != 2 {
+		t.Fatalf("expected the runes placed, got %v", placements)
+	}
+	for _, p := range placements {
+		if p.Tab != 2 {
+			t.Errorf("expected the runes in the first allowed tab, got tab %d", p.Tab)
+		}
+	}
+	// The model is updated with the placements
+	if free := s.Tab(2).FreeCells(); free != 2*Width-2 {
+		t.Errorf("expected %d free cells in tab 2, got %d", 2*Width-2, free)
+	}
+}
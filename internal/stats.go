@@ -1,16 +1,15 @@
 package koolo
 
 import (
-	"bufio"
 	"context"
-	"fmt"
+	"errors"
 	"log/slog"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/hectorgimenez/d2go/pkg/data"
 	"github.com/hectorgimenez/koolo/internal/event"
+	"github.com/hectorgimenez/koolo/internal/stats"
 )
 
 const (
@@ -19,20 +18,35 @@ const (
 	InGame     SupervisorStatus = "In game"
 	Paused     SupervisorStatus = "Paused"
 	Crashed    SupervisorStatus = "Crashed"
+	OnBreak    SupervisorStatus = "On break"
 )
 
 type SupervisorStatus string
 
 type StatsHandler struct {
-	stats  *Stats
-	name   string
-	logger *slog.Logger
+	stats   *Stats
+	history *stats.Store
+	name    string
+	logger  *slog.Logger
 }
 
 func NewStatsHandler(name string, logger *slog.Logger) *StatsHandler {
+	return NewStatsHandlerInDirectory(name, stats.DefaultDirectory, logger)
+}
+
+// NewStatsHandlerInDirectory persists the stats history in a custom directory, used to avoid mixing replayed
+// events with the real history
+func NewStatsHandlerInDirectory(name, dir string, logger *slog.Logger) *StatsHandler {
+	history, err := stats.Open(dir, name)
+	if err != nil {
+		// Stats history is not critical, keep running with in-memory stats only
+		logger.Error("Error opening stats history, it will not be persisted", slog.Any("error", err))
+	}
+
 	return &StatsHandler{
-		name:   name,
-		logger: logger,
+		name:    name,
+		logger:  logger,
+		history: history,
 		stats: &Stats{
 			SupervisorStatus: Starting,
 			StartedAt:        time.Now(),
@@ -52,11 +66,17 @@ func (h *StatsHandler) Handle(_ context.Context, e event.Event) error {
 			StartedAt: evt.OccurredAt(),
 		})
 		h.stats.SupervisorStatus = InGame
+		h.stats.NextSessionAt = time.Time{}
 	case event.GameFinishedEvent:
+		if len(h.stats.Games) == 0 {
+			break
+		}
 		h.stats.Games[len(h.stats.Games)-1].FinishedAt = evt.OccurredAt()
 		h.stats.Games[len(h.stats.Games)-1].Reason = evt.Reason
-		h.stats.Games[len(h.stats.Games)-1].Runs[len(h.stats.Games[len(h.stats.Games)-1].Runs)-1].FinishedAt = evt.OccurredAt()
-		h.stats.Games[len(h.stats.Games)-1].Runs[len(h.stats.Games[len(h.stats.Games)-1].Runs)-1].Reason = evt.Reason
+		if run := h.currentRun(); run != nil {
+			run.FinishedAt = evt.OccurredAt()
+			run.Reason = evt.Reason
+		}
 	case event.RunStartedEvent:
 		if len(h.stats.Games) == 0 {
 			h.stats.Games = append(h.stats.Games, GameStats{
@@ -75,80 +95,72 @@ func (h *StatsHandler) Handle(_ context.Context, e event.Event) error {
 			h.stats.SupervisorStatus = InGame
 		}
 	case event.RunFinishedEvent:
-		h.stats.Games[len(h.stats.Games)-1].Runs[len(h.stats.Games[len(h.stats.Games)-1].Runs)-1].FinishedAt = evt.OccurredAt()
-		h.stats.Games[len(h.stats.Games)-1].Runs[len(h.stats.Games[len(h.stats.Games)-1].Runs)-1].Reason = evt.Reason
+		if run := h.currentRun(); run != nil {
+			run.FinishedAt = evt.OccurredAt()
+			run.Reason = evt.Reason
+		}
 	case event.ItemStashedEvent:
 		// The hell is this Hector o.O
 		//h.stats.Games[len(h.stats.Games)-1].Runs[len(h.stats.Games[len(h.stats.Games)-1].Runs)-1].Items = append(h.stats.Games[len(h.stats.Games)-1].Runs[len(h.stats.Games[len(h.stats.Games)-1].Runs)-1].Items, evt.Item)
 
 		// Ain't this much easier?
 		h.stats.Drops = append(h.stats.Drops, evt.Item)
+	case event.SessionBreakEvent:
+		h.stats.SupervisorStatus = OnBreak
+		h.stats.NextSessionAt = evt.NextStart
 	case event.UsedPotionEvent:
-		h.stats.Games[len(h.stats.Games)-1].Runs[len(h.stats.Games[len(h.stats.Games)-1].Runs)-1].UsedPotions = append(h.stats.Games[len(h.stats.Games)-1].Runs[len(h.stats.Games[len(h.stats.Games)-1].Runs)-1].UsedPotions, evt)
+		if run := h.currentRun(); run != nil {
+			run.UsedPotions = append(run.UsedPotions, evt)
+		}
 	}
 
-	return nil
+	return h.persist(e)
 }
 
-func (h *StatsHandler) updateGameStatsFile() {
-	if _, err := os.Stat("stats"); os.IsNotExist(err) {
-		err = os.MkdirAll("stats", os.ModePerm)
-		if err != nil {
-			h.logger.Error("Error creating stats directory", slog.Any("error", err))
-			return
-		}
+// currentRun returns the last run of the last game, nil if there is none (events received mid-game)
+func (h *StatsHandler) currentRun() *RunStats {
+	if len(h.stats.Games) == 0 {
+		return nil
 	}
 
-	fileName := fmt.Sprintf("stats/stats_%s_%s.txt", h.name, h.stats.StartedAt.Format("2006-02-01-15_04_05"))
-	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		h.logger.Error("Error writing game stats", slog.Any("error", err))
-		return
+	game := &h.stats.Games[len(h.stats.Games)-1]
+	if len(game.Runs) == 0 {
+		return nil
+	}
+
+	return &game.Runs[len(game.Runs)-1]
+}
+
+func (h *StatsHandler) persist(e event.Event) error {
+	if h.history == nil {
+		return nil
 	}
-	w := bufio.NewWriter(f)
-
-	//for _, game := range h.stats.Games {
-	//	var items = ""
-	//	for _, item := range rs.ItemsFound {
-	//		items += fmt.Sprintf("%s [%d]\n", item.Name, item.Quality)
-	//	}
-	//	avgRunTime := rs.RunningTime.Seconds() / float64(rs.Errors+rs.Kills+rs.Deaths+rs.Chickens+rs.MerChicken)
-	//	statsRun := fmt.Sprintf("Stats for: %s\n"+
-	//		"    Run time: %0.2fs (Total) %0.2fs (Average)\n"+
-	//		"    Kills: %d\n"+
-	//		"    Deaths: %d\n"+
-	//		"    Chickens: %d\n"+
-	//		"    Merc Chickens: %d\n"+
-	//		"    Errors: %d\n"+
-	//		"    Used HP Potions: %d\n"+
-	//		"    Used MP Potions: %d\n"+
-	//		"    Used Rejuv Potions: %d\n"+
-	//		"    Used Merc HP Potions: %d\n"+
-	//		"    Used Merc Rejuv Potions: %d\n"+
-	//		"    Items: \n"+
-	//		"    %s",
-	//		runName,
-	//		rs.RunningTime.Seconds(), avgRunTime,
-	//		rs.Kills,
-	//		rs.Deaths,
-	//		rs.Chickens,
-	//		rs.MerChicken,
-	//		rs.Errors,
-	//		rs.HealingPotionsUsed,
-	//		rs.ManaPotionsUsed,
-	//		rs.RejuvPotionsUsed,
-	//		rs.MercHealingPotionsUsed,
-	//		rs.MercRejuvPotionsUsed,
-	//		items,
-	//	)
-	//	_, err = w.WriteString(statsRun + "\n")
-	//	if err != nil {
-	//		s.logger.Error("Error writing stats file", slog.Any("error", err))
-	//	}
-	//}
-
-	w.Flush()
-	f.Close()
+
+	record, ok := stats.FromEvent(e)
+	if !ok {
+		return nil
+	}
+
+	// Handler is not unregistered when the supervisor stops, ignore events after closing the history
+	if err := h.history.Append(record); err != nil && !errors.Is(err, stats.ErrStoreClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// History returns the persisted stats for this supervisor, including previous Koolo executions. It can be nil
+// if the history file could not be opened.
+func (h *StatsHandler) History() *stats.Store {
+	return h.history
+}
+
+func (h *StatsHandler) Close() error {
+	if h.history == nil {
+		return nil
+	}
+
+	return h.history.Close()
 }
 
 func (h *StatsHandler) Stats() Stats {
@@ -161,6 +173,8 @@ type Stats struct {
 	Details          string
 	Drops            []data.Drop
 	Games            []GameStats
+	// NextSessionAt is set while the supervisor is on a break
+	NextSessionAt time.Time
 }
 
 type GameStats struct {
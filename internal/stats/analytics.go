@@ -0,0 +1,169 @@
+package stats
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+// RunAnalytics aggregates every finished execution of a run with the same name
+type RunAnalytics struct {
+	Name          string
+	Runs          int
+	P50           time.Duration
+	P90           time.Duration
+	P99           time.Duration
+	Reasons       map[event.FinishReason]int
+	PotionsUsed   int
+	ItemsStashed  int
+	SuccessRate   float64
+	ChickenRate   float64
+	DeathRate     float64
+	ErrorRate     float64
+	PotionsPerRun float64
+	ItemsPerRun   float64
+	durations     []time.Duration
+}
+
+func (a *RunAnalytics) calculate() {
+	slices.Sort(a.durations)
+	a.P50 = percentile(a.durations, 50)
+	a.P90 = percentile(a.durations, 90)
+	a.P99 = percentile(a.durations, 99)
+
+	if a.Runs == 0 {
+		return
+	}
+
+	runs := float64(a.Runs)
+	a.SuccessRate = float64(a.Reasons[event.FinishedOK]) / runs
+	a.ChickenRate = float64(a.Reasons[event.FinishedChicken]+a.Reasons[event.FinishedMercChicken]) / runs
+	a.DeathRate = float64(a.Reasons[event.FinishedDied]) / runs
+	a.ErrorRate = float64(a.Reasons[event.FinishedError]) / runs
+	a.PotionsPerRun = float64(a.PotionsUsed) / runs
+	a.ItemsPerRun = float64(a.ItemsStashed) / runs
+}
+
+// AnalyzeRuns groups the records by run name, records must be sorted by time as they are stored.
+// Stashed items are attributed to the run they were picked up in, items are stashed in town when the next run
+// already started. Potions are attributed to the run in progress when they were used. A run is considered finished
+// by its RunFinished record or by the GameFinished record of the game it was running in, errors on actions that
+// can be skipped don't finish it.
+func AnalyzeRuns(records []Record) []RunAnalytics {
+	byName := make(map[string]*RunAnalytics)
+	var current *RunAnalytics
+	var currentStartedAt time.Time
+
+	finishCurrent := func(at time.Time, reason event.FinishReason) {
+		if current == nil {
+			return
+		}
+		current.Runs++
+		current.Reasons[reason]++
+		current.durations = append(current.durations, at.Sub(currentStartedAt))
+		current = nil
+	}
+
+	analyticsFor := func(name string) *RunAnalytics {
+		a, found := byName[name]
+		if !found {
+			a = &RunAnalytics{
+				Name:    name,
+				Reasons: make(map[event.FinishReason]int),
+			}
+			byName[name] = a
+		}
+
+		return a
+	}
+
+	for _, r := range records {
+		switch r.Type {
+		case RecordGameCreated:
+			// Previous game was never finished properly (crash, koolo closed...), we can't know the duration
+			current = nil
+		case RecordRunStarted:
+			current = analyticsFor(r.RunName)
+			currentStartedAt = r.OccurredAt
+		case RecordRunFinished:
+			if !r.ActionSkipped {
+				finishCurrent(r.OccurredAt, r.Reason)
+			}
+		case RecordGameFinished:
+			finishCurrent(r.OccurredAt, r.Reason)
+		case RecordUsedPotion:
+			if current != nil {
+				current.PotionsUsed++
+			}
+		case RecordItemStashed:
+			// Older records don't know where the item was picked up
+			if r.RunName != "" {
+				analyticsFor(r.RunName).ItemsStashed++
+			} else if current != nil {
+				current.ItemsStashed++
+			}
+		}
+	}
+
+	analytics := make([]RunAnalytics, 0, len(byName))
+	for _, a := range byName {
+		a.calculate()
+		analytics = append(analytics, *a)
+	}
+	sort.Slice(analytics, func(i, j int) bool {
+		return analytics[i].Name < analytics[j].Name
+	})
+
+	return analytics
+}
+
+// percentile uses the nearest-rank method, durations must be sorted
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(durations))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return durations[rank-1]
+}
+
+// RunResult is a single finished execution of a run
+type RunResult struct {
+	Name       string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Reason     event.FinishReason
+}
+
+// RunResults returns every finished run in the records, sorted by time. Same as AnalyzeRuns, a run is finished by
+// its RunFinished record or by the GameFinished record of the game it was running in, skipped actions are ignored.
+func RunResults(records []Record) []RunResult {
+	results := make([]RunResult, 0)
+	var current *RunResult
+
+	for _, r := range records {
+		switch r.Type {
+		case RecordGameCreated:
+			current = nil
+		case RecordRunStarted:
+			current = &RunResult{Name: r.RunName, StartedAt: r.OccurredAt}
+		case RecordRunFinished, RecordGameFinished:
+			if current == nil || r.ActionSkipped {
+				continue
+			}
+			current.FinishedAt = r.OccurredAt
+			current.Reason = r.Reason
+			results = append(results, *current)
+			current = nil
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+func TestAnalyzeRunsAttributesItemsToPickupRun(t *testing.T) {
+	at := time.Now()
+	next := func() time.Time {
+		at = at.Add(time.Minute)
+		return at
+	}
+
+	records := []Record{
+		{Type: RecordGameCreated, OccurredAt: next()},
+		{Type: RecordRunStarted, RunName: "pit", OccurredAt: next()},
+		{Type: RecordRunFinished, RunName: "pit", Reason: event.FinishedOK, OccurredAt: next()},
+		// Items found in the Pit are stashed before the next run, but after it started
+		{Type: RecordRunStarted, RunName: "ancient_tunnels", OccurredAt: next()},
+		{Type: RecordItemStashed, RunName: "pit", OccurredAt: next()},
+		{Type: RecordItemStashed, RunName: "pit", OccurredAt: next()},
+		{Type: RecordRunFinished, RunName: "ancient_tunnels", Reason: event.FinishedOK, OccurredAt: next()},
+	}
+
+	analytics := byRunName(AnalyzeRuns(records))
+	if analytics["pit"].ItemsStashed != 2 {
+		t.Errorf("expected 2 items for pit, got %d", analytics["pit"].ItemsStashed)
+	}
+	if analytics["ancient_tunnels"].ItemsStashed != 0 {
+		t.Errorf("expected 0 items for ancient_tunnels, got %d", analytics["ancient_tunnels"].ItemsStashed)
+	}
+}
+
+func TestAnalyzeRunsIgnoresSkippedActions(t *testing.T) {
+	start := time.Now()
+	records := []Record{
+		{Type: RecordGameCreated, OccurredAt: start},
+		{Type: RecordRunStarted, RunName: "pit", OccurredAt: start},
+		{Type: RecordRunFinished, RunName: "pit", Reason: event.FinishedError, ActionSkipped: true, OccurredAt: start.Add(time.Minute)},
+		{Type: RecordUsedPotion, OccurredAt: start.Add(2 * time.Minute)},
+		{Type: RecordRunFinished, RunName: "pit", Reason: event.FinishedOK, OccurredAt: start.Add(3 * time.Minute)},
+	}
+
+	pit := byRunName(AnalyzeRuns(records))["pit"]
+	if pit.Runs != 1 || pit.Reasons[event.FinishedOK] != 1 || pit.Reasons[event.FinishedError] != 0 {
+		t.Errorf("skipped action should not finish the run, got %d runs and reasons %v", pit.Runs, pit.Reasons)
+	}
+	if pit.PotionsUsed != 1 {
+		t.Errorf("potions used after the skipped action belong to the run, got %d", pit.PotionsUsed)
+	}
+	if pit.P50 != 3*time.Minute {
+		t.Errorf("expected duration of 3m, got %s", pit.P50)
+	}
+
+	if results := RunResults(records); len(results) != 1 || results[0].Reason != event.FinishedOK {
+		t.Errorf("expected a single successful result, got %+v", results)
+	}
+}
+
+func byRunName(analytics []RunAnalytics) map[string]RunAnalytics {
+	m := make(map[string]RunAnalytics)
+	for _, a := range analytics {
+		m[a.Name] = a
+	}
+
+	return m
+}
@@ -0,0 +1,271 @@
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+const (
+	RecordGameCreated  RecordType = "game_created"
+	RecordGameFinished RecordType = "game_finished"
+	RecordRunStarted   RecordType = "run_started"
+	RecordRunFinished  RecordType = "run_finished"
+	RecordUsedPotion   RecordType = "used_potion"
+	RecordItemStashed  RecordType = "item_stashed"
+
+	DefaultDirectory = "stats"
+
+	// MemoryRetention is how long the records are kept in memory, older ones are only read from disk when requested
+	MemoryRetention = 30 * 24 * time.Hour
+)
+
+var ErrStoreClosed = errors.New("stats store is closed")
+
+type RecordType string
+
+// Record is a single line of the history file, only the fields relevant to the Type are filled
+type Record struct {
+	Type       RecordType         `json:"type"`
+	Supervisor string             `json:"supervisor"`
+	OccurredAt time.Time          `json:"occurredAt"`
+	RunName    string             `json:"runName,omitempty"`
+	Reason     event.FinishReason `json:"reason,omitempty"`
+	PotionType data.PotionType    `json:"potionType,omitempty"`
+	OnMerc     bool               `json:"onMerc,omitempty"`
+	Item       *data.Drop         `json:"item,omitempty"`
+	// ActionSkipped run finished records don't finish the run, an action failed and the run continued
+	ActionSkipped bool `json:"actionSkipped,omitempty"`
+}
+
+// FromEvent converts the event into a Record, returns false if the event is not meant to be stored
+func FromEvent(e event.Event) (Record, bool) {
+	r := Record{
+		Supervisor: e.Supervisor(),
+		OccurredAt: e.OccurredAt(),
+	}
+
+	switch evt := e.(type) {
+	case event.GameCreatedEvent:
+		r.Type = RecordGameCreated
+	case event.GameFinishedEvent:
+		r.Type = RecordGameFinished
+		r.Reason = evt.Reason
+	case event.RunStartedEvent:
+		r.Type = RecordRunStarted
+		r.RunName = evt.RunName
+	case event.RunFinishedEvent:
+		r.Type = RecordRunFinished
+		r.RunName = evt.RunName
+		r.Reason = evt.Reason
+		r.ActionSkipped = evt.ActionSkipped
+	case event.UsedPotionEvent:
+		r.Type = RecordUsedPotion
+		r.PotionType = evt.PotionType
+		r.OnMerc = evt.OnMerc
+	case event.ItemStashedEvent:
+		r.Type = RecordItemStashed
+		r.RunName = evt.RunName
+		drop := evt.Item
+		r.Item = &drop
+	default:
+		return Record{}, false
+	}
+
+	return r, true
+}
+
+// Store is an append-only history of records for a single supervisor, persisted as JSON lines.
+// Records of the last MemoryRetention are kept in memory as well, sorted by time, so recent queries don't need to
+// touch the disk.
+type Store struct {
+	mu      sync.RWMutex
+	path    string
+	file    *os.File
+	closed  bool
+	records []Record
+	// trimmed is true when older records were removed from memory
+	trimmed bool
+}
+
+func Open(dir, supervisor string) (*Store, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("error creating stats directory: %w", err)
+	}
+
+	path := filepath.Join(dir, supervisor+".jsonl")
+	records, err := readRecords(path)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(records, compareRecords)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening stats file: %w", err)
+	}
+
+	s := &Store{
+		path:    path,
+		file:    f,
+		records: records,
+	}
+	s.trim(time.Now())
+
+	return s, nil
+}
+
+// ReadHistory loads the stored records of a supervisor without opening the file for writing, useful when
+// the supervisor is not running
+func ReadHistory(dir, supervisor string) ([]Record, error) {
+	return readRecords(filepath.Join(dir, supervisor+".jsonl"))
+}
+
+func readRecords(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading stats file: %w", err)
+	}
+	defer f.Close()
+
+	records := make([]Record, 0)
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var r Record
+		// A truncated last line (process killed while writing) is skipped, not fatal
+		if err = json.Unmarshal(sc.Bytes(), &r); err != nil {
+			continue
+		}
+		records = append(records, r)
+	}
+
+	return records, sc.Err()
+}
+
+func (s *Store) Append(r Record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	if _, err = s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing stats file: %w", err)
+	}
+
+	// Events are delivered asynchronously, they may arrive slightly out of order
+	idx := len(s.records)
+	for idx > 0 && s.records[idx-1].OccurredAt.After(r.OccurredAt) {
+		idx--
+	}
+	s.records = slices.Insert(s.records, idx, r)
+	s.trim(time.Now())
+
+	return nil
+}
+
+// trim removes the records older than MemoryRetention from memory, it's done once a day worth of records expired
+// to avoid copying the records on every append
+func (s *Store) trim(now time.Time) {
+	if len(s.records) == 0 || now.Sub(s.records[0].OccurredAt) < MemoryRetention+24*time.Hour {
+		return
+	}
+
+	idx := s.search(now.Add(-MemoryRetention))
+	s.records = slices.Clone(s.records[idx:])
+	s.trimmed = true
+}
+
+// search returns the index of the first record that occurred at t or later
+func (s *Store) search(t time.Time) int {
+	idx, _ := slices.BinarySearchFunc(s.records, t, func(r Record, t time.Time) int {
+		if r.OccurredAt.Before(t) {
+			return -1
+		}
+		return 1
+	})
+
+	return idx
+}
+
+// Records returns the records that occurred in the [from, to) window, zero values mean unbounded. Records older than
+// the ones kept in memory are read from disk.
+func (s *Store) Records(from, to time.Time) []Record {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.trimmed && (len(s.records) == 0 || from.Before(s.records[0].OccurredAt)) {
+		records, err := readRecords(s.path)
+		if err == nil {
+			slices.SortStableFunc(records, compareRecords)
+			return window(records, from, to)
+		}
+	}
+
+	start := 0
+	if !from.IsZero() {
+		start = s.search(from)
+	}
+	end := len(s.records)
+	if !to.IsZero() {
+		end = s.search(to)
+	}
+	if start >= end {
+		return []Record{}
+	}
+
+	return slices.Clone(s.records[start:end])
+}
+
+func (s *Store) Query(from, to time.Time) Summary {
+	return Summarize(s.Records(from, to), from, to)
+}
+
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	return s.file.Close()
+}
+
+func window(records []Record, from, to time.Time) []Record {
+	filtered := make([]Record, 0)
+	for _, r := range records {
+		if !from.IsZero() && r.OccurredAt.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !r.OccurredAt.Before(to) {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+
+	return filtered
+}
+
+func compareRecords(a, b Record) int {
+	return a.OccurredAt.Compare(b.OccurredAt)
+}
@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+func TestStoreRecordsWindow(t *testing.T) {
+	s, err := Open(t.TempDir(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	start := time.Now().Add(-time.Hour)
+	// Last record arrives out of order, it must be returned sorted anyway
+	for _, minute := range []int{0, 10, 30, 20} {
+		if err = s.Append(Record{Type: RecordGameCreated, OccurredAt: start.Add(time.Duration(minute) * time.Minute)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records := s.Records(start.Add(10*time.Minute), start.Add(30*time.Minute))
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records in the window, got %d", len(records))
+	}
+	if !records[0].OccurredAt.Before(records[1].OccurredAt) {
+		t.Errorf("records should be sorted by time")
+	}
+
+	if all := s.Records(time.Time{}, time.Time{}); len(all) != 4 {
+		t.Errorf("expected 4 records, got %d", len(all))
+	}
+}
+
+func TestStoreReadsOldRecordsFromDisk(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := time.Now().Add(-MemoryRetention - 48*time.Hour)
+	_ = s.Append(Record{Type: RecordGameCreated, OccurredAt: old})
+	_ = s.Append(Record{Type: RecordGameCreated, OccurredAt: time.Now()})
+	s.Close()
+
+	s, err = Open(dir, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if len(s.records) != 1 {
+		t.Errorf("only recent records should be kept in memory, got %d", len(s.records))
+	}
+	if records := s.Records(old, time.Time{}); len(records) != 2 {
+		t.Errorf("old records should be read from disk, got %d", len(records))
+	}
+}
+
+func TestSummarizeCountsFailedGamesOnce(t *testing.T) {
+	now := time.Now()
+	records := []Record{
+		{Type: RecordGameCreated, OccurredAt: now},
+		{Type: RecordRunStarted, RunName: "pit", OccurredAt: now},
+		{Type: RecordRunFinished, RunName: "pit", Reason: event.FinishedError, OccurredAt: now},
+		{Type: RecordGameFinished, Reason: event.FinishedError, OccurredAt: now},
+	}
+
+	if s := Summarize(records, time.Time{}, time.Time{}); s.Errors != 1 {
+		t.Errorf("expected 1 error, got %d", s.Errors)
+	}
+}
@@ -0,0 +1,96 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/koolo/internal/event"
+)
+
+type Summary struct {
+	From     time.Time
+	To       time.Time
+	Games    int
+	Runs     int
+	Deaths   int
+	Chickens int
+	// Errors are the games finished by an error, failed runs are in the run analytics
+	Errors      int
+	Drops       int
+	PotionsUsed map[data.PotionType]int
+}
+
+// Summarize aggregates the given records, from and to are used to calculate rates, if zero the first and
+// last record timestamps are used instead
+func Summarize(records []Record, from, to time.Time) Summary {
+	s := Summary{
+		From:        from,
+		To:          to,
+		PotionsUsed: make(map[data.PotionType]int),
+	}
+
+	for _, r := range records {
+		if from.IsZero() && (s.From.IsZero() || r.OccurredAt.Before(s.From)) {
+			s.From = r.OccurredAt
+		}
+		if to.IsZero() && r.OccurredAt.After(s.To) {
+			s.To = r.OccurredAt
+		}
+
+		switch r.Type {
+		case RecordGameCreated:
+			s.Games++
+		case RecordRunStarted:
+			s.Runs++
+		case RecordGameFinished:
+			switch r.Reason {
+			case event.FinishedDied:
+				s.Deaths++
+			case event.FinishedChicken, event.FinishedMercChicken:
+				s.Chickens++
+			case event.FinishedError:
+				s.Errors++
+			}
+		case RecordUsedPotion:
+			s.PotionsUsed[r.PotionType]++
+		case RecordItemStashed:
+			s.Drops++
+		}
+	}
+
+	return s
+}
+
+func (s Summary) Duration() time.Duration {
+	if s.From.IsZero() || s.To.IsZero() {
+		return 0
+	}
+
+	return s.To.Sub(s.From)
+}
+
+func (s Summary) RunsPerHour() float64 {
+	hours := s.Duration().Hours()
+	if hours == 0 {
+		return 0
+	}
+
+	return float64(s.Runs) / hours
+}
+
+// DeathRate is the ratio of games finished by a character death
+func (s Summary) DeathRate() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+
+	return float64(s.Deaths) / float64(s.Games)
+}
+
+func (s Summary) DropsPerRun() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+
+	return float64(s.Drops) / float64(s.Runs)
+}
@@ -11,9 +11,12 @@ import (
 	"time"
 
 	"github.com/hectorgimenez/koolo/internal/container"
+	"github.com/hectorgimenez/koolo/internal/event"
 	"github.com/hectorgimenez/koolo/internal/game"
 	"github.com/hectorgimenez/koolo/internal/helper/winproc"
 	"github.com/hectorgimenez/koolo/internal/run"
+	"github.com/hectorgimenez/koolo/internal/session"
+	"github.com/hectorgimenez/koolo/internal/stats"
 	"github.com/lxn/win"
 )
 
@@ -22,6 +25,7 @@ type Supervisor interface {
 	Name() string
 	Stop()
 	Stats() Stats
+	History() *stats.Store
 	TogglePause()
 	SetWindowPosition(x, y int)
 	GetData() game.Data
@@ -32,10 +36,27 @@ type Supervisor interface {
 type baseSupervisor struct {
 	bot          *Bot
 	runFactory   *run.Factory
+	scheduler    *run.Scheduler
+	session      *session.Planner
 	name         string
 	statsHandler *StatsHandler
 	cancelFn     context.CancelFunc
 	c            container.Container
+	// gr is the reader attached to the game process, container only exposes the game data
+	gr *game.MemoryReader
+	// killGameOnStop closes D2R on stop even if KillD2OnStop is disabled, used for session breaks
+	killGameOnStop bool
+}
+
+// SessionBreakError is returned by the supervisor when it stops to take a break with the game closed, it has to be
+// started again at NextStart
+type SessionBreakError struct {
+	NextStart time.Time
+	Reason    string
+}
+
+func (e SessionBreakError) Error() string {
+	return fmt.Sprintf("taking a break until %s: %s", e.NextStart.Format(time.DateTime), e.Reason)
 }
 
 func newBaseSupervisor(
@@ -44,13 +65,17 @@ func newBaseSupervisor(
 	name string,
 	statsHandler *StatsHandler,
 	c container.Container,
+	gr *game.MemoryReader,
 ) (*baseSupervisor, error) {
 	return &baseSupervisor{
 		bot:          bot,
 		runFactory:   runFactory,
+		scheduler:    run.NewScheduler(c.Logger, statsHandler.History()),
+		session:      session.NewPlanner(statsHandler.History()),
 		name:         name,
 		statsHandler: statsHandler,
 		c:            c,
+		gr:           gr,
 	}, nil
 }
 
@@ -62,16 +87,20 @@ func (s *baseSupervisor) Stats() Stats {
 	return s.statsHandler.Stats()
 }
 
+func (s *baseSupervisor) History() *stats.Store {
+	return s.statsHandler.History()
+}
+
 func (s *baseSupervisor) GetData() game.Data {
 	return s.c.Reader.GetData(false)
 }
 
 func (s *baseSupervisor) GetMapSeed() string {
-	return strconv.Itoa(int(s.c.Reader.CachedMapSeed))
+	return strconv.Itoa(int(s.c.Reader.MapSeed()))
 }
 
 func (s *baseSupervisor) GetImg() (image.Image, error) {
-	return s.c.Reader.ScreenshotWithRelease()
+	return s.c.Screenshotter.ScreenshotWithRelease()
 }
 
 func (s *baseSupervisor) TogglePause() {
@@ -85,10 +114,14 @@ func (s *baseSupervisor) Stop() {
 	}
 
 	s.c.Injector.Unload()
-	s.c.Reader.Close()
+	s.gr.Close()
+
+	if err := s.statsHandler.Close(); err != nil {
+		s.c.Logger.Error("Error closing stats history", slog.Any("error", err))
+	}
 
-	if s.c.CharacterCfg.KillD2OnStop {
-		process, err := os.FindProcess(int(s.c.Reader.Process.GetPID()))
+	if s.c.CharacterCfg.KillD2OnStop || s.killGameOnStop {
+		process, err := os.FindProcess(int(s.gr.Process.GetPID()))
 		if err != nil {
 			s.c.Logger.Info("Failed to find process", slog.String("configuration", s.name))
 		}
@@ -115,13 +148,39 @@ func (s *baseSupervisor) logGameStart(runs []run.Run) {
 	s.c.Logger.Info(fmt.Sprintf("Starting Game #%d. Run list: %s", s.statsHandler.Stats().TotalGames(), runNames[:len(runNames)-2]))
 }
 
+// takeBreak exits the game and waits until the next session, if the game has to be closed during the break it
+// returns SessionBreakError instead of waiting
+func (s *baseSupervisor) takeBreak(ctx context.Context, nextStart time.Time, reason string) error {
+	msg := fmt.Sprintf("Taking a break until %s, %s", nextStart.Format(time.DateTime), reason)
+	s.c.Logger.Info(msg)
+	event.Send(event.SessionBreak(event.Text(s.name, msg), nextStart, reason))
+
+	if s.c.Manager.InGame() {
+		if err := s.c.Manager.ExitGame(); err != nil {
+			return fmt.Errorf("error exiting game before the break: %w", err)
+		}
+	}
+
+	if s.c.CharacterCfg.Session.CloseGame {
+		s.killGameOnStop = true
+		return SessionBreakError{NextStart: nextStart, Reason: reason}
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Until(nextStart)):
+	}
+
+	return nil
+}
+
 func (s *baseSupervisor) waitUntilCharacterSelectionScreen() error {
 	s.c.Logger.Info("Waiting for character selection screen...")
-	for !s.c.Reader.GameReader.InCharacterSelectionScreen() {
+	for !s.gr.GameReader.InCharacterSelectionScreen() {
 		s.c.HID.Click(game.LeftButton, 100, 100)
 		time.Sleep(time.Second)
 	}
-	for s.c.Reader.GameReader.GetSelectedCharacterName() == "" {
+	for s.gr.GameReader.GetSelectedCharacterName() == "" {
 		s.c.HID.Click(game.LeftButton, 100, 100)
 		time.Sleep(time.Second)
 	}
@@ -134,7 +193,7 @@ func (s *baseSupervisor) waitUntilCharacterSelectionScreen() error {
 		s.c.Logger.Info("Selecting character...")
 		previousSelection := ""
 		for {
-			characterName := s.c.Reader.GameReader.GetSelectedCharacterName()
+			characterName := s.gr.GameReader.GetSelectedCharacterName()
 			if strings.EqualFold(previousSelection, characterName) {
 				return fmt.Errorf("character %s not found", s.c.CharacterCfg.CharacterName)
 			}
@@ -143,7 +202,7 @@ func (s *baseSupervisor) waitUntilCharacterSelectionScreen() error {
 				return nil
 			}
 
-			s.c.HID.PressKey(win.VK_DOWN)
+			s.c.HID.PressKey(game.VKDown)
 			time.Sleep(time.Millisecond * 500)
 			previousSelection = characterName
 		}
@@ -154,5 +213,5 @@ func (s *baseSupervisor) waitUntilCharacterSelectionScreen() error {
 
 func (s *baseSupervisor) SetWindowPosition(x, y int) {
 	uFlags := win.SWP_NOZORDER | win.SWP_NOSIZE | win.SWP_NOACTIVATE
-	win.SetWindowPos(s.c.Reader.HWND, 0, int32(x), int32(y), 0, 0, uint32(uFlags))
+	win.SetWindowPos(s.gr.HWND, 0, int32(x), int32(y), 0, 0, uint32(uFlags))
 }
@@ -7,10 +7,10 @@ import (
 )
 
 type Manager struct {
-	gr *game.MemoryReader
+	gr game.DataReader
 }
 
-func NewManager(gr *game.MemoryReader) *Manager {
+func NewManager(gr game.DataReader) *Manager {
 	return &Manager{
 		gr: gr,
 	}